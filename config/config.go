@@ -1,79 +1,111 @@
 package config
 
 import (
-	"paystore/lib/helper"
+	"paystore/lib/schema"
 	"paystore/user"
 	"time"
 )
 
 type App struct {
-	ItemPerPage              int64
-	RecordAge                time.Duration
-	PaginationAge            time.Duration
-	PaymentVendorTableAlias  string
-	PaymentVendorTableName   string
-	paymentVendorSampleItem  *user.PaymentVendor
-	WithdrawVendorTableAlias string
-	WithdrawVendorTableName  string
-	withdrawVendorSampleItem *user.WithdrawVendor
+	ItemPerPage             int64
+	RecordAge               time.Duration
+	PaginationAge           time.Duration
+	ExportBatchSize         int64
+	ExportSettleLag         time.Duration
+	InvoiceDuration         time.Duration
+	ReconciliationInterval  time.Duration
+	PaymentTTL              time.Duration
+	ExpirySweepInterval     time.Duration
+	ExpirySweepBatchSize    int64
+	WithdrawPollThreshold   time.Duration
+	WithdrawPollInterval    time.Duration
+	WithdrawPollBackoff     time.Duration
+	WithdrawPollMaxAttempts int64
+	WithdrawPollBatchSize   int64
+	OutboxStream            string
+	// OutboxStreamMaxLen caps the events kept in the stream, trimmed approximately as events
+	// are added. It must stay well above what the webhook consumer can fall behind by.
+	OutboxStreamMaxLen      int64
+	OutboxRelayInterval     time.Duration
+	OutboxBatchSize         int64
+	WebhookConsumerGroup    string
+	WebhookInterval         time.Duration
+	WebhookBatchSize        int64
+	WebhookTimeout          time.Duration
+	WebhookBackoff          time.Duration
+	WebhookMaxAttempts      int64
+	ReconciliationLag       time.Duration
+	SettlementInterval      time.Duration
+	SettlementLag           time.Duration
+	PaymentVendors          []*Vendor
+	WithdrawVendors         []*Vendor
+	paymentVendorTableName  string
+	withdrawVendorTableName string
 }
 
-func (a *App) GetPaymentVendorTableAlias() string {
-	return a.PaymentVendorTableAlias
+// PaymentVendor looks up a payment vendor by code; an empty code is the default vendor.
+func (a *App) PaymentVendor(code string) (*Vendor, error) {
+	return FindVendor(a.PaymentVendors, code)
 }
 
-func (a *App) GetPaymentVendorTableName() string {
-	return a.PaymentVendorTableName
+// WithdrawVendor looks up a withdraw vendor by code; an empty code is the default vendor.
+func (a *App) WithdrawVendor(code string) (*Vendor, error) {
+	return FindVendor(a.WithdrawVendors, code)
 }
 
-func (a *App) GetPaymentVendorModelFields() []string {
-	return helper.FetchColumns(a.paymentVendorSampleItem)
+// StoredPaymentVendors lists the payment vendors kept in a table, in registry order.
+func (a *App) StoredPaymentVendors() []*Vendor {
+	return storedVendors(a.PaymentVendors)
 }
 
-func (a *App) GetWithdrawVendorTableAlias() string {
-	return a.WithdrawVendorTableAlias
+// StoredWithdrawVendors lists the withdraw vendors kept in a table, in registry order.
+func (a *App) StoredWithdrawVendors() []*Vendor {
+	return storedVendors(a.WithdrawVendors)
 }
 
-func (a *App) GetWithdrawVendorTableName() string {
-	return a.WithdrawVendorTableName
-}
-
-func (a *App) GetWithdrawVendorModelFields() []string {
-	return helper.FetchColumns(a.withdrawVendorSampleItem)
+// SetVendorSchemas replaces the vendor registry, e.g. with vendors loaded from a schema file.
+func (a *App) SetVendorSchemas(schemas *schema.Schemas) {
+	a.PaymentVendors = newVendors(schemas.Payment, a.paymentVendorTableName, "pv")
+	a.WithdrawVendors = newVendors(schemas.Withdraw, a.withdrawVendorTableName, "wv")
 }
 
 func DefaultConfig(paymentVendorTableName string, withdrawVendorTableName string) *App {
-	var paymentVendorTableAlias string
-	var withdrawVendorTableAlias string
-
-	if len(paymentVendorTableName) > 0 {
-		firstChar := string(paymentVendorTableName[0])
-		if firstChar == "p" {
-			paymentVendorTableAlias = "q"
-		} else {
-			paymentVendorTableAlias = firstChar
-		}
+	vendorSchemas, errParse := schema.Parse(user.DefaultVendorSchema)
+	if errParse != nil {
+		panic(errParse)
 	}
 
-	if len(withdrawVendorTableName) > 0 {
-		firstChar := string(withdrawVendorTableName[0])
-		if firstChar == "w" {
-			withdrawVendorTableAlias = "x"
-		}
-	}
-
-	paymentVendorSampleItem := user.NewPaymentVendor()
-	withdrawVendorSampleItem := user.NewWithdrawVendor()
-
-	return &App{
-		ItemPerPage:              50,
-		RecordAge:                time.Hour * 12,
-		PaginationAge:            time.Hour * 24,
-		PaymentVendorTableName:   paymentVendorTableName,
-		PaymentVendorTableAlias:  paymentVendorTableAlias,
-		paymentVendorSampleItem:  paymentVendorSampleItem,
-		WithdrawVendorTableAlias: withdrawVendorTableAlias,
-		WithdrawVendorTableName:  withdrawVendorTableName,
-		withdrawVendorSampleItem: withdrawVendorSampleItem,
+	app := &App{
+		ItemPerPage:             50,
+		RecordAge:               time.Hour * 12,
+		PaginationAge:           time.Hour * 24,
+		ExportBatchSize:         1000,
+		ExportSettleLag:         time.Minute,
+		InvoiceDuration:         time.Hour * 24,
+		ReconciliationLag:       time.Hour,
+		PaymentTTL:              time.Hour * 24,
+		ExpirySweepInterval:     time.Minute * 5,
+		ExpirySweepBatchSize:    500,
+		WithdrawPollThreshold:   time.Minute * 30,
+		WithdrawPollInterval:    time.Minute * 5,
+		WithdrawPollBackoff:     time.Minute * 5,
+		WithdrawPollMaxAttempts: 8,
+		WithdrawPollBatchSize:   200,
+		OutboxStream:            "paystore:events",
+		OutboxStreamMaxLen:      1000000,
+		OutboxRelayInterval:     time.Second,
+		OutboxBatchSize:         100,
+		WebhookConsumerGroup:    "paystore-webhooks",
+		WebhookInterval:         time.Second,
+		WebhookBatchSize:        100,
+		WebhookTimeout:          time.Second * 10,
+		WebhookBackoff:          time.Second * 30,
+		WebhookMaxAttempts:      10,
+		SettlementInterval:      time.Hour,
+		SettlementLag:           time.Hour,
+		paymentVendorTableName:  paymentVendorTableName,
+		withdrawVendorTableName: withdrawVendorTableName,
 	}
+	app.SetVendorSchemas(vendorSchemas)
+	return app
 }
@@ -0,0 +1,97 @@
+package config
+
+import (
+	"errors"
+	"paystore/lib/helper"
+	"paystore/lib/schema"
+	"strconv"
+)
+
+var UnknownVendor = errors.New("Unknown vendor code")
+
+// Vendor is one entry of the vendor registry: a vendor code, the table its records are
+// stored in and the schema they follow. TableAlias is unique within the registry, so every
+// vendor table can be joined into the same query. WebhookSecret and WebhookToken
+// authenticate the vendor's webhooks, so one vendor's credentials never admit another's.
+type Vendor struct {
+	Code          string
+	TableName     string
+	TableAlias    string
+	Schema        *schema.Schema
+	Default       bool
+	WebhookSecret string
+	WebhookToken  string
+}
+
+// Stored reports whether records of the vendor are kept in a table. Vendors without one
+// are still decoded and finalized, but never joined, upserted or reconciled.
+func (v *Vendor) Stored() bool {
+	return v.TableName != ""
+}
+
+func (v *Vendor) ColumnNames() []string {
+	return v.Schema.ColumnNames()
+}
+
+func (v *Vendor) ColumnDefinitions() []helper.ColumnDefinition {
+	return v.Schema.ColumnDefinitions()
+}
+
+// IDColumn is the column holding the vendor's own record ID.
+func (v *Vendor) IDColumn() string {
+	return v.Schema.RoleColumn(schema.RoleID)
+}
+
+// CodeCondition matches records processed by the vendor on codeColumn, comparing against the
+// bind parameter $placeholder, which the caller sets to the vendor's Code. Records created
+// before vendors were recorded carry no code and belong to the default vendor.
+func (v *Vendor) CodeCondition(codeColumn string, placeholder int) string {
+	parameter := "$" + strconv.Itoa(placeholder)
+	if v.Default {
+		return codeColumn + " IN (" + parameter + ", '')"
+	}
+	return codeColumn + " = " + parameter
+}
+
+// FindVendor looks a vendor up by code in a registry; an empty code is the default vendor.
+func FindVendor(vendors []*Vendor, code string) (*Vendor, error) {
+	if code == "" && len(vendors) > 0 {
+		return vendors[0], nil
+	}
+	for _, vendor := range vendors {
+		if vendor.Code == code {
+			return vendor, nil
+		}
+	}
+	return nil, UnknownVendor
+}
+
+// newVendors builds the registry from validated schemas. The first schema is the default
+// vendor and falls back to defaultTableName when it does not name its own table.
+func newVendors(vendorSchemas []*schema.Schema, defaultTableName string, aliasPrefix string) []*Vendor {
+	var vendors []*Vendor
+	for i, vendorSchema := range vendorSchemas {
+		tableName := vendorSchema.Table
+		if tableName == "" && i == 0 {
+			tableName = defaultTableName
+		}
+		vendors = append(vendors, &Vendor{
+			Code:       vendorSchema.Code,
+			TableName:  tableName,
+			TableAlias: aliasPrefix + strconv.Itoa(i),
+			Schema:     vendorSchema,
+			Default:    i == 0,
+		})
+	}
+	return vendors
+}
+
+func storedVendors(vendors []*Vendor) []*Vendor {
+	var stored []*Vendor
+	for _, vendor := range vendors {
+		if vendor.Stored() {
+			stored = append(stored, vendor)
+		}
+	}
+	return stored
+}
@@ -1,12 +1,375 @@
 package fetch
 
+import (
+	"bufio"
+	"github.com/gofiber/fiber/v2"
+	"paystore/lib/balance"
+	"paystore/lib/export"
+	"paystore/lib/helper"
+	"paystore/lib/payment"
+	"paystore/lib/reconciliation"
+	"paystore/lib/settlement"
+	"paystore/lib/statement"
+	"paystore/lib/webhook"
+	"paystore/lib/withdraw"
+	"strings"
+	"time"
+)
+
 // HTTPCommandHandler
 /*
 	- FetchBalance
 	- FetchTranscations
 	- FetchPayment
 	- FetchWithdraw
+	- SearchPayments
+	- SearchWithdraws
+	- FetchStatement
+	- ExportLedger
+	- FetchReconciliationReport
+	- FetchWebhookEndpoints
+	- SearchWebhookDeliveries
+	- FetchSettlementReports
 */
 type HTTPFetcherHandler struct {
 	paystoreFetcher *PaystoreFetcher
 }
+
+type searchQuery struct {
+	OrganizationUUID string `query:"organizationUUID"`
+	BalanceUUID      string `query:"balanceUUID"`
+	Status           string `query:"status"`
+	MinAmount        int64  `query:"minAmount"`
+	MaxAmount        int64  `query:"maxAmount"`
+	CreatedFrom      string `query:"createdFrom"`
+	CreatedTo        string `query:"createdTo"`
+	VendorRecordID   string `query:"vendorRecordID"`
+	Cursor           string `query:"cursor"`
+	Limit            int64  `query:"limit"`
+}
+
+func (q *searchQuery) statuses() []string {
+	if q.Status == "" {
+		return nil
+	}
+	return strings.Split(q.Status, ",")
+}
+
+func (q *searchQuery) createdWindow() (time.Time, time.Time, error) {
+	var createdFrom, createdTo time.Time
+	var err error
+
+	if q.CreatedFrom != "" {
+		createdFrom, err = time.Parse(time.RFC3339, q.CreatedFrom)
+		if err != nil {
+			return createdFrom, createdTo, err
+		}
+	}
+	if q.CreatedTo != "" {
+		createdTo, err = time.Parse(time.RFC3339, q.CreatedTo)
+		if err != nil {
+			return createdFrom, createdTo, err
+		}
+	}
+
+	return createdFrom, createdTo, nil
+}
+
+func (h *HTTPFetcherHandler) SearchPayments(c *fiber.Ctx) error {
+	var query searchQuery
+	if errParse := c.QueryParser(&query); errParse != nil {
+		return helper.ReturnErrorResponse(c, fiber.StatusBadRequest, errParse, "invalid-query", "fetch", "SearchPayments")
+	}
+
+	createdFrom, createdTo, errParse := query.createdWindow()
+	if errParse != nil {
+		return helper.ReturnErrorResponse(c, fiber.StatusBadRequest, errParse, "invalid-query", "fetch", "SearchPayments")
+	}
+
+	filter := payment.SearchFilter{
+		OrganizationUUID: query.OrganizationUUID,
+		BalanceUUID:      query.BalanceUUID,
+		MinAmount:        query.MinAmount,
+		MaxAmount:        query.MaxAmount,
+		CreatedFrom:      createdFrom,
+		CreatedTo:        createdTo,
+		VendorRecordID:   query.VendorRecordID,
+		Cursor:           query.Cursor,
+		Limit:            query.Limit,
+	}
+	for _, status := range query.statuses() {
+		filter.Statuses = append(filter.Statuses, payment.PaymentStatus(status))
+	}
+
+	payments, nextCursor, errSearch := h.paystoreFetcher.SearchPayments(filter)
+	if errSearch != nil {
+		if errSearch == helper.InvalidCursor {
+			return helper.ReturnErrorResponse(c, fiber.StatusBadRequest, errSearch, "invalid-cursor", "fetch", "SearchPayments")
+		}
+		return helper.ReturnErrorResponse(c, fiber.StatusInternalServerError, errSearch, "search-failed", "fetch", "SearchPayments")
+	}
+
+	return c.JSON(fiber.Map{
+		"payments":   payments,
+		"nextCursor": nextCursor,
+	})
+}
+
+func (h *HTTPFetcherHandler) SearchWithdraws(c *fiber.Ctx) error {
+	var query searchQuery
+	if errParse := c.QueryParser(&query); errParse != nil {
+		return helper.ReturnErrorResponse(c, fiber.StatusBadRequest, errParse, "invalid-query", "fetch", "SearchWithdraws")
+	}
+
+	createdFrom, createdTo, errParse := query.createdWindow()
+	if errParse != nil {
+		return helper.ReturnErrorResponse(c, fiber.StatusBadRequest, errParse, "invalid-query", "fetch", "SearchWithdraws")
+	}
+
+	filter := withdraw.SearchFilter{
+		OrganizationUUID: query.OrganizationUUID,
+		BalanceUUID:      query.BalanceUUID,
+		MinAmount:        query.MinAmount,
+		MaxAmount:        query.MaxAmount,
+		CreatedFrom:      createdFrom,
+		CreatedTo:        createdTo,
+		VendorRecordID:   query.VendorRecordID,
+		Cursor:           query.Cursor,
+		Limit:            query.Limit,
+	}
+	for _, status := range query.statuses() {
+		filter.Statuses = append(filter.Statuses, withdraw.WithdrawStatus(status))
+	}
+
+	withdraws, nextCursor, errSearch := h.paystoreFetcher.SearchWithdraws(filter)
+	if errSearch != nil {
+		if errSearch == helper.InvalidCursor {
+			return helper.ReturnErrorResponse(c, fiber.StatusBadRequest, errSearch, "invalid-cursor", "fetch", "SearchWithdraws")
+		}
+		return helper.ReturnErrorResponse(c, fiber.StatusInternalServerError, errSearch, "search-failed", "fetch", "SearchWithdraws")
+	}
+
+	return c.JSON(fiber.Map{
+		"withdraws":  withdraws,
+		"nextCursor": nextCursor,
+	})
+}
+
+type statementQuery struct {
+	From   string `query:"from"`
+	To     string `query:"to"`
+	Format string `query:"format"`
+}
+
+func (h *HTTPFetcherHandler) FetchStatement(c *fiber.Ctx) error {
+	var query statementQuery
+	if errParse := c.QueryParser(&query); errParse != nil {
+		return helper.ReturnErrorResponse(c, fiber.StatusBadRequest, errParse, "invalid-query", "fetch", "FetchStatement")
+	}
+
+	periodStart, errParse := time.Parse(time.RFC3339, query.From)
+	if errParse != nil {
+		return helper.ReturnErrorResponse(c, fiber.StatusBadRequest, errParse, "invalid-query", "fetch", "FetchStatement")
+	}
+	periodEnd, errParse := time.Parse(time.RFC3339, query.To)
+	if errParse != nil {
+		return helper.ReturnErrorResponse(c, fiber.StatusBadRequest, errParse, "invalid-query", "fetch", "FetchStatement")
+	}
+
+	generated, errGenerate := h.paystoreFetcher.GenerateStatement(c.Params("balanceUUID"), periodStart, periodEnd)
+	if errGenerate != nil {
+		if errGenerate == balance.BalanceNotFound {
+			return helper.ReturnErrorResponse(c, fiber.StatusNotFound, errGenerate, "balance-not-found", "fetch", "FetchStatement")
+		}
+		if errGenerate == statement.InvalidPeriod {
+			return helper.ReturnErrorResponse(c, fiber.StatusBadRequest, errGenerate, "invalid-period", "fetch", "FetchStatement")
+		}
+		return helper.ReturnErrorResponse(c, fiber.StatusInternalServerError, errGenerate, "statement-failed", "fetch", "FetchStatement")
+	}
+
+	content, contentType, errRender := generated.Render(statement.Format(query.Format))
+	if errRender != nil {
+		return helper.ReturnErrorResponse(c, fiber.StatusBadRequest, errRender, "unsupported-format", "fetch", "FetchStatement")
+	}
+
+	c.Set("Content-Type", contentType)
+	return c.Send(content)
+}
+
+type exportQuery struct {
+	Since  string `query:"since"`
+	Format string `query:"format"`
+}
+
+func (h *HTTPFetcherHandler) ExportLedger(c *fiber.Ctx) error {
+	var query exportQuery
+	if errParse := c.QueryParser(&query); errParse != nil {
+		return helper.ReturnErrorResponse(c, fiber.StatusBadRequest, errParse, "invalid-query", "fetch", "ExportLedger")
+	}
+
+	var since time.Time
+	if query.Since != "" {
+		var errParse error
+		since, errParse = time.Parse(time.RFC3339Nano, query.Since)
+		if errParse != nil {
+			return helper.ReturnErrorResponse(c, fiber.StatusBadRequest, errParse, "invalid-query", "fetch", "ExportLedger")
+		}
+	}
+
+	// The encoder writes through output, which is pointed at the response stream once it opens,
+	// so an unsupported format is rejected before any of the response is sent.
+	format := export.Format(query.Format)
+	output := bufio.NewWriter(nil)
+	encoder, errEncoder := export.NewEncoder(format, output)
+	if errEncoder != nil {
+		return helper.ReturnErrorResponse(c, fiber.StatusBadRequest, errEncoder, "unsupported-format", "fetch", "ExportLedger")
+	}
+
+	window := h.paystoreFetcher.ExportWindow(c.Params("organizationUUID"), since)
+
+	c.Set("Content-Type", export.ContentType(format))
+	c.Set("X-Export-Watermark", window.Until.Format(time.RFC3339Nano))
+	c.Context().SetBodyStreamWriter(func(writer *bufio.Writer) {
+		output.Reset(writer)
+		total, errExport := h.paystoreFetcher.Export(window, encoder)
+		if errExport != nil {
+			helper.Logger.Error("export-error", "component", "paystore", "source", "fetch.ExportLedger",
+				"organizationUUID", window.OrganizationUUID, "exported", total, "error", errExport.Error())
+		}
+		output.Flush()
+		writer.Flush()
+	})
+
+	return nil
+}
+
+func (h *HTTPFetcherHandler) FetchReconciliationReport(c *fiber.Ctx) error {
+	report, errFind := h.paystoreFetcher.FetchReconciliationReport(c.Params("reportUUID"),
+		reconciliation.Result(c.Query("result")))
+	if errFind != nil {
+		if errFind == reconciliation.ReportNotFound {
+			return helper.ReturnErrorResponse(c, fiber.StatusNotFound, errFind, "report-not-found", "fetch", "FetchReconciliationReport")
+		}
+		return helper.ReturnErrorResponse(c, fiber.StatusInternalServerError, errFind, "fetch-report-failed", "fetch", "FetchReconciliationReport")
+	}
+
+	return c.JSON(report)
+}
+
+func (h *HTTPFetcherHandler) FetchWebhookEndpoints(c *fiber.Ctx) error {
+	endpoints, errFind := h.paystoreFetcher.FetchWebhookEndpoints(c.Params("organizationUUID"))
+	if errFind != nil {
+		return helper.ReturnErrorResponse(c, fiber.StatusInternalServerError, errFind, "fetch-endpoints-failed", "fetch", "FetchWebhookEndpoints")
+	}
+
+	return c.JSON(fiber.Map{
+		"endpoints": endpoints,
+	})
+}
+
+type webhookDeliveryQuery struct {
+	EndpointUUID string `query:"endpointUUID"`
+	EventType    string `query:"eventType"`
+	Status       string `query:"status"`
+	Cursor       string `query:"cursor"`
+	Limit        int64  `query:"limit"`
+}
+
+func (h *HTTPFetcherHandler) SearchWebhookDeliveries(c *fiber.Ctx) error {
+	var query webhookDeliveryQuery
+	if errParse := c.QueryParser(&query); errParse != nil {
+		return helper.ReturnErrorResponse(c, fiber.StatusBadRequest, errParse, "invalid-query", "fetch", "SearchWebhookDeliveries")
+	}
+
+	deliveries, nextCursor, errSearch := h.paystoreFetcher.SearchWebhookDeliveries(webhook.DeliveryFilter{
+		OrganizationUUID: c.Params("organizationUUID"),
+		EndpointUUID:     query.EndpointUUID,
+		EventType:        query.EventType,
+		Status:           webhook.DeliveryStatus(query.Status),
+		Cursor:           query.Cursor,
+		Limit:            query.Limit,
+	})
+	if errSearch != nil {
+		if errSearch == helper.InvalidCursor {
+			return helper.ReturnErrorResponse(c, fiber.StatusBadRequest, errSearch, "invalid-cursor", "fetch", "SearchWebhookDeliveries")
+		}
+		return helper.ReturnErrorResponse(c, fiber.StatusInternalServerError, errSearch, "search-failed", "fetch", "SearchWebhookDeliveries")
+	}
+
+	return c.JSON(fiber.Map{
+		"deliveries": deliveries,
+		"nextCursor": nextCursor,
+	})
+}
+
+type settlementQuery struct {
+	Currency string `query:"currency"`
+	From     string `query:"from"`
+	To       string `query:"to"`
+}
+
+// days parses the inclusive range of settlement days; an empty end is left open.
+func (q *settlementQuery) days() (time.Time, time.Time, error) {
+	var from, to time.Time
+	var err error
+
+	if q.From != "" {
+		from, err = time.Parse(settlement.DayLayout, q.From)
+		if err != nil {
+			return from, to, err
+		}
+	}
+	if q.To != "" {
+		to, err = time.Parse(settlement.DayLayout, q.To)
+		if err != nil {
+			return from, to, err
+		}
+	}
+
+	return from, to, nil
+}
+
+func (h *HTTPFetcherHandler) FetchSettlementReports(c *fiber.Ctx) error {
+	var query settlementQuery
+	if errParse := c.QueryParser(&query); errParse != nil {
+		return helper.ReturnErrorResponse(c, fiber.StatusBadRequest, errParse, "invalid-query", "fetch", "FetchSettlementReports")
+	}
+	from, to, errParse := query.days()
+	if errParse != nil {
+		return helper.ReturnErrorResponse(c, fiber.StatusBadRequest, errParse, "invalid-query", "fetch", "FetchSettlementReports")
+	}
+
+	reports, errFind := h.paystoreFetcher.FetchSettlementReports(settlement.Filter{
+		OrganizationUUID: c.Params("organizationUUID"),
+		Currency:         query.Currency,
+		From:             from,
+		To:               to,
+	})
+	if errFind != nil {
+		if errFind == settlement.InvalidRange {
+			return helper.ReturnErrorResponse(c, fiber.StatusBadRequest, errFind, "invalid-range", "fetch", "FetchSettlementReports")
+		}
+		return helper.ReturnErrorResponse(c, fiber.StatusInternalServerError, errFind, "fetch-settlements-failed", "fetch", "FetchSettlementReports")
+	}
+
+	return c.JSON(fiber.Map{
+		"reports": reports,
+	})
+}
+
+func (h *HTTPFetcherHandler) RegisterRoutes(app *fiber.App) {
+	app.Get("/payments/search", h.SearchPayments)
+	app.Get("/withdraws/search", h.SearchWithdraws)
+	app.Get("/balances/:balanceUUID/statement", h.FetchStatement)
+	app.Get("/organizations/:organizationUUID/export", h.ExportLedger)
+	app.Get("/reconciliations/:reportUUID", h.FetchReconciliationReport)
+	app.Get("/organizations/:organizationUUID/webhook-endpoints", h.FetchWebhookEndpoints)
+	app.Get("/organizations/:organizationUUID/webhook-deliveries", h.SearchWebhookDeliveries)
+	app.Get("/organizations/:organizationUUID/settlements", h.FetchSettlementReports)
+}
+
+func NewHTTPFetcherHandler(paystoreFetcher *PaystoreFetcher) *HTTPFetcherHandler {
+	return &HTTPFetcherHandler{
+		paystoreFetcher: paystoreFetcher,
+	}
+}
@@ -1,13 +1,28 @@
 package fetch
 
 import (
+	"database/sql"
 	"github.com/redis/go-redis/v9"
 	"paystore/config"
+	"paystore/lib/export"
 	"paystore/lib/payment"
+	"paystore/lib/reconciliation"
+	"paystore/lib/settlement"
+	"paystore/lib/statement"
+	"paystore/lib/webhook"
+	"paystore/lib/withdraw"
+	"time"
 )
 
 type PaystoreFetcher struct {
-	paymentFetcher payment.FetcherClient
+	paymentFetcher           payment.FetcherClient
+	paymentRepository        payment.RepositoryClient
+	withdrawRepository       withdraw.RepositoryClient
+	statementRepository      statement.RepositoryClient
+	exportRepository         export.RepositoryClient
+	reconciliationRepository reconciliation.RepositoryClient
+	webhookRepository        webhook.RepositoryClient
+	settlementRepository     settlement.RepositoryClient
 }
 
 func (pf *PaystoreFetcher) FetchByBalance(lastRandId []string, balanceUUID string) ([]*payment.Payment, *string, *string, bool, error) {
@@ -30,9 +45,71 @@ func (pf *PaystoreFetcher) FetchByBalance(lastRandId []string, balanceUUID strin
 	return payments, &validLastRandId, &position, false, nil
 }
 
-func NewFetcher(redis redis.UniversalClient, config *config.App) *PaystoreFetcher {
+func (pf *PaystoreFetcher) SearchPayments(filter payment.SearchFilter) ([]*payment.Payment, string, error) {
+	return pf.paymentRepository.Search(filter)
+}
+
+func (pf *PaystoreFetcher) SearchWithdraws(filter withdraw.SearchFilter) ([]*withdraw.Withdraw, string, error) {
+	return pf.withdrawRepository.Search(filter)
+}
+
+func (pf *PaystoreFetcher) GenerateStatement(balanceUUID string,
+	periodStart time.Time, periodEnd time.Time) (*statement.Statement, error) {
+	return pf.statementRepository.Generate(balanceUUID, periodStart, periodEnd)
+}
+
+func (pf *PaystoreFetcher) ExportWindow(organizationUUID string, since time.Time) export.Window {
+	return pf.exportRepository.Window(organizationUUID, since)
+}
+
+func (pf *PaystoreFetcher) Export(window export.Window, encoder export.Encoder) (int64, error) {
+	return pf.exportRepository.Stream(window, encoder)
+}
+
+func (pf *PaystoreFetcher) FetchReconciliationReport(reportUUID string,
+	result reconciliation.Result) (*reconciliation.Report, error) {
+	report, errFind := pf.reconciliationRepository.FindByUUID(reportUUID)
+	if errFind != nil {
+		return nil, errFind
+	}
+
+	items, errFind := pf.reconciliationRepository.FindItems(reportUUID, result)
+	if errFind != nil {
+		return nil, errFind
+	}
+	report.Items = items
+
+	return report, nil
+}
+
+func (pf *PaystoreFetcher) FetchWebhookEndpoints(organizationUUID string) ([]*webhook.Endpoint, error) {
+	return pf.webhookRepository.FindActiveEndpoints(organizationUUID)
+}
+
+func (pf *PaystoreFetcher) SearchWebhookDeliveries(filter webhook.DeliveryFilter) ([]*webhook.Delivery, string, error) {
+	return pf.webhookRepository.SearchDeliveries(filter)
+}
+
+func (pf *PaystoreFetcher) FetchSettlementReports(filter settlement.Filter) ([]*settlement.Report, error) {
+	return pf.settlementRepository.Find(filter)
+}
+
+func NewFetcher(readDB *sql.DB, redis redis.UniversalClient, config *config.App) *PaystoreFetcher {
 	paymentFetcher := payment.NewFetcher(redis, config)
+	paymentRepository, errInit := payment.NewRepository(readDB, redis, config)
+	if errInit != nil {
+		panic(errInit)
+	}
+	withdrawRepository := withdraw.NewRepository(readDB, redis, config)
+
 	return &PaystoreFetcher{
-		paymentFetcher: paymentFetcher,
+		paymentFetcher:           paymentFetcher,
+		paymentRepository:        paymentRepository,
+		withdrawRepository:       withdrawRepository,
+		statementRepository:      statement.NewRepository(readDB),
+		exportRepository:         export.NewRepository(readDB, config),
+		reconciliationRepository: reconciliation.NewRepository(readDB, redis, config),
+		webhookRepository:        webhook.NewRepository(nil, readDB, config),
+		settlementRepository:     settlement.NewRepository(readDB),
 	}
 }
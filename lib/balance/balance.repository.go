@@ -9,19 +9,54 @@ import (
 )
 
 var findByUUIDQuery = `SELECT * FROM balance WHERE uuid = $1;`
+var findByUUIDForUpdateQuery = `SELECT * FROM balance WHERE uuid = $1 FOR UPDATE;`
 var findByExternalIDQuery = `SELECT * FROM balance WHERE external_id = $1;`
 var createBalanceQuery = `INSERT INTO balance 
     (
      uuid, randid, created_at, updated_at, balance, 
      last_receive, last_withdraw, income_accumulation, withdraw_accumulation, 
-     currency, active, external_id, organization_uuid
-	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13);`
+     currency, active, external_id, organization_uuid, kind
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14);`
+
+// createRevenueBalanceQuery leaves an existing revenue balance alone, so concurrent first
+// fees of an organization and currency end up crediting the same balance.
+var createRevenueBalanceQuery = `INSERT INTO balance 
+    (
+     uuid, randid, created_at, updated_at, balance, 
+     last_receive, last_withdraw, income_accumulation, withdraw_accumulation, 
+     currency, active, external_id, organization_uuid, kind
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+	ON CONFLICT (organization_uuid, currency) WHERE kind = 'revenue' DO NOTHING;`
+var findRevenueQuery = `SELECT * FROM balance WHERE organization_uuid = $1 AND currency = $2 AND kind = 'revenue';`
+var findRevenueForUpdateQuery = `SELECT * FROM balance WHERE organization_uuid = $1 AND currency = $2 AND kind = 'revenue' 
+	FOR UPDATE;`
+
+// rollupQuery sums the merchant balances of the organization and, recursively, of its
+// sub-merchants, per organization and currency.
+var rollupQuery = `
+	WITH RECURSIVE tree AS (
+		SELECT uuid FROM organization WHERE uuid = $1
+		UNION
+		SELECT o.uuid FROM organization o JOIN tree ON o.parent_uuid = tree.uuid
+	)
+	SELECT b.organization_uuid::TEXT, b.currency, COUNT(*), SUM(b.balance), SUM(b.income_accumulation),
+		SUM(b.withdraw_accumulation)
+	FROM balance b JOIN tree ON b.organization_uuid::TEXT = tree.uuid
+	WHERE b.kind = 'merchant'
+	GROUP BY b.organization_uuid, b.currency
+	ORDER BY b.currency, b.organization_uuid`
 
 type RepositoryClient interface {
-	Create(balance *Balance) error
+	Create(tx *sql.Tx, balance *Balance) error
 	Update(tx *sql.Tx, balance *Balance) error
+	SetCache(balance *Balance) error
+	AddToTimeline(balance *Balance) error
 	FindByUUID(uuid string) (*Balance, error)
+	FindByUUIDForUpdate(tx *sql.Tx, uuid string) (*Balance, error)
 	FindByExternalID(externalID string) (*Balance, error)
+	FindRevenue(organizationUUID string, currency string) (*Balance, error)
+	FindOrCreateRevenueForUpdate(tx *sql.Tx, organizationUUID string, currency string) (*Balance, bool, error)
+	Rollup(organizationUUID string) ([]*Rollup, error)
 	SeedPartial(subtraction int64, lastRandId string, organization organization.Organization) error
 }
 
@@ -32,25 +67,26 @@ type Repository struct {
 	createBalanceStmt    *sql.Stmt
 	findByUUIDStmt       *sql.Stmt
 	findByExternalIDStmt *sql.Stmt
+	findRevenueStmt      *sql.Stmt
+	rollupStmt           *sql.Stmt
 }
 
-func (br *Repository) Create(balance *Balance) (err error) {
-	_, errExec := br.createBalanceStmt.Exec(balance.GetUUID(),
+func (br *Repository) Create(tx *sql.Tx, balance *Balance) (err error) {
+	_, errExec := tx.Stmt(br.createBalanceStmt).Exec(balance.GetUUID(),
 		balance.GetRandId(), balance.GetCreatedAt(), balance.GetUpdatedAt(), balance.Balance,
 		balance.LastReceive, balance.LastWithdraw, balance.IncomeAccumulation, balance.WithdrawAccumulation,
-		balance.Currency, balance.Active, balance.ExternalID, balance.OrganizationUUID)
-	if errExec != nil {
-		return errExec
-	}
-
-	errSet := br.base.Set(balance)
-	if errSet != nil {
-		return errSet
-	}
+		balance.Currency, balance.Active, balance.ExternalID, balance.OrganizationUUID, balance.Kind)
+	return errExec
+}
 
-	br.timeline.AddItem(balance, []string{balance.OrganizationUUID})
+// SetCache stores the balance in the cache. Create and Update only write the row, so callers
+// cache the balance once the transaction that wrote it has committed.
+func (br *Repository) SetCache(balance *Balance) error {
+	return br.base.Set(balance)
+}
 
-	return nil
+func (br *Repository) AddToTimeline(balance *Balance) error {
+	return br.timeline.AddItem(balance, []string{balance.OrganizationUUID})
 }
 
 func (br *Repository) Update(tx *sql.Tx, balance *Balance) (err error) {
@@ -63,20 +99,23 @@ func (br *Repository) Update(tx *sql.Tx, balance *Balance) (err error) {
 		query, balance.GetUpdatedAt(), balance.Balance, balance.LastReceive, balance.LastWithdraw,
 		balance.IncomeAccumulation, balance.WithdrawAccumulation, balance.Currency, balance.Active,
 		balance.ExternalID, balance.OrganizationUUID, balance.GetUUID())
-	if errExec != nil {
-		return errExec
-	}
+	return errExec
+}
 
-	errSet := br.base.Set(balance)
-	if errSet != nil {
-		return errSet
+func (br *Repository) FindByUUID(uuid string) (*Balance, error) {
+	account, errFind := BalanceRowScanner(br.findByUUIDStmt.QueryRow(uuid))
+	if errFind != nil {
+		if errFind == sql.ErrNoRows {
+			return nil, BalanceNotFound
+		}
+		return nil, errFind
 	}
 
-	return nil
+	return account, nil
 }
 
-func (br *Repository) FindByUUID(uuid string) (*Balance, error) {
-	account, errFind := BalanceRowScanner(br.findByUUIDStmt.QueryRow(uuid))
+func (br *Repository) FindByUUIDForUpdate(tx *sql.Tx, uuid string) (*Balance, error) {
+	account, errFind := BalanceRowScanner(tx.QueryRow(findByUUIDForUpdateQuery, uuid))
 	if errFind != nil {
 		if errFind == sql.ErrNoRows {
 			return nil, BalanceNotFound
@@ -99,10 +138,77 @@ func (br *Repository) FindByExternalID(externalID string) (*Balance, error) {
 	return account, nil
 }
 
+func (br *Repository) FindRevenue(organizationUUID string, currency string) (*Balance, error) {
+	account, errFind := BalanceRowScanner(br.findRevenueStmt.QueryRow(organizationUUID, currency))
+	if errFind != nil {
+		if errFind == sql.ErrNoRows {
+			return nil, BalanceNotFound
+		}
+		return nil, errFind
+	}
+
+	return account, nil
+}
+
+// FindOrCreateRevenueForUpdate locks the organization's revenue balance in currency, opening
+// it on the first fee collected in that currency. created reports whether it was opened, so
+// the caller can add it to the timeline once the transaction has committed.
+func (br *Repository) FindOrCreateRevenueForUpdate(tx *sql.Tx, organizationUUID string,
+	currency string) (account *Balance, created bool, err error) {
+	revenue := NewRevenueBalance(organizationUUID, currency)
+	result, errExec := tx.Exec(createRevenueBalanceQuery, revenue.GetUUID(),
+		revenue.GetRandId(), revenue.GetCreatedAt(), revenue.GetUpdatedAt(), revenue.Balance,
+		revenue.LastReceive, revenue.LastWithdraw, revenue.IncomeAccumulation, revenue.WithdrawAccumulation,
+		revenue.Currency, revenue.Active, revenue.ExternalID, revenue.OrganizationUUID, revenue.Kind)
+	if errExec != nil {
+		return nil, false, errExec
+	}
+	inserted, errAffected := result.RowsAffected()
+	if errAffected != nil {
+		return nil, false, errAffected
+	}
+
+	account, errFind := BalanceRowScanner(tx.QueryRow(findRevenueForUpdateQuery, organizationUUID, currency))
+	if errFind != nil {
+		return nil, false, errFind
+	}
+
+	return account, inserted > 0, nil
+}
+
+// Rollup returns one total per currency of the organization and its sub-merchants.
+func (br *Repository) Rollup(organizationUUID string) ([]*Rollup, error) {
+	rows, errQuery := br.rollupStmt.Query(organizationUUID)
+	if errQuery != nil {
+		return nil, errQuery
+	}
+	defer rows.Close()
+
+	var rollups []*Rollup
+	for rows.Next() {
+		subtotal := &Rollup{}
+		errScan := rows.Scan(&subtotal.OrganizationUUID, &subtotal.Currency, &subtotal.BalanceCount,
+			&subtotal.Balance, &subtotal.IncomeAccumulation, &subtotal.WithdrawAccumulation)
+		if errScan != nil {
+			return nil, errScan
+		}
+
+		if len(rollups) == 0 || rollups[len(rollups)-1].Currency != subtotal.Currency {
+			rollups = append(rollups, &Rollup{OrganizationUUID: organizationUUID, Currency: subtotal.Currency})
+		}
+		rollups[len(rollups)-1].Add(subtotal)
+	}
+	if errRows := rows.Err(); errRows != nil {
+		return nil, errRows
+	}
+
+	return rollups, nil
+}
+
 func (br *Repository) SeedPartial(subtraction int64, lastRandId string, organization organization.Organization) error {
 	baseQuery := `SELECT 
     	uuid, randid, created_at, updated_at, balance, last_receive, last_withdraw, income_accumulation, 
-    	withdraw_accumulation, currency, active, external_id, organization_uuid FROM balance`
+    	withdraw_accumulation, currency, active, external_id, organization_uuid, kind FROM balance`
 
 	rowQuery := baseQuery + ` WHERE randid = $1`
 	firstPageQuery := baseQuery + ` WHERE organization_uuid = $1 ORDER BY created_at DESC`
@@ -140,6 +246,14 @@ func NewRepository(writeDB *sql.DB, readDB *sql.DB, redis redis.UniversalClient,
 	if err != nil {
 		panic(err)
 	}
+	findRevenueStmt, err := readDB.Prepare(findRevenueQuery)
+	if err != nil {
+		panic(err)
+	}
+	rollupStmt, err := readDB.Prepare(rollupQuery)
+	if err != nil {
+		panic(err)
+	}
 	createBalanceStmt, err := writeDB.Prepare(createBalanceQuery)
 	if err != nil {
 		panic(err)
@@ -151,6 +265,8 @@ func NewRepository(writeDB *sql.DB, readDB *sql.DB, redis redis.UniversalClient,
 		timelineSeeder:       timelineSeeder,
 		findByUUIDStmt:       findByUUIDStmt,
 		findByExternalIDStmt: findByExternalIDStmt,
+		findRevenueStmt:      findRevenueStmt,
+		rollupStmt:           rollupStmt,
 		createBalanceStmt:    createBalanceStmt,
 	}
 }
@@ -2,5 +2,14 @@ package balance
 
 import "errors"
 
+// Kind separates merchant balances from the platform revenue balances fees are credited to.
+type Kind string
+
+const (
+	KindMerchant Kind = "merchant"
+	KindRevenue  Kind = "revenue"
+)
+
 var InsufficientFunds = errors.New("Insufficient funds")
 var BalanceNotFound = errors.New("Account not found")
+var RevenueNotPayable = errors.New("Revenue balance cannot receive payments")
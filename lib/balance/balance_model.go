@@ -17,6 +17,7 @@ type Balance struct {
 	Active               bool
 	ExternalID           string
 	OrganizationUUID     string
+	Kind                 Kind
 }
 
 func (ac *Balance) SetCurrency(currency string) {
@@ -67,13 +68,44 @@ func (ac *Balance) ScanDestinations() []interface{} {
 		&ac.Active,
 		&ac.ExternalID,
 		&ac.OrganizationUUID,
+		&ac.Kind,
 	}
 }
 
+// Rollup totals the merchant balances in one currency of an organization and all of its
+// sub-merchants. Organizations holds the subtotal of each organization with a balance.
+type Rollup struct {
+	OrganizationUUID     string
+	Currency             string
+	BalanceCount         int64
+	Balance              int64
+	IncomeAccumulation   int64
+	WithdrawAccumulation int64
+	Organizations        []*Rollup
+}
+
+func (r *Rollup) Add(subtotal *Rollup) {
+	r.BalanceCount += subtotal.BalanceCount
+	r.Balance += subtotal.Balance
+	r.IncomeAccumulation += subtotal.IncomeAccumulation
+	r.WithdrawAccumulation += subtotal.WithdrawAccumulation
+	r.Organizations = append(r.Organizations, subtotal)
+}
+
+// NewRevenueBalance is the balance an organization's fees in currency are credited to.
+func NewRevenueBalance(organizationUUID string, currency string) *Balance {
+	account := NewBalance()
+	account.OrganizationUUID = organizationUUID
+	account.Currency = currency
+	account.Kind = KindRevenue
+	return account
+}
+
 func NewBalance() *Balance {
 	account := &Balance{}
 	redifu.InitRecord(account)
 
 	account.Active = true
+	account.Kind = KindMerchant
 	return account
 }
@@ -2,36 +2,150 @@ package builder
 
 import (
 	"paystore/config"
+	"paystore/lib/helper"
 	"paystore/lib/transaction"
+	"strconv"
+	"strings"
+	"time"
 )
 
-func JoinBuilder(firstPartSelectQuery string, transcationType transaction.TransactionType, config *config.App) string {
+// JoinBuilder selects the columns of every stored vendor next to the record. Each vendor table
+// is joined on its own alias and only matches records processed by that vendor, so at most
+// one of the joined vendor rows is non-null. Vendor codes are bound from parameter
+// firstPlaceholder on; JoinBuilder returns them as the arguments for those parameters.
+func JoinBuilder(firstPartSelectQuery string, transcationType transaction.TransactionType, config *config.App,
+	firstPlaceholder int) (string, []interface{}) {
 	var finalQuery string
+	var args []interface{}
 
 	finalQuery += firstPartSelectQuery
-	finalQuery += `, `
 
 	if transcationType == transaction.TypePayment {
-		fields := config.GetPaymentVendorModelFields()
-		for _, field := range fields {
-			finalQuery += config.GetPaymentVendorTableAlias() + "." + field + ", "
+		var joins string
+		joins, args = joinVendors(`payment p`, `p`, config.StoredPaymentVendors(), firstPlaceholder)
+		finalQuery += joins
+	} else if transcationType == transaction.TypeWithdraw {
+		var joins string
+		joins, args = joinVendors(`withdraw w`, `w`, config.StoredWithdrawVendors(), firstPlaceholder)
+		finalQuery += joins
+	}
+
+	return finalQuery, args
+}
+
+func joinVendors(fromTable string, recordAlias string, vendors []*config.Vendor,
+	firstPlaceholder int) (string, []interface{}) {
+	var query string
+	var args []interface{}
+	for _, vendor := range vendors {
+		for _, field := range vendor.ColumnNames() {
+			query += `, ` + vendor.TableAlias + "." + field
 		}
+	}
 
-		finalQuery += `FROM payment p`
-		finalQuery += ` `
-		finalQuery += `LEFT JOIN ` + config.GetPaymentVendorTableName() + ` ` + config.GetPaymentVendorTableAlias()
-		finalQuery += ` `
-	} else if transcationType == transaction.TypeWithdraw {
-		fields := config.GetWithdrawVendorModelFields()
-		for _, field := range fields {
-			finalQuery += config.GetWithdrawVendorTableAlias() + "." + field + ", "
+	query += ` FROM ` + fromTable
+	query += ` `
+	for _, vendor := range vendors {
+		query += `LEFT JOIN ` + vendor.TableName + ` ` + vendor.TableAlias
+		query += ` ON ` + vendor.TableAlias + `.` + vendor.IDColumn() + ` = ` + recordAlias + `.vendor_record_id`
+		query += ` AND ` + vendor.CodeCondition(recordAlias+`.vendor_code`, firstPlaceholder+len(args))
+		query += ` `
+		args = append(args, vendor.Code)
+	}
+	return query, args
+}
+
+// CreateTableBuilder renders the DDL for a vendor table. Vendor rows are keyed by paystore's
+// uuid and must be unique on the vendor's own id column, which the upsert and the join rely on.
+func CreateTableBuilder(tableName string, columns []helper.ColumnDefinition, idColumn string) string {
+	var definitions []string
+	for _, column := range columns {
+		definition := column.Name + " " + column.SQLType
+		if column.Name == "uuid" {
+			definition += " PRIMARY KEY"
+		}
+		if column.Name == idColumn {
+			definition += " NOT NULL UNIQUE"
 		}
+		definitions = append(definitions, definition)
+	}
+
+	return `CREATE TABLE IF NOT EXISTS ` + tableName + ` (` + strings.Join(definitions, ", ") + `)`
+}
+
+type FilterBuilder struct {
+	conditions []string
+	args       []interface{}
+}
+
+func (fb *FilterBuilder) placeholder(value interface{}) string {
+	fb.args = append(fb.args, value)
+	return "$" + strconv.Itoa(len(fb.args))
+}
+
+func (fb *FilterBuilder) Where(column string, operator string, value interface{}) {
+	fb.conditions = append(fb.conditions, column+" "+operator+" "+fb.placeholder(value))
+}
+
+func (fb *FilterBuilder) WhereIn(column string, values []interface{}) {
+	if len(values) == 0 {
+		return
+	}
+
+	var placeholders []string
+	for _, value := range values {
+		placeholders = append(placeholders, fb.placeholder(value))
+	}
+	fb.conditions = append(fb.conditions, column+" IN ("+strings.Join(placeholders, ", ")+")")
+}
+
+func (fb *FilterBuilder) WhereBefore(timeColumn string, uuidColumn string, cursorTime time.Time, cursorUUID string) {
+	fb.conditions = append(fb.conditions,
+		"("+timeColumn+", "+uuidColumn+") < ("+fb.placeholder(cursorTime)+", "+fb.placeholder(cursorUUID)+")")
+}
 
-		finalQuery += `FROM withdraw w`
-		finalQuery += ` `
-		finalQuery += `LEFT JOIN ` + config.GetWithdrawVendorTableName() + ` ` + config.GetWithdrawVendorTableAlias()
-		finalQuery += ` `
+func (fb *FilterBuilder) Clause() string {
+	if len(fb.conditions) == 0 {
+		return ""
 	}
 
-	return finalQuery
+	return " WHERE " + strings.Join(fb.conditions, " AND ")
+}
+
+func (fb *FilterBuilder) Limit(limit int64) string {
+	return " LIMIT " + fb.placeholder(limit)
+}
+
+func (fb *FilterBuilder) Args() []interface{} {
+	return fb.args
+}
+
+func NewFilterBuilder() *FilterBuilder {
+	return &FilterBuilder{}
+}
+
+// UpsertBuilder renders an INSERT that refreshes every column except the immutable ones
+// when a row with the same conflict column already exists.
+func UpsertBuilder(tableName string, columns []string, conflictColumn string, immutableColumns []string) string {
+	var placeholders []string
+	var updates []string
+
+	immutable := make(map[string]bool)
+	for _, column := range immutableColumns {
+		immutable[column] = true
+	}
+
+	for i, column := range columns {
+		placeholders = append(placeholders, "$"+strconv.Itoa(i+1))
+		if column != conflictColumn && !immutable[column] {
+			updates = append(updates, column+" = EXCLUDED."+column)
+		}
+	}
+
+	query := `INSERT INTO ` + tableName + ` (` + strings.Join(columns, ", ") + `) VALUES (` +
+		strings.Join(placeholders, ", ") + `) ON CONFLICT (` + conflictColumn + `) DO `
+	if len(updates) == 0 {
+		return query + `NOTHING`
+	}
+	return query + `UPDATE SET ` + strings.Join(updates, ", ")
 }
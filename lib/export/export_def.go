@@ -0,0 +1,22 @@
+package export
+
+import "errors"
+
+type Kind string
+
+const (
+	KindPayment     Kind = "payment"
+	KindWithdraw    Kind = "withdraw"
+	KindTransaction Kind = "transaction"
+)
+
+type Format string
+
+const (
+	FormatNDJSON Format = "ndjson"
+	FormatCSV    Format = "csv"
+)
+
+var OrganizationRequired = errors.New("Organization is required")
+var UnsupportedFormat = errors.New("Unsupported export format")
+var InvalidWindow = errors.New("Export window end must be after its start")
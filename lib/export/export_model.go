@@ -0,0 +1,142 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Window is a half-open [Since, Until) range over updated_at. Until is the watermark
+// a loader passes back as Since on its next run.
+type Window struct {
+	OrganizationUUID string
+	Since            time.Time
+	Until            time.Time
+}
+
+type Movement struct {
+	Kind             Kind      `json:"kind"`
+	UUID             string    `json:"uuid"`
+	CreatedAt        time.Time `json:"createdAt"`
+	UpdatedAt        time.Time `json:"updatedAt"`
+	OrganizationUUID string    `json:"organizationUUID"`
+	BalanceUUID      string    `json:"balanceUUID"`
+	Amount           int64     `json:"amount"`
+	Fees             int64     `json:"fees"`
+	BalanceBefore    int64     `json:"balanceBefore"`
+	BalanceAfter     int64     `json:"balanceAfter"`
+	Status           string    `json:"status"`
+	VendorRecordID   string    `json:"vendorRecordID"`
+	RecordUUID       string    `json:"recordUUID"`
+	TransactionType  string    `json:"transactionType"`
+}
+
+func (m *Movement) ScanDestinations() []interface{} {
+	return []interface{}{
+		&m.Kind,
+		&m.UUID,
+		&m.CreatedAt,
+		&m.UpdatedAt,
+		&m.OrganizationUUID,
+		&m.BalanceUUID,
+		&m.Amount,
+		&m.Fees,
+		&m.BalanceBefore,
+		&m.BalanceAfter,
+		&m.Status,
+		&m.VendorRecordID,
+		&m.RecordUUID,
+		&m.TransactionType,
+	}
+}
+
+func (m *Movement) csvRecord() []string {
+	return []string{
+		string(m.Kind),
+		m.UUID,
+		m.CreatedAt.Format(time.RFC3339Nano),
+		m.UpdatedAt.Format(time.RFC3339Nano),
+		m.OrganizationUUID,
+		m.BalanceUUID,
+		strconv.FormatInt(m.Amount, 10),
+		strconv.FormatInt(m.Fees, 10),
+		strconv.FormatInt(m.BalanceBefore, 10),
+		strconv.FormatInt(m.BalanceAfter, 10),
+		m.Status,
+		m.VendorRecordID,
+		m.RecordUUID,
+		m.TransactionType,
+	}
+}
+
+var csvHeader = []string{
+	"kind", "uuid", "created_at", "updated_at", "organization_uuid", "balance_uuid", "amount", "fees",
+	"balance_before", "balance_after", "status", "vendor_record_id", "record_uuid", "transaction_type",
+}
+
+type Encoder interface {
+	Encode(movement *Movement) error
+	Flush() error
+}
+
+type ndjsonEncoder struct {
+	encoder *json.Encoder
+}
+
+func (e *ndjsonEncoder) Encode(movement *Movement) error {
+	return e.encoder.Encode(movement)
+}
+
+func (e *ndjsonEncoder) Flush() error {
+	return nil
+}
+
+type csvEncoder struct {
+	writer        *csv.Writer
+	headerWritten bool
+}
+
+func (e *csvEncoder) Encode(movement *Movement) error {
+	if !e.headerWritten {
+		errWrite := e.writer.Write(csvHeader)
+		if errWrite != nil {
+			return errWrite
+		}
+		e.headerWritten = true
+	}
+
+	return e.writer.Write(movement.csvRecord())
+}
+
+func (e *csvEncoder) Flush() error {
+	if !e.headerWritten {
+		errWrite := e.writer.Write(csvHeader)
+		if errWrite != nil {
+			return errWrite
+		}
+		e.headerWritten = true
+	}
+
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+func NewEncoder(format Format, writer io.Writer) (Encoder, error) {
+	switch format {
+	case FormatNDJSON, "":
+		return &ndjsonEncoder{encoder: json.NewEncoder(writer)}, nil
+	case FormatCSV:
+		return &csvEncoder{writer: csv.NewWriter(writer)}, nil
+	default:
+		return nil, UnsupportedFormat
+	}
+}
+
+func ContentType(format Format) string {
+	if format == FormatCSV {
+		return "text/csv"
+	}
+	return "application/x-ndjson"
+}
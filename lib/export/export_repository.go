@@ -0,0 +1,118 @@
+package export
+
+import (
+	"database/sql"
+	"paystore/config"
+	"time"
+)
+
+var streamMovementQuery = `
+	SELECT kind, uuid, created_at, updated_at, organization_uuid, balance_uuid, amount, fees,
+		balance_before, balance_after, status, vendor_record_id, record_uuid, transaction_type
+	FROM (
+		SELECT 'payment' AS kind, uuid, created_at, updated_at, organization_uuid, balance_uuid, amount, fees,
+			balance_before_payment AS balance_before, balance_after_payment AS balance_after, status,
+			vendor_record_id, '' AS record_uuid, '' AS transaction_type
+		FROM payment WHERE organization_uuid = $1 AND updated_at >= $2 AND updated_at < $3
+		UNION ALL
+		SELECT 'withdraw' AS kind, uuid, created_at, updated_at, organization_uuid, balance_uuid, amount, fees,
+			balance_before_withdraw AS balance_before, balance_after_withdraw AS balance_after, status,
+			vendor_record_id, '' AS record_uuid, '' AS transaction_type
+		FROM withdraw WHERE organization_uuid = $1 AND updated_at >= $2 AND updated_at < $3
+		UNION ALL
+		SELECT 'transaction' AS kind, t.uuid, t.created_at, t.updated_at, b.organization_uuid, t.balance_uuid,
+			0 AS amount, 0 AS fees, 0 AS balance_before, 0 AS balance_after, '' AS status,
+			'' AS vendor_record_id, t.record_uuid, t.transaction_type
+		FROM transaction t JOIN balance b ON b.uuid = t.balance_uuid
+		WHERE b.organization_uuid = $1 AND t.updated_at >= $2 AND t.updated_at < $3
+	) movement
+	WHERE (updated_at, kind, uuid) > ($4, $5, $6)
+	ORDER BY updated_at ASC, kind ASC, uuid ASC
+	LIMIT $7`
+
+type RepositoryClient interface {
+	Window(organizationUUID string, since time.Time) Window
+	Stream(window Window, encoder Encoder) (int64, error)
+}
+
+type Repository struct {
+	streamMovementStmt *sql.Stmt
+	batchSize          int64
+	settleLag          time.Duration
+}
+
+// Window closes the export range slightly in the past so rows from transactions that
+// are still in flight are picked up by the next run instead of being skipped.
+func (r *Repository) Window(organizationUUID string, since time.Time) Window {
+	return Window{
+		OrganizationUUID: organizationUUID,
+		Since:            since,
+		Until:            time.Now().Add(-r.settleLag).UTC(),
+	}
+}
+
+func (r *Repository) Stream(window Window, encoder Encoder) (int64, error) {
+	if window.OrganizationUUID == "" {
+		return 0, OrganizationRequired
+	}
+	if !window.Until.After(window.Since) {
+		return 0, encoder.Flush()
+	}
+
+	var total int64
+	var lastUpdatedAt time.Time
+	var lastKind Kind
+	var lastUUID string
+
+	for {
+		rows, errQuery := r.streamMovementStmt.Query(window.OrganizationUUID, window.Since, window.Until,
+			lastUpdatedAt, lastKind, lastUUID, r.batchSize)
+		if errQuery != nil {
+			return total, errQuery
+		}
+
+		var fetched int64
+		for rows.Next() {
+			movement := &Movement{}
+			errScan := rows.Scan(movement.ScanDestinations()...)
+			if errScan != nil {
+				rows.Close()
+				return total, errScan
+			}
+
+			errEncode := encoder.Encode(movement)
+			if errEncode != nil {
+				rows.Close()
+				return total, errEncode
+			}
+
+			lastUpdatedAt, lastKind, lastUUID = movement.UpdatedAt, movement.Kind, movement.UUID
+			fetched++
+		}
+		errRows := rows.Err()
+		rows.Close()
+		if errRows != nil {
+			return total, errRows
+		}
+
+		total += fetched
+		if fetched < r.batchSize {
+			break
+		}
+	}
+
+	return total, encoder.Flush()
+}
+
+func NewRepository(readDB *sql.DB, config *config.App) *Repository {
+	streamMovementStmt, err := readDB.Prepare(streamMovementQuery)
+	if err != nil {
+		panic(err)
+	}
+
+	return &Repository{
+		streamMovementStmt: streamMovementStmt,
+		batchSize:          config.ExportBatchSize,
+		settleLag:          config.ExportSettleLag,
+	}
+}
@@ -0,0 +1,40 @@
+package fee
+
+import "errors"
+
+// BasisPoints is the number of basis points in a whole; a PercentBps of 290 is 2.9%.
+const BasisPoints = 10000
+
+// Kind is what a schedule prices: incoming payments or outgoing withdraws.
+type Kind string
+
+const (
+	KindPayment  Kind = "payment"
+	KindWithdraw Kind = "withdraw"
+)
+
+// RoundingMode decides how the percentage part of a fee is rounded to the smallest currency
+// unit. The empty mode rounds down, as fees were rounded before modes were configurable.
+type RoundingMode string
+
+const (
+	RoundDown     RoundingMode = "down"
+	RoundUp       RoundingMode = "up"
+	RoundHalfUp   RoundingMode = "half-up"
+	RoundHalfEven RoundingMode = "half-even"
+)
+
+// DefaultChannel is the channel of the rule applied when no rule names the payment's channel.
+const DefaultChannel = ""
+
+var ScheduleNotFound = errors.New("Fee schedule not found")
+var InvalidRule = errors.New("Fee rule amounts must not be negative and percent must not exceed 100%")
+var InvalidRange = errors.New("Fee rule maximum must not be below its minimum")
+var DuplicateChannel = errors.New("Fee schedule has more than one rule for a channel")
+var InvalidRate = errors.New("Fee rule rate must be a fraction between 0 and 1 and cannot be combined with basis points")
+var UnknownRoundingMode = errors.New("Unknown fee rounding mode")
+var RetroactiveVersion = errors.New("Fee schedule version cannot take effect in the past")
+var VersionConflict = errors.New("Another fee schedule version takes effect at the same time")
+var UnknownKind = errors.New("Unknown fee schedule kind")
+var NoMatchingRule = errors.New("Fee schedule has no rule for the channel and no default rule")
+var InvalidColumn = errors.New("Fee column does not hold JSON")
@@ -0,0 +1,310 @@
+package fee
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"github.com/21strive/item"
+	"github.com/21strive/redifu"
+	"math/big"
+	"paystore/lib/helper"
+	"time"
+)
+
+// Rule prices payments or withdraws on one channel as a percentage of the amount plus Fixed,
+// clamped to [Min, Max]. A zero Min or Max leaves that side open. The percentage is either
+// PercentBps or, for rates basis points cannot express, RateNumerator/RateDenominator.
+type Rule struct {
+	Channel         string `json:"channel"`
+	PercentBps      int64  `json:"percentBps"`
+	RateNumerator   int64  `json:"rateNumerator,omitempty"`
+	RateDenominator int64  `json:"rateDenominator,omitempty"`
+	Fixed           int64  `json:"fixed"`
+	Min             int64  `json:"min"`
+	Max             int64  `json:"max"`
+}
+
+func (r Rule) Validate() error {
+	if r.PercentBps < 0 || r.PercentBps > BasisPoints || r.Fixed < 0 || r.Min < 0 || r.Max < 0 {
+		return InvalidRule
+	}
+	if r.RateDenominator != 0 || r.RateNumerator != 0 {
+		if r.PercentBps != 0 || r.RateDenominator <= 0 || r.RateNumerator < 0 || r.RateNumerator > r.RateDenominator {
+			return InvalidRate
+		}
+	}
+	if r.Max > 0 && r.Max < r.Min {
+		return InvalidRange
+	}
+	return nil
+}
+
+// Rate returns the percentage of the rule as a fraction of the amount.
+func (r Rule) Rate() (int64, int64) {
+	if r.RateDenominator > 0 {
+		return r.RateNumerator, r.RateDenominator
+	}
+	return r.PercentBps, BasisPoints
+}
+
+// Evaluate prices amount with the rule, rounding the percentage part with mode.
+func (r Rule) Evaluate(amount int64, mode RoundingMode) Breakdown {
+	numerator, denominator := r.Rate()
+	percentFee, residue := mode.Apply(amount, numerator, denominator)
+	breakdown := Breakdown{
+		Channel:         r.Channel,
+		PercentBps:      r.PercentBps,
+		RateNumerator:   numerator,
+		RateDenominator: denominator,
+		Rounding:        mode.orDefault(),
+		PercentFee:      percentFee,
+		Residue:         residue,
+		FixedFee:        r.Fixed,
+	}
+
+	subtotal := breakdown.PercentFee + breakdown.FixedFee
+	if r.Min > 0 && subtotal < r.Min {
+		breakdown.Adjustment = r.Min - subtotal
+	}
+	if r.Max > 0 && subtotal > r.Max {
+		breakdown.Adjustment = r.Max - subtotal
+	}
+	breakdown.Total = subtotal + breakdown.Adjustment
+	return breakdown
+}
+
+func (m RoundingMode) Validate() error {
+	switch m {
+	case "", RoundDown, RoundUp, RoundHalfUp, RoundHalfEven:
+		return nil
+	}
+	return UnknownRoundingMode
+}
+
+func (m RoundingMode) orDefault() RoundingMode {
+	if m == "" {
+		return RoundDown
+	}
+	return m
+}
+
+// Apply computes amount * numerator / denominator rounded with the mode. The residue is what
+// rounding added, in units of 1/denominator of the smallest currency unit, so that
+// amount*numerator + residue == fee*denominator holds exactly.
+func (m RoundingMode) Apply(amount int64, numerator int64, denominator int64) (int64, int64) {
+	exact := new(big.Int).Mul(big.NewInt(amount), big.NewInt(numerator))
+	divisor := big.NewInt(denominator)
+	quotient, remainder := new(big.Int).QuoRem(exact, divisor, new(big.Int))
+
+	if remainder.Sign() != 0 {
+		twice := new(big.Int).Lsh(remainder, 1)
+		roundUp := false
+		switch m.orDefault() {
+		case RoundUp:
+			roundUp = true
+		case RoundHalfUp:
+			roundUp = twice.Cmp(divisor) >= 0
+		case RoundHalfEven:
+			half := twice.Cmp(divisor)
+			roundUp = half > 0 || (half == 0 && quotient.Bit(0) == 1)
+		}
+		if roundUp {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+
+	residue := new(big.Int).Mul(quotient, divisor)
+	residue.Sub(residue, exact)
+	return quotient.Int64(), residue.Int64()
+}
+
+// Schedule is one version of the fee contract of an organization for one kind of movement:
+// one rule per channel, plus an optional default rule for every other channel. A version is
+// in force from EffectiveFrom until EffectiveTo; a zero EffectiveTo leaves it open.
+type Schedule struct {
+	*redifu.Record
+	OrganizationUUID string    `json:"organizationUUID"`
+	Kind             Kind      `json:"kind"`
+	Rules            []Rule    `json:"rules"`
+	EffectiveFrom    time.Time `json:"effectiveFrom"`
+	EffectiveTo      time.Time `json:"effectiveTo,omitempty"`
+	// Rounding is the organization's rounding mode. It is set when the schedule is loaded
+	// for pricing and is not stored with the schedule.
+	Rounding RoundingMode `json:"rounding"`
+}
+
+func (s *Schedule) SetRules(rules []Rule) error {
+	channels := make(map[string]bool)
+	for _, rule := range rules {
+		errValidate := rule.Validate()
+		if errValidate != nil {
+			return errValidate
+		}
+		if channels[rule.Channel] {
+			return DuplicateChannel
+		}
+		channels[rule.Channel] = true
+	}
+
+	s.Rules = rules
+	return nil
+}
+
+// SetEffectiveFrom schedules the version to take effect at effectiveFrom, or right away when
+// it is zero. Versions cannot take effect in the past, where payments were already priced.
+func (s *Schedule) SetEffectiveFrom(effectiveFrom time.Time, now time.Time) error {
+	if effectiveFrom.IsZero() {
+		effectiveFrom = now
+	}
+	if effectiveFrom.Before(now) {
+		return RetroactiveVersion
+	}
+	s.EffectiveFrom = effectiveFrom
+	return nil
+}
+
+// InForce reports whether the version prices movements created at the time.
+func (s *Schedule) InForce(at time.Time) bool {
+	return !at.Before(s.EffectiveFrom) && (s.EffectiveTo.IsZero() || at.Before(s.EffectiveTo))
+}
+
+// Supersede fits the version among versions, the other versions of its organization and kind.
+// The version in force when it takes effect ends there, and it runs until the next version
+// already scheduled, if any. Supersede returns the versions whose end it changed.
+func (s *Schedule) Supersede(versions []*Schedule) ([]*Schedule, error) {
+	var ended []*Schedule
+	s.EffectiveTo = time.Time{}
+	for _, version := range versions {
+		if version.EffectiveFrom.Equal(s.EffectiveFrom) {
+			return nil, VersionConflict
+		}
+		if version.EffectiveFrom.After(s.EffectiveFrom) {
+			if s.EffectiveTo.IsZero() || version.EffectiveFrom.Before(s.EffectiveTo) {
+				s.EffectiveTo = version.EffectiveFrom
+			}
+			continue
+		}
+		if version.InForce(s.EffectiveFrom) {
+			version.EffectiveTo = s.EffectiveFrom
+			ended = append(ended, version)
+		}
+	}
+	return ended, nil
+}
+
+// Rule returns the rule for channel, falling back to the default rule.
+func (s *Schedule) Rule(channel string) (Rule, error) {
+	var defaultRule *Rule
+	for i, rule := range s.Rules {
+		if rule.Channel == channel {
+			return rule, nil
+		}
+		if rule.Channel == DefaultChannel {
+			defaultRule = &s.Rules[i]
+		}
+	}
+	if defaultRule == nil {
+		return Rule{}, NoMatchingRule
+	}
+	return *defaultRule, nil
+}
+
+func (s *Schedule) Evaluate(amount int64, channel string) (Breakdown, error) {
+	rule, errRule := s.Rule(channel)
+	if errRule != nil {
+		return Breakdown{}, errRule
+	}
+
+	breakdown := rule.Evaluate(amount, s.Rounding)
+	breakdown.ScheduleUUID = s.GetUUID()
+	return breakdown, nil
+}
+
+func (s *Schedule) ScanDestinations() []interface{} {
+	return []interface{}{
+		&s.UUID,
+		&s.RandId,
+		&s.CreatedAt,
+		&s.UpdatedAt,
+		&s.OrganizationUUID,
+		&s.Kind,
+		(*rules)(&s.Rules),
+		&s.EffectiveFrom,
+		helper.NullableDestinations([]interface{}{&s.EffectiveTo})[0],
+	}
+}
+
+func NewSchedule(organizationUUID string, kind Kind) (*Schedule, error) {
+	if kind != KindPayment && kind != KindWithdraw {
+		return nil, UnknownKind
+	}
+
+	schedule := &Schedule{}
+	redifu.InitRecord(schedule)
+	schedule.OrganizationUUID = organizationUUID
+	schedule.Kind = kind
+	return schedule, nil
+}
+
+// FlatSchedule is a single default rule, for organizations priced by a flat fee or percentage
+// rather than a schedule of their own. It is not stored, so its UUID is empty and it has no
+// effective dates.
+func FlatSchedule(organizationUUID string, kind Kind, percentBps int64, fixed int64) *Schedule {
+	schedule := &Schedule{Record: &redifu.Record{Foundation: &item.Foundation{}}}
+	schedule.OrganizationUUID = organizationUUID
+	schedule.Kind = kind
+	schedule.Rules = []Rule{{Channel: DefaultChannel, PercentBps: percentBps, Fixed: fixed}}
+	return schedule
+}
+
+// Breakdown records how the fee of a payment or withdraw was derived, so it can be explained and
+// recomputed after the schedule changes. Channel is the channel of the rule applied, empty
+// for the default rule, and Total is the fee charged. The percentage part is the rate
+// RateNumerator/RateDenominator of the amount, rounded with Rounding; Residue is what the
+// rounding added, in units of 1/RateDenominator of the smallest currency unit.
+type Breakdown struct {
+	ScheduleUUID    string       `json:"scheduleUUID,omitempty"`
+	Channel         string       `json:"channel"`
+	PercentBps      int64        `json:"percentBps"`
+	RateNumerator   int64        `json:"rateNumerator"`
+	RateDenominator int64        `json:"rateDenominator"`
+	Rounding        RoundingMode `json:"rounding"`
+	PercentFee      int64        `json:"percentFee"`
+	Residue         int64        `json:"residue"`
+	FixedFee        int64        `json:"fixedFee"`
+	Adjustment      int64        `json:"adjustment"`
+	Total           int64        `json:"total"`
+}
+
+func (b Breakdown) Value() (driver.Value, error) {
+	return json.Marshal(b)
+}
+
+func (b *Breakdown) Scan(src interface{}) error {
+	return scanJSON(src, b)
+}
+
+// rules stores a schedule's rules as one JSONB column.
+type rules []Rule
+
+func (r rules) Value() (driver.Value, error) {
+	if r == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal([]Rule(r))
+}
+
+func (r *rules) Scan(src interface{}) error {
+	return scanJSON(src, (*[]Rule)(r))
+}
+
+func scanJSON(src interface{}, destination interface{}) error {
+	switch value := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(value, destination)
+	case string:
+		return json.Unmarshal([]byte(value), destination)
+	}
+	return InvalidColumn
+}
@@ -0,0 +1,276 @@
+package fee
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRuleValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		rule Rule
+		want error
+	}{
+		{"percent and fixed", Rule{PercentBps: 290, Fixed: 2000, Min: 1000, Max: 50000}, nil},
+		{"exact rate", Rule{RateNumerator: 1, RateDenominator: 3}, nil},
+		{"negative fixed", Rule{Fixed: -1}, InvalidRule},
+		{"percent above 100%", Rule{PercentBps: BasisPoints + 1}, InvalidRule},
+		{"rate with basis points", Rule{PercentBps: 100, RateNumerator: 1, RateDenominator: 3}, InvalidRate},
+		{"rate above 1", Rule{RateNumerator: 4, RateDenominator: 3}, InvalidRate},
+		{"rate without denominator", Rule{RateNumerator: 1}, InvalidRate},
+		{"max below min", Rule{Min: 5000, Max: 1000}, InvalidRange},
+	}
+	for _, test := range tests {
+		if errValidate := test.rule.Validate(); errValidate != test.want {
+			t.Errorf("%s: Validate() = %v, want %v", test.name, errValidate, test.want)
+		}
+	}
+}
+
+func TestRuleEvaluate(t *testing.T) {
+	tests := []struct {
+		name       string
+		rule       Rule
+		amount     int64
+		adjustment int64
+		total      int64
+	}{
+		{"percent plus fixed", Rule{PercentBps: 290, Fixed: 2000}, 100000, 0, 4900},
+		{"raised to min", Rule{PercentBps: 100, Min: 1500}, 10000, 1400, 1500},
+		{"capped at max", Rule{PercentBps: 500, Fixed: 1000, Max: 20000}, 1000000, -31000, 20000},
+		{"open range", Rule{Fixed: 2500}, 0, 0, 2500},
+	}
+	for _, test := range tests {
+		breakdown := test.rule.Evaluate(test.amount, "")
+		if breakdown.Adjustment != test.adjustment || breakdown.Total != test.total {
+			t.Errorf("%s: adjustment %d total %d, want %d %d", test.name, breakdown.Adjustment, breakdown.Total,
+				test.adjustment, test.total)
+		}
+		if breakdown.PercentFee+breakdown.FixedFee+breakdown.Adjustment != breakdown.Total {
+			t.Errorf("%s: breakdown does not add up: %+v", test.name, breakdown)
+		}
+	}
+}
+
+func TestScheduleRule(t *testing.T) {
+	schedule := FlatSchedule("organization", KindPayment, 100, 0)
+	errRules := schedule.SetRules([]Rule{
+		{Channel: "QRIS", PercentBps: 70},
+		{Channel: DefaultChannel, PercentBps: 290},
+	})
+	if errRules != nil {
+		t.Fatalf("SetRules: %v", errRules)
+	}
+
+	if rule, _ := schedule.Rule("QRIS"); rule.PercentBps != 70 {
+		t.Errorf("QRIS priced at %d bps, want 70", rule.PercentBps)
+	}
+	if rule, _ := schedule.Rule("VA_BCA"); rule.PercentBps != 290 {
+		t.Errorf("unlisted channel priced at %d bps, want the default 290", rule.PercentBps)
+	}
+
+	errRules = schedule.SetRules([]Rule{{Channel: "QRIS"}, {Channel: "QRIS"}})
+	if errRules != DuplicateChannel {
+		t.Errorf("SetRules with a duplicate channel = %v, want %v", errRules, DuplicateChannel)
+	}
+
+	schedule.Rules = []Rule{{Channel: "QRIS", PercentBps: 70}}
+	if _, errRule := schedule.Rule("VA_BCA"); errRule != NoMatchingRule {
+		t.Errorf("Rule without a default = %v, want %v", errRule, NoMatchingRule)
+	}
+}
+
+func TestNewScheduleKind(t *testing.T) {
+	if _, errNew := NewSchedule("organization", Kind("refund")); errNew != UnknownKind {
+		t.Errorf("NewSchedule with an unknown kind = %v, want %v", errNew, UnknownKind)
+	}
+}
+
+func TestRoundingModeApply(t *testing.T) {
+	tests := []struct {
+		mode    RoundingMode
+		amount  int64
+		fee     int64
+		residue int64
+	}{
+		// 2.9% of 12345 is 358.005.
+		{"", 12345, 358, -50},
+		{RoundDown, 12345, 358, -50},
+		{RoundUp, 12345, 359, 9950},
+		{RoundHalfUp, 12345, 358, -50},
+		// 2.9% of 50 is 1.45 and of 150 is 4.35.
+		{RoundHalfUp, 50, 1, -4500},
+		{RoundHalfUp, 150, 4, -3500},
+		// 2.9% of 5000 is exactly 145.
+		{RoundUp, 5000, 145, 0},
+	}
+	for _, test := range tests {
+		fee, residue := test.mode.Apply(test.amount, 290, BasisPoints)
+		if fee != test.fee || residue != test.residue {
+			t.Errorf("%q.Apply(%d) = %d, %d; want %d, %d", test.mode, test.amount, fee, residue, test.fee, test.residue)
+		}
+	}
+}
+
+func TestRoundingModeApplyHalfway(t *testing.T) {
+	// 50% of an odd amount lands exactly halfway between two units.
+	tests := []struct {
+		mode   RoundingMode
+		amount int64
+		fee    int64
+	}{
+		{RoundDown, 5, 2},
+		{RoundUp, 5, 3},
+		{RoundHalfUp, 5, 3},
+		{RoundHalfUp, 7, 4},
+		{RoundHalfEven, 5, 2},
+		{RoundHalfEven, 7, 4},
+	}
+	for _, test := range tests {
+		if fee, _ := test.mode.Apply(test.amount, 5000, BasisPoints); fee != test.fee {
+			t.Errorf("%q.Apply(%d) = %d, want %d", test.mode, test.amount, fee, test.fee)
+		}
+	}
+}
+
+func TestRoundingModeResidueBalances(t *testing.T) {
+	modes := []RoundingMode{RoundDown, RoundUp, RoundHalfUp, RoundHalfEven}
+	rates := [][2]int64{{290, BasisPoints}, {1, 3}, {7, 9}, {BasisPoints, BasisPoints}}
+	for _, mode := range modes {
+		for _, rate := range rates {
+			for amount := int64(0); amount < 1000; amount += 37 {
+				fee, residue := mode.Apply(amount, rate[0], rate[1])
+				if amount*rate[0]+residue != fee*rate[1] {
+					t.Fatalf("%q.Apply(%d, %d/%d) = %d, %d does not balance", mode, amount, rate[0], rate[1], fee,
+						residue)
+				}
+				if residue <= -rate[1] || residue >= rate[1] {
+					t.Fatalf("%q.Apply(%d, %d/%d) residue %d is a whole unit or more", mode, amount, rate[0],
+						rate[1], residue)
+				}
+			}
+		}
+	}
+}
+
+func TestRoundingModeValidate(t *testing.T) {
+	if errValidate := RoundingMode("").Validate(); errValidate != nil {
+		t.Errorf("empty mode rejected: %v", errValidate)
+	}
+	if errValidate := RoundingMode("bankers").Validate(); errValidate != UnknownRoundingMode {
+		t.Errorf("Validate(bankers) = %v, want %v", errValidate, UnknownRoundingMode)
+	}
+}
+
+func TestScheduleEvaluateRecordsRounding(t *testing.T) {
+	schedule := FlatSchedule("organization", KindPayment, 0, 0)
+	schedule.Rules = []Rule{{Channel: DefaultChannel, RateNumerator: 1, RateDenominator: 3}}
+	schedule.Rounding = RoundHalfEven
+
+	breakdown, errEvaluate := schedule.Evaluate(100, "QRIS")
+	if errEvaluate != nil {
+		t.Fatalf("Evaluate: %v", errEvaluate)
+	}
+	if breakdown.Rounding != RoundHalfEven || breakdown.RateNumerator != 1 || breakdown.RateDenominator != 3 {
+		t.Errorf("breakdown does not record the rate and rounding: %+v", breakdown)
+	}
+	if breakdown.PercentFee != 33 || breakdown.Residue != -1 || breakdown.Total != 33 {
+		t.Errorf("percent fee %d residue %d total %d, want 33 -1 33", breakdown.PercentFee, breakdown.Residue,
+			breakdown.Total)
+	}
+
+	schedule.Rounding = ""
+	breakdown, _ = schedule.Evaluate(100, "QRIS")
+	if breakdown.Rounding != RoundDown {
+		t.Errorf("default rounding recorded as %q, want %q", breakdown.Rounding, RoundDown)
+	}
+}
+
+func version(from time.Time, to time.Time) *Schedule {
+	schedule := FlatSchedule("organization", KindPayment, 290, 0)
+	schedule.EffectiveFrom = from
+	schedule.EffectiveTo = to
+	return schedule
+}
+
+func TestScheduleSupersede(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 5, d, 0, 0, 0, 0, time.UTC) }
+
+	t.Run("ends the version in force", func(t *testing.T) {
+		current := version(day(1), time.Time{})
+		next := version(day(10), time.Time{})
+		ended, errSupersede := next.Supersede([]*Schedule{current})
+		if errSupersede != nil {
+			t.Fatalf("Supersede: %v", errSupersede)
+		}
+		if len(ended) != 1 || ended[0] != current || !current.EffectiveTo.Equal(day(10)) {
+			t.Errorf("current version ends at %v, want %v", current.EffectiveTo, day(10))
+		}
+		if !next.EffectiveTo.IsZero() {
+			t.Errorf("latest version ends at %v, want open", next.EffectiveTo)
+		}
+	})
+
+	t.Run("runs until the next scheduled version", func(t *testing.T) {
+		current := version(day(1), day(20))
+		scheduled := version(day(20), time.Time{})
+		later := version(day(25), time.Time{})
+		inserted := version(day(10), time.Time{})
+		ended, errSupersede := inserted.Supersede([]*Schedule{later, current, scheduled})
+		if errSupersede != nil {
+			t.Fatalf("Supersede: %v", errSupersede)
+		}
+		if !inserted.EffectiveTo.Equal(day(20)) {
+			t.Errorf("inserted version ends at %v, want %v", inserted.EffectiveTo, day(20))
+		}
+		if len(ended) != 1 || !current.EffectiveTo.Equal(day(10)) {
+			t.Errorf("ended %d versions, current ends at %v", len(ended), current.EffectiveTo)
+		}
+		if !scheduled.EffectiveTo.IsZero() || !later.EffectiveTo.IsZero() {
+			t.Errorf("versions after the inserted one were changed")
+		}
+	})
+
+	t.Run("leaves expired versions alone", func(t *testing.T) {
+		expired := version(day(1), day(5))
+		next := version(day(10), time.Time{})
+		ended, _ := next.Supersede([]*Schedule{expired})
+		if len(ended) != 0 || !expired.EffectiveTo.Equal(day(5)) {
+			t.Errorf("expired version was changed to end at %v", expired.EffectiveTo)
+		}
+	})
+
+	t.Run("rejects a version at the same time", func(t *testing.T) {
+		current := version(day(10), time.Time{})
+		_, errSupersede := version(day(10), time.Time{}).Supersede([]*Schedule{current})
+		if errSupersede != VersionConflict {
+			t.Errorf("Supersede = %v, want %v", errSupersede, VersionConflict)
+		}
+		if !current.EffectiveTo.IsZero() {
+			t.Errorf("conflicting version changed the current one")
+		}
+	})
+}
+
+func TestScheduleInForce(t *testing.T) {
+	from := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	schedule := version(from, from.Add(24*time.Hour))
+	if !schedule.InForce(from) || schedule.InForce(from.Add(-time.Second)) || schedule.InForce(from.Add(24*time.Hour)) {
+		t.Errorf("version in force outside [from, to)")
+	}
+	schedule.EffectiveTo = time.Time{}
+	if !schedule.InForce(from.AddDate(10, 0, 0)) {
+		t.Errorf("open version not in force")
+	}
+}
+
+func TestScheduleSetEffectiveFrom(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	schedule := version(time.Time{}, time.Time{})
+	if errSet := schedule.SetEffectiveFrom(time.Time{}, now); errSet != nil || !schedule.EffectiveFrom.Equal(now) {
+		t.Errorf("zero effective date = %v, %v; want now", schedule.EffectiveFrom, errSet)
+	}
+	if errSet := schedule.SetEffectiveFrom(now.Add(-time.Minute), now); errSet != RetroactiveVersion {
+		t.Errorf("past effective date = %v, want %v", errSet, RetroactiveVersion)
+	}
+}
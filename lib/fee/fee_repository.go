@@ -0,0 +1,123 @@
+package fee
+
+import (
+	"database/sql"
+	"github.com/21strive/redifu"
+	"time"
+)
+
+var scheduleColumns = `uuid, randid, created_at, updated_at, organization_uuid, kind, rules, effective_from, effective_to`
+
+var createScheduleQuery = `INSERT INTO fee_schedule (` + scheduleColumns + `)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+var endScheduleQuery = `UPDATE fee_schedule SET effective_to = $1, updated_at = $2 WHERE uuid = $3`
+var findScheduleInForceQuery = `SELECT ` + scheduleColumns + ` FROM fee_schedule
+	WHERE organization_uuid = $1 AND kind = $2 AND effective_from <= $3 AND (effective_to IS NULL OR effective_to > $3)
+	ORDER BY effective_from DESC LIMIT 1`
+var findScheduleVersionsQuery = `SELECT ` + scheduleColumns + ` FROM fee_schedule
+	WHERE organization_uuid = $1 AND kind = $2 ORDER BY effective_from`
+
+// lockOrganizationQuery serializes scheduling per organization, including its first version,
+// which has no existing version row to lock.
+var lockOrganizationQuery = `SELECT uuid FROM organization WHERE uuid = $1 FOR UPDATE`
+
+type RepositoryClient interface {
+	Create(tx *sql.Tx, schedule *Schedule) error
+	End(tx *sql.Tx, schedule *Schedule) error
+	FindInForce(organizationUUID string, kind Kind, at time.Time) (*Schedule, error)
+	FindVersions(organizationUUID string, kind Kind) ([]*Schedule, error)
+	FindVersionsForUpdate(tx *sql.Tx, organizationUUID string, kind Kind) ([]*Schedule, error)
+}
+
+type Repository struct {
+	findScheduleInForceStmt  *sql.Stmt
+	findScheduleVersionsStmt *sql.Stmt
+}
+
+func (r *Repository) Create(tx *sql.Tx, schedule *Schedule) error {
+	_, errExec := tx.Exec(createScheduleQuery, schedule.GetUUID(), schedule.GetRandId(), schedule.GetCreatedAt(),
+		schedule.GetUpdatedAt(), schedule.OrganizationUUID, schedule.Kind, rules(schedule.Rules),
+		schedule.EffectiveFrom, nullTime(schedule.EffectiveTo))
+	return errExec
+}
+
+// End stores the effective end of a version superseded by a newer one.
+func (r *Repository) End(tx *sql.Tx, schedule *Schedule) error {
+	schedule.SetUpdatedAt(time.Now())
+	_, errExec := tx.Exec(endScheduleQuery, nullTime(schedule.EffectiveTo), schedule.GetUpdatedAt(),
+		schedule.GetUUID())
+	return errExec
+}
+
+func (r *Repository) FindInForce(organizationUUID string, kind Kind, at time.Time) (*Schedule, error) {
+	schedule := &Schedule{}
+	redifu.InitRecord(schedule)
+	errScan := r.findScheduleInForceStmt.QueryRow(organizationUUID, kind, at).Scan(schedule.ScanDestinations()...)
+	if errScan != nil {
+		if errScan == sql.ErrNoRows {
+			return nil, ScheduleNotFound
+		}
+		return nil, errScan
+	}
+	return schedule, nil
+}
+
+func (r *Repository) FindVersions(organizationUUID string, kind Kind) ([]*Schedule, error) {
+	rows, errQuery := r.findScheduleVersionsStmt.Query(organizationUUID, kind)
+	if errQuery != nil {
+		return nil, errQuery
+	}
+	return scanSchedules(rows)
+}
+
+func (r *Repository) FindVersionsForUpdate(tx *sql.Tx, organizationUUID string, kind Kind) ([]*Schedule, error) {
+	_, errLock := tx.Exec(lockOrganizationQuery, organizationUUID)
+	if errLock != nil {
+		return nil, errLock
+	}
+
+	rows, errQuery := tx.Query(findScheduleVersionsQuery, organizationUUID, kind)
+	if errQuery != nil {
+		return nil, errQuery
+	}
+	return scanSchedules(rows)
+}
+
+func scanSchedules(rows *sql.Rows) ([]*Schedule, error) {
+	defer rows.Close()
+
+	var schedules []*Schedule
+	for rows.Next() {
+		schedule := &Schedule{}
+		redifu.InitRecord(schedule)
+		errScan := rows.Scan(schedule.ScanDestinations()...)
+		if errScan != nil {
+			return nil, errScan
+		}
+		schedules = append(schedules, schedule)
+	}
+	if errRows := rows.Err(); errRows != nil {
+		return nil, errRows
+	}
+	return schedules, nil
+}
+
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
+
+func NewRepository(readDB *sql.DB) *Repository {
+	findScheduleInForceStmt, err := readDB.Prepare(findScheduleInForceQuery)
+	if err != nil {
+		panic(err)
+	}
+	findScheduleVersionsStmt, err := readDB.Prepare(findScheduleVersionsQuery)
+	if err != nil {
+		panic(err)
+	}
+
+	return &Repository{
+		findScheduleInForceStmt:  findScheduleInForceStmt,
+		findScheduleVersionsStmt: findScheduleVersionsStmt,
+	}
+}
@@ -4,11 +4,13 @@ import (
 	"bytes"
 	"context"
 	"database/sql"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/21strive/item"
 	"github.com/gofiber/fiber/v2"
+	"github.com/lib/pq"
 	"github.com/redis/go-redis/v9"
 	"log"
 	"log/slog"
@@ -164,3 +166,170 @@ func FetchColumns(s interface{}) []string {
 
 	return tags
 }
+
+var InvalidCursor = errors.New("Invalid cursor")
+
+func EncodeCursor(createdAt time.Time, uuid string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(createdAt.UTC().Format(time.RFC3339Nano) + "|" + uuid))
+}
+
+func DecodeCursor(cursor string) (time.Time, string, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, "", InvalidCursor
+	}
+
+	parts := strings.SplitN(string(decoded), "|", 2)
+	if len(parts) != 2 {
+		return time.Time{}, "", InvalidCursor
+	}
+
+	createdAt, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return time.Time{}, "", InvalidCursor
+	}
+
+	return createdAt, parts[1], nil
+}
+
+// ScanValues dereferences scan destinations so the same field order can be reused as query arguments.
+func ScanValues(destinations []interface{}) []interface{} {
+	values := make([]interface{}, len(destinations))
+	for i, destination := range destinations {
+		value := reflect.ValueOf(destination).Elem().Interface()
+		if list, ok := value.([]string); ok {
+			value = pq.Array(list)
+		}
+		values[i] = value
+	}
+	return values
+}
+
+type ColumnDefinition struct {
+	Name    string
+	SQLType string
+}
+
+// FetchColumnDefinitions walks the same db tags as FetchColumns and maps each field's Go type to a Postgres type.
+func FetchColumnDefinitions(s interface{}) []ColumnDefinition {
+	var definitions []ColumnDefinition
+
+	t := reflect.TypeOf(s)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return definitions
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		if tag, ok := field.Tag.Lookup("db"); ok {
+			definitions = append(definitions, ColumnDefinition{Name: tag, SQLType: sqlType(field.Type)})
+		}
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+
+		if fieldType.Kind() == reflect.Struct {
+			nestedValue := reflect.New(fieldType).Elem().Interface()
+			definitions = append(definitions, FetchColumnDefinitions(nestedValue)...)
+		}
+	}
+
+	return definitions
+}
+
+func sqlType(t reflect.Type) string {
+	if t == reflect.TypeOf(time.Time{}) {
+		return "TIMESTAMP WITH TIME ZONE"
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return "BOOL"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "BIGINT"
+	case reflect.Float32, reflect.Float64:
+		return "DOUBLE PRECISION"
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.String {
+			return "TEXT[]"
+		}
+		return "JSONB"
+	default:
+		return "TEXT"
+	}
+}
+
+type nullableDestination struct {
+	destination interface{}
+}
+
+// Scan leaves the destination at its zero value for NULL, which LEFT JOINed vendor columns produce.
+func (n nullableDestination) Scan(src interface{}) error {
+	if src == nil {
+		return nil
+	}
+
+	switch destination := n.destination.(type) {
+	case sql.Scanner:
+		return destination.Scan(src)
+	case *[]string:
+		return pq.Array(destination).Scan(src)
+	case *time.Time:
+		value, ok := src.(time.Time)
+		if !ok {
+			return fmt.Errorf("cannot scan %T into *time.Time", src)
+		}
+		*destination = value
+		return nil
+	}
+
+	target := reflect.ValueOf(n.destination).Elem()
+	switch value := src.(type) {
+	case []byte:
+		if target.Kind() == reflect.String {
+			target.SetString(string(value))
+			return nil
+		}
+	case string:
+		if target.Kind() == reflect.String {
+			target.SetString(value)
+			return nil
+		}
+	case int64:
+		switch target.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			target.SetInt(value)
+			return nil
+		case reflect.Float32, reflect.Float64:
+			target.SetFloat(float64(value))
+			return nil
+		}
+	case float64:
+		switch target.Kind() {
+		case reflect.Float32, reflect.Float64:
+			target.SetFloat(value)
+			return nil
+		}
+	case bool:
+		if target.Kind() == reflect.Bool {
+			target.SetBool(value)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("cannot scan %T into %s", src, target.Type())
+}
+
+func NullableDestinations(destinations []interface{}) []interface{} {
+	nullable := make([]interface{}, len(destinations))
+	for i, destination := range destinations {
+		nullable[i] = nullableDestination{destination: destination}
+	}
+	return nullable
+}
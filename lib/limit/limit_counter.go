@@ -0,0 +1,197 @@
+package limit
+
+import (
+	"context"
+	"github.com/redis/go-redis/v9"
+	"strconv"
+	"time"
+)
+
+// reserveScript counts a movement against the rolling windows of every scope, or against none
+// when any window would exceed its limit. KEYS holds bucketsPerScope keys per scope, laid out
+// by Usage.keys: the members counted that day, then the hour buckets of the day window and the
+// day buckets of the month window, newest first. Every bucket is a hash of the amount and
+// count counted in it, so the windows are summed over a fixed number of buckets whatever the
+// volume. ARGV holds the member, the amount, the hour, day and member TTLs in milliseconds,
+// then the daily total, monthly total and daily count limits of each scope in order. A member
+// counted already is not counted again.
+var reserveScript = redis.NewScript(`
+	local amount = tonumber(ARGV[2])
+	local stride = tonumber(ARGV[6])
+	local hours = tonumber(ARGV[7])
+	local scopes = #KEYS / stride
+	for s = 0, scopes - 1 do
+		local base = s * stride
+		local offset = 7 + s * 3
+		local dailyLimit = tonumber(ARGV[offset + 1])
+		local monthlyLimit = tonumber(ARGV[offset + 2])
+		local countLimit = tonumber(ARGV[offset + 3])
+		if redis.call("SISMEMBER", KEYS[base + 1], ARGV[1]) == 1 then
+			return ""
+		end
+		local dayTotal, monthTotal, dayCount = 0, 0, 0
+		for i = base + 2, base + 1 + hours do
+			local bucket = redis.call("HMGET", KEYS[i], "amount", "count")
+			dayTotal = dayTotal + (tonumber(bucket[1]) or 0)
+			dayCount = dayCount + (tonumber(bucket[2]) or 0)
+		end
+		for i = base + 2 + hours, base + stride do
+			monthTotal = monthTotal + (tonumber(redis.call("HGET", KEYS[i], "amount")) or 0)
+		end
+		if dailyLimit > 0 and dayTotal + amount > dailyLimit then
+			return "daily"
+		end
+		if monthlyLimit > 0 and monthTotal + amount > monthlyLimit then
+			return "monthly"
+		end
+		if countLimit > 0 and dayCount + 1 > countLimit then
+			return "count"
+		end
+	end
+	for s = 0, scopes - 1 do
+		local base = s * stride
+		local hour, day = KEYS[base + 2], KEYS[base + 2 + hours]
+		redis.call("SADD", KEYS[base + 1], ARGV[1])
+		redis.call("PEXPIRE", KEYS[base + 1], ARGV[5])
+		redis.call("HINCRBY", hour, "amount", amount)
+		redis.call("HINCRBY", hour, "count", 1)
+		redis.call("PEXPIRE", hour, ARGV[3])
+		redis.call("HINCRBY", day, "amount", amount)
+		redis.call("PEXPIRE", day, ARGV[4])
+	end
+	return ""`)
+
+// releaseScript uncounts a movement from the buckets it was counted in. KEYS holds the members,
+// hour bucket and day bucket of each scope; ARGV the member and its amount. A member that was
+// never counted, or was released already, is left alone, and buckets that have expired are not
+// brought back.
+var releaseScript = redis.NewScript(`
+	local amount = tonumber(ARGV[2])
+	for i = 1, #KEYS, 3 do
+		if redis.call("SREM", KEYS[i], ARGV[1]) == 1 then
+			if redis.call("EXISTS", KEYS[i + 1]) == 1 then
+				redis.call("HINCRBY", KEYS[i + 1], "amount", -amount)
+				redis.call("HINCRBY", KEYS[i + 1], "count", -1)
+			end
+			if redis.call("EXISTS", KEYS[i + 2]) == 1 then
+				redis.call("HINCRBY", KEYS[i + 2], "amount", -amount)
+			end
+		end
+	end
+	return ""`)
+
+// The day window is counted in hour buckets and the month window in day buckets, both ending
+// with the bucket of the movement, so a window spans between one bucket less and its full length.
+const (
+	dayBuckets      = int(Day / time.Hour)
+	monthBuckets    = int(Month / Day)
+	bucketsPerScope = 1 + dayBuckets + monthBuckets
+)
+
+var exceededErrors = map[string]error{
+	"daily":   DailyTotalExceeded,
+	"monthly": MonthlyTotalExceeded,
+	"count":   DailyCountExceeded,
+}
+
+// Usage is one payment or withdraw counted against the limits of its balance and organization.
+type Usage struct {
+	Kind             Kind
+	MovementUUID     string
+	OrganizationUUID string
+	BalanceUUID      string
+	Amount           int64
+	At               time.Time
+}
+
+func (u Usage) member() string {
+	return u.MovementUUID + ":" + strconv.FormatInt(u.Amount, 10)
+}
+
+// prefixes returns the organization and balance key prefixes. Both carry the organization
+// UUID as hash tag so the scripts can update them together on a cluster.
+func (u Usage) prefixes() []string {
+	prefix := "limit:{" + u.OrganizationUUID + "}:" + string(u.Kind) + ":"
+	return []string{
+		prefix + string(ScopeOrganization) + ":" + u.OrganizationUUID,
+		prefix + string(ScopeBalance) + ":" + u.BalanceUUID,
+	}
+}
+
+// keys returns, per scope, the members counted on the day of the usage followed by the hour
+// buckets of its day window and the day buckets of its month window, newest first.
+func (u Usage) keys() []string {
+	hour := u.At.Unix() / int64(time.Hour/time.Second)
+	day := u.At.Unix() / int64(Day/time.Second)
+	var keys []string
+	for _, prefix := range u.prefixes() {
+		keys = append(keys, prefix+":members:"+strconv.FormatInt(day, 10))
+		for i := int64(0); i < int64(dayBuckets); i++ {
+			keys = append(keys, prefix+":hour:"+strconv.FormatInt(hour-i, 10))
+		}
+		for i := int64(0); i < int64(monthBuckets); i++ {
+			keys = append(keys, prefix+":day:"+strconv.FormatInt(day-i, 10))
+		}
+	}
+	return keys
+}
+
+// releaseKeys returns, per scope, the members, hour bucket and day bucket the usage was
+// counted in.
+func (u Usage) releaseKeys() []string {
+	keys := u.keys()
+	var releaseKeys []string
+	for base := 0; base < len(keys); base += bucketsPerScope {
+		releaseKeys = append(releaseKeys, keys[base], keys[base+1], keys[base+1+dayBuckets])
+	}
+	return releaseKeys
+}
+
+type CounterClient interface {
+	Reserve(usage Usage, organizationLimit *Limit, balanceLimit *Limit) error
+	Release(usage Usage) error
+}
+
+// Counter keeps the rolling totals of every organization and balance in Redis. Movements are
+// counted whether or not a limit is set, so a limit set later applies to the month before it.
+type Counter struct {
+	redis redis.UniversalClient
+}
+
+// Reserve counts usage when neither limit would be exceeded by it. A nil limit leaves its
+// scope unlimited.
+func (c *Counter) Reserve(usage Usage, organizationLimit *Limit, balanceLimit *Limit) error {
+	for _, limit := range []*Limit{organizationLimit, balanceLimit} {
+		errCheck := limit.CheckAmount(usage.Kind, usage.Amount)
+		if errCheck != nil {
+			return errCheck
+		}
+	}
+
+	args := []interface{}{usage.member(), usage.Amount, (Day + time.Hour).Milliseconds(),
+		(Month + Day).Milliseconds(), (Month + Day).Milliseconds(), bucketsPerScope, dayBuckets}
+	for _, limit := range []*Limit{organizationLimit, balanceLimit} {
+		daily, monthly, count := limit.Windows(usage.Kind)
+		args = append(args, daily, monthly, count)
+	}
+
+	exceeded, errRun := reserveScript.Run(context.Background(), c.redis, usage.keys(), args...).Text()
+	if errRun != nil {
+		return errRun
+	}
+	if exceeded != "" {
+		return exceededErrors[exceeded]
+	}
+	return nil
+}
+
+// Release uncounts usage whose payment or withdraw was not created, or did not go through:
+// failed, expired or, for withdraws, rejected. Releasing usage twice uncounts it once.
+func (c *Counter) Release(usage Usage) error {
+	return releaseScript.Run(context.Background(), c.redis, usage.releaseKeys(), usage.member(),
+		usage.Amount).Err()
+}
+
+func NewCounter(redis redis.UniversalClient) *Counter {
+	return &Counter{redis: redis}
+}
@@ -0,0 +1,134 @@
+package limit
+
+import (
+	"context"
+	"github.com/redis/go-redis/v9"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testUsage(uuid string, amount int64, at time.Time) Usage {
+	return Usage{
+		Kind:             KindWithdraw,
+		MovementUUID:     uuid,
+		OrganizationUUID: "organization",
+		BalanceUUID:      "balance",
+		Amount:           amount,
+		At:               at,
+	}
+}
+
+func TestUsageKeys(t *testing.T) {
+	at := time.Date(2026, 5, 10, 14, 30, 0, 0, time.UTC)
+	keys := testUsage("withdraw-1", 100, at).keys()
+	if len(keys) != 2*bucketsPerScope {
+		t.Fatalf("%d keys, want %d", len(keys), 2*bucketsPerScope)
+	}
+
+	hour := at.Unix() / 3600
+	day := at.Unix() / 86400
+	prefix := "limit:{organization}:withdraw:organization:organization"
+	want := map[int]string{
+		0:                   prefix + ":members:" + strconv.FormatInt(day, 10),
+		1:                   prefix + ":hour:" + strconv.FormatInt(hour, 10),
+		dayBuckets:          prefix + ":hour:" + strconv.FormatInt(hour-int64(dayBuckets)+1, 10),
+		1 + dayBuckets:      prefix + ":day:" + strconv.FormatInt(day, 10),
+		bucketsPerScope - 1: prefix + ":day:" + strconv.FormatInt(day-int64(monthBuckets)+1, 10),
+		bucketsPerScope:     "limit:{organization}:withdraw:balance:balance:members:" + strconv.FormatInt(day, 10),
+	}
+	for i, key := range want {
+		if keys[i] != key {
+			t.Errorf("key %d = %q, want %q", i, keys[i], key)
+		}
+	}
+	for _, key := range keys {
+		if !strings.HasPrefix(key, "limit:{organization}:") {
+			t.Errorf("key %q is not tagged with the organization", key)
+		}
+	}
+
+	releaseKeys := testUsage("withdraw-1", 100, at).releaseKeys()
+	wantRelease := []string{keys[0], keys[1], keys[1+dayBuckets], keys[bucketsPerScope], keys[bucketsPerScope+1],
+		keys[bucketsPerScope+1+dayBuckets]}
+	if strings.Join(releaseKeys, " ") != strings.Join(wantRelease, " ") {
+		t.Errorf("release keys = %v, want %v", releaseKeys, wantRelease)
+	}
+}
+
+// testCounter connects to the Redis at PAYSTORE_TEST_REDIS_ADDR, skipping the test without one.
+func testCounter(t *testing.T) *Counter {
+	t.Helper()
+	addr := os.Getenv("PAYSTORE_TEST_REDIS_ADDR")
+	if addr == "" {
+		t.Skip("PAYSTORE_TEST_REDIS_ADDR is not set")
+	}
+	client := redis.NewClient(&redis.Options{Addr: addr})
+	t.Cleanup(func() { client.Close() })
+	if errFlush := client.FlushDB(context.Background()).Err(); errFlush != nil {
+		t.Fatalf("flush: %v", errFlush)
+	}
+	return NewCounter(client)
+}
+
+func TestCounterReserveWindows(t *testing.T) {
+	counter := testCounter(t)
+	now := time.Date(2026, 5, 10, 14, 30, 0, 0, time.UTC)
+	organizationLimit := &Limit{DailyWithdraw: 1000, MonthlyWithdraw: 1500, DailyWithdrawCount: 3}
+
+	if errReserve := counter.Reserve(testUsage("w1", 600, now.Add(-2*Day)), organizationLimit, nil); errReserve != nil {
+		t.Fatalf("reserve two days ago: %v", errReserve)
+	}
+	if errReserve := counter.Reserve(testUsage("w2", 901, now), organizationLimit, nil); errReserve != MonthlyTotalExceeded {
+		t.Errorf("reserve above the month = %v, want %v", errReserve, MonthlyTotalExceeded)
+	}
+	if errReserve := counter.Reserve(testUsage("w3", 700, now), organizationLimit, nil); errReserve != nil {
+		t.Fatalf("reserve today: %v", errReserve)
+	}
+	if errReserve := counter.Reserve(testUsage("w4", 400, now), organizationLimit, nil); errReserve != DailyTotalExceeded {
+		t.Errorf("reserve above the day = %v, want %v", errReserve, DailyTotalExceeded)
+	}
+	if errReserve := counter.Reserve(testUsage("w3", 700, now), organizationLimit, nil); errReserve != nil {
+		t.Errorf("reserving the same usage again = %v, want it ignored", errReserve)
+	}
+
+	balanceLimit := &Limit{DailyWithdrawCount: 2}
+	if errReserve := counter.Reserve(testUsage("w5", 1, now), nil, balanceLimit); errReserve != nil {
+		t.Fatalf("second withdraw of the day: %v", errReserve)
+	}
+	if errReserve := counter.Reserve(testUsage("w6", 1, now), nil, balanceLimit); errReserve != DailyCountExceeded {
+		t.Errorf("third withdraw of the day = %v, want %v", errReserve, DailyCountExceeded)
+	}
+}
+
+func TestCounterRelease(t *testing.T) {
+	counter := testCounter(t)
+	now := time.Date(2026, 5, 10, 14, 30, 0, 0, time.UTC)
+	organizationLimit := &Limit{DailyWithdraw: 1000}
+
+	usage := testUsage("w1", 800, now.Add(-3*time.Hour))
+	if errReserve := counter.Reserve(usage, organizationLimit, nil); errReserve != nil {
+		t.Fatalf("reserve: %v", errReserve)
+	}
+	if errReserve := counter.Reserve(testUsage("w2", 800, now), organizationLimit, nil); errReserve != DailyTotalExceeded {
+		t.Fatalf("reserve over the day = %v, want %v", errReserve, DailyTotalExceeded)
+	}
+
+	for i := 0; i < 2; i++ {
+		if errRelease := counter.Release(usage); errRelease != nil {
+			t.Fatalf("release: %v", errRelease)
+		}
+	}
+	if errRelease := counter.Release(testUsage("never-counted", 500, now)); errRelease != nil {
+		t.Fatalf("release of an uncounted usage: %v", errRelease)
+	}
+
+	if errReserve := counter.Reserve(testUsage("w2", 800, now), organizationLimit, nil); errReserve != nil {
+		t.Errorf("reserve after release: %v", errReserve)
+	}
+	if errReserve := counter.Reserve(testUsage("w3", 201, now), organizationLimit, nil); errReserve != DailyTotalExceeded {
+		t.Errorf("releasing twice uncounted more than once: %v", errReserve)
+	}
+}
@@ -0,0 +1,36 @@
+package limit
+
+import (
+	"errors"
+	"time"
+)
+
+// Scope is what a limit applies to: all balances of an organization together, or one balance.
+type Scope string
+
+const (
+	ScopeOrganization Scope = "organization"
+	ScopeBalance      Scope = "balance"
+)
+
+// Kind is the movement a limit caps.
+type Kind string
+
+const (
+	KindPayment  Kind = "payment"
+	KindWithdraw Kind = "withdraw"
+)
+
+// Day and Month are the rolling windows daily and monthly totals are counted over.
+const (
+	Day   = 24 * time.Hour
+	Month = 30 * Day
+)
+
+var LimitNotFound = errors.New("Limit not found")
+var UnknownScope = errors.New("Unknown limit scope")
+var InvalidLimit = errors.New("Limits must not be negative")
+var SingleAmountExceeded = errors.New("Limit exceeded: amount is above the maximum single amount")
+var DailyTotalExceeded = errors.New("Limit exceeded: daily total would be above its limit")
+var MonthlyTotalExceeded = errors.New("Limit exceeded: monthly total would be above its limit")
+var DailyCountExceeded = errors.New("Limit exceeded: withdraw count for the day would be above its limit")
@@ -0,0 +1,85 @@
+package limit
+
+import "github.com/21strive/redifu"
+
+// Limit caps the payments and withdraws of an organization or of one balance. A zero field
+// leaves that limit off.
+type Limit struct {
+	*redifu.Record
+	Scope              Scope  `json:"scope"`
+	ScopeUUID          string `json:"scopeUUID"`
+	MaxPayment         int64  `json:"maxPayment"`
+	MaxWithdraw        int64  `json:"maxWithdraw"`
+	DailyPayment       int64  `json:"dailyPayment"`
+	MonthlyPayment     int64  `json:"monthlyPayment"`
+	DailyWithdraw      int64  `json:"dailyWithdraw"`
+	MonthlyWithdraw    int64  `json:"monthlyWithdraw"`
+	DailyWithdrawCount int64  `json:"dailyWithdrawCount"`
+}
+
+func (l *Limit) Validate() error {
+	for _, value := range []int64{l.MaxPayment, l.MaxWithdraw, l.DailyPayment, l.MonthlyPayment, l.DailyWithdraw,
+		l.MonthlyWithdraw, l.DailyWithdrawCount} {
+		if value < 0 {
+			return InvalidLimit
+		}
+	}
+	return nil
+}
+
+// CheckAmount rejects a single payment or withdraw above its maximum.
+func (l *Limit) CheckAmount(kind Kind, amount int64) error {
+	if l == nil {
+		return nil
+	}
+	maximum := l.MaxPayment
+	if kind == KindWithdraw {
+		maximum = l.MaxWithdraw
+	}
+	if maximum > 0 && amount > maximum {
+		return SingleAmountExceeded
+	}
+	return nil
+}
+
+// Windows returns the daily total, monthly total and daily count limits of the kind. Only
+// withdraws are limited by count.
+func (l *Limit) Windows(kind Kind) (int64, int64, int64) {
+	if l == nil {
+		return 0, 0, 0
+	}
+	if kind == KindWithdraw {
+		return l.DailyWithdraw, l.MonthlyWithdraw, l.DailyWithdrawCount
+	}
+	return l.DailyPayment, l.MonthlyPayment, 0
+}
+
+func (l *Limit) ScanDestinations() []interface{} {
+	return []interface{}{
+		&l.UUID,
+		&l.RandId,
+		&l.CreatedAt,
+		&l.UpdatedAt,
+		&l.Scope,
+		&l.ScopeUUID,
+		&l.MaxPayment,
+		&l.MaxWithdraw,
+		&l.DailyPayment,
+		&l.MonthlyPayment,
+		&l.DailyWithdraw,
+		&l.MonthlyWithdraw,
+		&l.DailyWithdrawCount,
+	}
+}
+
+func NewLimit(scope Scope, scopeUUID string) (*Limit, error) {
+	if scope != ScopeOrganization && scope != ScopeBalance {
+		return nil, UnknownScope
+	}
+
+	limit := &Limit{}
+	redifu.InitRecord(limit)
+	limit.Scope = scope
+	limit.ScopeUUID = scopeUUID
+	return limit, nil
+}
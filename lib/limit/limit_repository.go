@@ -0,0 +1,65 @@
+package limit
+
+import (
+	"database/sql"
+	"github.com/21strive/redifu"
+)
+
+var limitColumns = `uuid, randid, created_at, updated_at, scope, scope_uuid, max_payment, max_withdraw,
+	daily_payment, monthly_payment, daily_withdraw, monthly_withdraw, daily_withdraw_count`
+
+// upsertLimitQuery keeps one limit per scope; replacing it keeps the original UUID.
+var upsertLimitQuery = `INSERT INTO transaction_limit (` + limitColumns + `)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	ON CONFLICT (scope, scope_uuid) DO UPDATE SET updated_at = EXCLUDED.updated_at,
+		max_payment = EXCLUDED.max_payment, max_withdraw = EXCLUDED.max_withdraw,
+		daily_payment = EXCLUDED.daily_payment, monthly_payment = EXCLUDED.monthly_payment,
+		daily_withdraw = EXCLUDED.daily_withdraw, monthly_withdraw = EXCLUDED.monthly_withdraw,
+		daily_withdraw_count = EXCLUDED.daily_withdraw_count
+	RETURNING ` + limitColumns
+var findLimitQuery = `SELECT ` + limitColumns + ` FROM transaction_limit WHERE scope = $1 AND scope_uuid = $2`
+
+type RepositoryClient interface {
+	Upsert(limit *Limit) error
+	Find(scope Scope, scopeUUID string) (*Limit, error)
+}
+
+type Repository struct {
+	upsertLimitStmt *sql.Stmt
+	findLimitStmt   *sql.Stmt
+}
+
+func (r *Repository) Upsert(limit *Limit) error {
+	return r.upsertLimitStmt.QueryRow(limit.GetUUID(), limit.GetRandId(), limit.GetCreatedAt(), limit.GetUpdatedAt(),
+		limit.Scope, limit.ScopeUUID, limit.MaxPayment, limit.MaxWithdraw, limit.DailyPayment, limit.MonthlyPayment,
+		limit.DailyWithdraw, limit.MonthlyWithdraw, limit.DailyWithdrawCount).Scan(limit.ScanDestinations()...)
+}
+
+func (r *Repository) Find(scope Scope, scopeUUID string) (*Limit, error) {
+	limit := &Limit{}
+	redifu.InitRecord(limit)
+	errScan := r.findLimitStmt.QueryRow(scope, scopeUUID).Scan(limit.ScanDestinations()...)
+	if errScan != nil {
+		if errScan == sql.ErrNoRows {
+			return nil, LimitNotFound
+		}
+		return nil, errScan
+	}
+	return limit, nil
+}
+
+func NewRepository(writeDB *sql.DB, readDB *sql.DB) *Repository {
+	upsertLimitStmt, err := writeDB.Prepare(upsertLimitQuery)
+	if err != nil {
+		panic(err)
+	}
+	findLimitStmt, err := readDB.Prepare(findLimitQuery)
+	if err != nil {
+		panic(err)
+	}
+
+	return &Repository{
+		upsertLimitStmt: upsertLimitStmt,
+		findLimitStmt:   findLimitStmt,
+	}
+}
@@ -0,0 +1,56 @@
+package lock
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"github.com/redis/go-redis/v9"
+	"time"
+)
+
+var NotHeld = errors.New("Lock is not held by this owner")
+
+// releaseScript deletes the key only while it still holds our token, so a holder whose
+// lease already expired cannot release a lock another instance has since acquired.
+var releaseScript = redis.NewScript(`
+	if redis.call("GET", KEYS[1]) == ARGV[1] then
+		return redis.call("DEL", KEYS[1])
+	end
+	return 0`)
+
+type Lock struct {
+	redis redis.UniversalClient
+	key   string
+	token string
+}
+
+func (l *Lock) Release() error {
+	released, errRelease := releaseScript.Run(context.Background(), l.redis, []string{l.key}, l.token).Int()
+	if errRelease != nil {
+		return errRelease
+	}
+	if released == 0 {
+		return NotHeld
+	}
+	return nil
+}
+
+// Acquire takes the named lock for ttl. It returns nil without error when another owner holds it.
+func Acquire(redis redis.UniversalClient, key string, ttl time.Duration) (*Lock, error) {
+	tokenBytes := make([]byte, 16)
+	if _, errRand := rand.Read(tokenBytes); errRand != nil {
+		return nil, errRand
+	}
+	token := hex.EncodeToString(tokenBytes)
+
+	acquired, errSet := redis.SetNX(context.Background(), key, token, ttl).Result()
+	if errSet != nil {
+		return nil, errSet
+	}
+	if !acquired {
+		return nil, nil
+	}
+
+	return &Lock{redis: redis, key: key, token: token}, nil
+}
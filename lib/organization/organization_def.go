@@ -4,6 +4,16 @@ import "errors"
 
 type FeesType string
 
+// Status is where an organization is in its lifecycle. Only active organizations open new
+// balances, payments and withdraws; movements already in flight are still finalized.
+type Status string
+
+const (
+	StatusActive    Status = "active"
+	StatusSuspended Status = "suspended"
+	StatusClosed    Status = "closed"
+)
+
 const (
 	Fixed   FeesType = "fixed"
 	Percent FeesType = "percent"
@@ -13,6 +23,12 @@ var OrganizationMismatch = errors.New("Organization mismatch")
 var OrganizationNotFound = errors.New("Organization not found")
 var DuplicateSlug = errors.New("Duplicate slug")
 var DuplicateName = errors.New("Duplicate name")
+var CyclicParent = errors.New("Organization cannot be a sub-merchant of itself or of its sub-merchants")
+var OrganizationSuspended = errors.New("Organization is suspended")
+var OrganizationClosed = errors.New("Organization is closed")
+var UnknownStatus = errors.New("Unknown organization status")
+var InvalidStatusTransition = errors.New("Organization cannot move to that status")
+var ReasonRequired = errors.New("A reason is required to suspend or close an organization")
 
 type CreateOrganizationRequest struct {
 	Name string `json:"name" binding:"required"`
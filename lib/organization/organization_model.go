@@ -2,14 +2,56 @@ package organization
 
 import (
 	"github.com/21strive/redifu"
+	"paystore/lib/fee"
+	"time"
 )
 
+// Organization is a merchant, or a sub-merchant when ParentUUID names its parent. Fee
+// settings and limits a sub-merchant leaves unset are inherited from its parent; FeesOverride
+// marks FeesConstant as set, so a zero flat fee overrides the parent's too.
+// StatusReason and StatusChangedAt describe the latest status change.
 type Organization struct {
 	*redifu.Record
-	Name         string
-	Slug         string
-	FeesConstant int64
-	FeesType     FeesType
+	Name            string
+	Slug            string
+	FeesConstant    int64
+	FeesType        FeesType
+	FeesOverride    bool
+	FeeRounding     fee.RoundingMode
+	ParentUUID      string
+	Status          Status
+	StatusReason    string
+	StatusChangedAt time.Time
+}
+
+// StatusChange audits one status change of an organization and who made it.
+type StatusChange struct {
+	*redifu.Record
+	OrganizationUUID string `json:"organizationUUID"`
+	FromStatus       Status `json:"fromStatus"`
+	ToStatus         Status `json:"toStatus"`
+	Reason           string `json:"reason"`
+	Actor            string `json:"actor"`
+}
+
+func (c *StatusChange) ScanDestinations() []interface{} {
+	return []interface{}{
+		&c.UUID,
+		&c.RandId,
+		&c.CreatedAt,
+		&c.UpdatedAt,
+		&c.OrganizationUUID,
+		&c.FromStatus,
+		&c.ToStatus,
+		&c.Reason,
+		&c.Actor,
+	}
+}
+
+func NewStatusChange() *StatusChange {
+	change := &StatusChange{}
+	redifu.InitRecord(change)
+	return change
 }
 
 func (o *Organization) SetName(name string) {
@@ -23,6 +65,83 @@ func (o *Organization) SetSlug(slug string) {
 func (o *Organization) SetPaymentFees(feesConstant int64, feesType FeesType) {
 	o.FeesConstant = feesConstant
 	o.FeesType = feesType
+	o.FeesOverride = true
+}
+
+// SetFeeRounding sets how percentage fees are rounded. The empty mode inherits the parent's.
+func (o *Organization) SetFeeRounding(mode fee.RoundingMode) error {
+	errValidate := mode.Validate()
+	if errValidate != nil {
+		return errValidate
+	}
+	o.FeeRounding = mode
+	return nil
+}
+
+// SetParent makes the organization a sub-merchant of parentUUID, or a top-level organization
+// when it is empty.
+func (o *Organization) SetParent(parentUUID string) error {
+	if parentUUID == o.UUID {
+		return CyclicParent
+	}
+	o.ParentUUID = parentUUID
+	return nil
+}
+
+// SetStatus moves the organization to status and returns the audit record of the change.
+// Suspended organizations can be reactivated; closed organizations stay closed. Suspending
+// and closing need a reason.
+func (o *Organization) SetStatus(status Status, reason string, actor string) (*StatusChange, error) {
+	switch status {
+	case StatusActive, StatusSuspended, StatusClosed:
+	default:
+		return nil, UnknownStatus
+	}
+	if o.Status == status || o.Status == StatusClosed {
+		return nil, InvalidStatusTransition
+	}
+	if status != StatusActive && reason == "" {
+		return nil, ReasonRequired
+	}
+
+	change := NewStatusChange()
+	change.OrganizationUUID = o.GetUUID()
+	change.FromStatus = o.Status
+	change.ToStatus = status
+	change.Reason = reason
+	change.Actor = actor
+
+	o.Status = status
+	o.StatusReason = reason
+	o.StatusChangedAt = change.GetCreatedAt()
+	o.SetUpdatedAt(change.GetCreatedAt())
+	return change, nil
+}
+
+// CheckActive refuses new balances, payments and withdraws for an organization that is not active.
+func (o *Organization) CheckActive() error {
+	switch o.Status {
+	case StatusSuspended:
+		return OrganizationSuspended
+	case StatusClosed:
+		return OrganizationClosed
+	}
+	return nil
+}
+
+// HasFlatFee reports whether the organization sets a flat fee of its own rather than
+// inheriting its parent's.
+func (o *Organization) HasFlatFee() bool {
+	return o.FeesOverride
+}
+
+// FlatFeeSchedule prices payments by FeesConstant alone, for organizations without a fee
+// schedule of their own. A percent FeesConstant is a whole percentage.
+func (o *Organization) FlatFeeSchedule() *fee.Schedule {
+	if o.FeesType == Percent {
+		return fee.FlatSchedule(o.UUID, fee.KindPayment, o.FeesConstant*fee.BasisPoints/100, 0)
+	}
+	return fee.FlatSchedule(o.UUID, fee.KindPayment, 0, o.FeesConstant)
 }
 
 func NewOrganization() *Organization {
@@ -30,5 +149,7 @@ func NewOrganization() *Organization {
 	redifu.InitRecord(organization)
 	organization.FeesType = Fixed
 	organization.FeesConstant = 0
+	organization.Status = StatusActive
+	organization.StatusChangedAt = organization.GetCreatedAt()
 	return organization
 }
@@ -0,0 +1,88 @@
+package organization
+
+import "testing"
+
+func TestOrganizationSetStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		from   Status
+		to     Status
+		reason string
+		want   error
+	}{
+		{"suspend", StatusActive, StatusSuspended, "chargebacks", nil},
+		{"close", StatusActive, StatusClosed, "offboarded", nil},
+		{"reactivate", StatusSuspended, StatusActive, "", nil},
+		{"close suspended", StatusSuspended, StatusClosed, "offboarded", nil},
+		{"suspend without reason", StatusActive, StatusSuspended, "", ReasonRequired},
+		{"close without reason", StatusSuspended, StatusClosed, "", ReasonRequired},
+		{"same status", StatusActive, StatusActive, "", InvalidStatusTransition},
+		{"reopen closed", StatusClosed, StatusActive, "", InvalidStatusTransition},
+		{"suspend closed", StatusClosed, StatusSuspended, "chargebacks", InvalidStatusTransition},
+		{"unknown status", StatusActive, Status("frozen"), "audit", UnknownStatus},
+	}
+	for _, test := range tests {
+		organization := NewOrganization()
+		organization.Status = test.from
+
+		change, errStatus := organization.SetStatus(test.to, test.reason, "operator")
+		if errStatus != test.want {
+			t.Errorf("%s: SetStatus = %v, want %v", test.name, errStatus, test.want)
+			continue
+		}
+		if errStatus != nil {
+			if change != nil || organization.Status != test.from {
+				t.Errorf("%s: refused change still moved the organization to %q", test.name, organization.Status)
+			}
+			continue
+		}
+
+		if organization.Status != test.to || organization.StatusReason != test.reason {
+			t.Errorf("%s: organization is %q (%q)", test.name, organization.Status, organization.StatusReason)
+		}
+		if change.OrganizationUUID != organization.GetUUID() || change.FromStatus != test.from ||
+			change.ToStatus != test.to || change.Actor != "operator" {
+			t.Errorf("%s: unexpected change %+v", test.name, change)
+		}
+		if !organization.StatusChangedAt.Equal(change.GetCreatedAt()) {
+			t.Errorf("%s: status changed at %v, want %v", test.name, organization.StatusChangedAt, change.GetCreatedAt())
+		}
+	}
+}
+
+func TestOrganizationCheckActive(t *testing.T) {
+	want := map[Status]error{
+		StatusActive:    nil,
+		StatusSuspended: OrganizationSuspended,
+		StatusClosed:    OrganizationClosed,
+	}
+	for status, wantErr := range want {
+		organization := NewOrganization()
+		organization.Status = status
+		if errActive := organization.CheckActive(); errActive != wantErr {
+			t.Errorf("CheckActive(%q) = %v, want %v", status, errActive, wantErr)
+		}
+	}
+}
+
+func TestOrganizationHasFlatFee(t *testing.T) {
+	inherits := NewOrganization()
+	if inherits.HasFlatFee() {
+		t.Errorf("a new organization overrides its parent's flat fee")
+	}
+
+	free := NewOrganization()
+	free.SetPaymentFees(0, Fixed)
+	if !free.HasFlatFee() {
+		t.Errorf("a zero flat fee does not override the parent's")
+	}
+	if rule := free.FlatFeeSchedule().Rules[0]; rule.Fixed != 0 || rule.PercentBps != 0 {
+		t.Errorf("zero flat fee prices %+v", rule)
+	}
+
+	charged := NewOrganization()
+	charged.SetPaymentFees(2, Percent)
+	if !charged.HasFlatFee() {
+		t.Errorf("a flat fee does not override the parent's")
+	}
+}
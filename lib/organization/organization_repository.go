@@ -5,20 +5,40 @@ import (
 	"github.com/21strive/redifu"
 	"github.com/redis/go-redis/v9"
 	"paystore/config"
+	"time"
 )
 
 var createOrganizationQuery = `
-	INSERT INTO organization (uuid, randid, created_at, updated_at, name, slug, fees_constant, fees_type) 
-	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	INSERT INTO organization (uuid, randid, created_at, updated_at, name, slug, fees_constant, fees_type, fee_rounding,
+		parent_uuid, status, status_reason, status_changed_at, fees_override) 
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`
 var updateOrganizationQuery = `UPDATE organization SET name = $1, slug = $2 WHERE uuid = $3`
+var updateFeeRoundingQuery = `UPDATE organization SET fee_rounding = $1, updated_at = $2 WHERE uuid = $3`
+var updateParentQuery = `UPDATE organization SET parent_uuid = $1, updated_at = $2 WHERE uuid = $3`
+var updateStatusQuery = `UPDATE organization SET status = $1, status_reason = $2, status_changed_at = $3, updated_at = $4
+	WHERE uuid = $5`
+var createStatusChangeQuery = `
+	INSERT INTO organization_status_change (uuid, randid, created_at, updated_at, organization_uuid, from_status,
+		to_status, reason, actor)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+var findStatusChangesQuery = `
+	SELECT uuid, randid, created_at, updated_at, organization_uuid, from_status, to_status, reason, actor
+	FROM organization_status_change WHERE organization_uuid = $1 ORDER BY created_at, uuid`
 var findOrganizationByUUIDQuery = `SELECT * FROM organization WHERE uuid = $1`
+var findOrganizationByUUIDForUpdateQuery = `SELECT * FROM organization WHERE uuid = $1 FOR UPDATE`
 var findOrganizationBySlugQuery = `SELECT * FROM organization WHERE slug = $1`
 var findOrganizationByNameQuery = `SELECT * FROM organization WHERE name = $1`
 
 type RepositoryClient interface {
 	Create(organization *Organization) error
 	Update(organization *Organization) error
+	UpdateFeeRounding(organization *Organization) error
+	UpdateParent(tx *sql.Tx, organization *Organization) error
+	UpdateStatus(tx *sql.Tx, organization *Organization, change *StatusChange) error
+	SetCache(organization *Organization) error
+	FindStatusChanges(organizationUUID string) ([]*StatusChange, error)
 	FindByUUID(uuid string) (*Organization, error)
+	FindByUUIDForUpdate(tx *sql.Tx, uuid string) (*Organization, error)
 	FindBySlug(slug string) (*Organization, error)
 	FindByName(name string) (*Organization, error)
 }
@@ -29,15 +49,19 @@ type Repository struct {
 	base                       *redifu.Base[*Organization]
 	createOrganizationStmt     *sql.Stmt
 	updateOrganizationStmt     *sql.Stmt
+	updateFeeRoundingStmt      *sql.Stmt
 	findOrganizationByUUIDStmt *sql.Stmt
 	findOrganizationBySlugStmt *sql.Stmt
 	findOrganizationByNameStmt *sql.Stmt
+	findStatusChangesStmt      *sql.Stmt
 }
 
 func (or *Repository) Create(organization *Organization) error {
 	_, err := or.createOrganizationStmt.Exec(organization.GetUUID(),
 		organization.GetRandId(), organization.GetCreatedAt(), organization.GetUUID(),
-		organization.Name, organization.Slug, organization.FeesConstant, organization.FeesType)
+		organization.Name, organization.Slug, organization.FeesConstant, organization.FeesType,
+		organization.FeeRounding, organization.ParentUUID, organization.Status, organization.StatusReason,
+		organization.StatusChangedAt, organization.FeesOverride)
 	return err
 }
 
@@ -46,6 +70,80 @@ func (or *Repository) Update(organization *Organization) error {
 	return err
 }
 
+func (or *Repository) UpdateFeeRounding(organization *Organization) error {
+	organization.SetUpdatedAt(time.Now())
+	_, err := or.updateFeeRoundingStmt.Exec(organization.FeeRounding, organization.GetUpdatedAt(), organization.GetUUID())
+	if err != nil {
+		return err
+	}
+	return or.base.Set(organization)
+}
+
+// UpdateParent stores the organization's parent. It only writes the row, so callers cache the
+// organization once the transaction has committed.
+func (or *Repository) UpdateParent(tx *sql.Tx, organization *Organization) error {
+	organization.SetUpdatedAt(time.Now())
+	_, err := tx.Exec(updateParentQuery, organization.ParentUUID, organization.GetUpdatedAt(), organization.GetUUID())
+	return err
+}
+
+// UpdateStatus stores the organization's new status together with the audit record of the change.
+// It only writes the rows, so callers cache the organization once the transaction has committed.
+func (or *Repository) UpdateStatus(tx *sql.Tx, organization *Organization, change *StatusChange) error {
+	_, err := tx.Exec(updateStatusQuery, organization.Status, organization.StatusReason,
+		organization.StatusChangedAt, organization.GetUpdatedAt(), organization.GetUUID())
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(createStatusChangeQuery, change.GetUUID(), change.GetRandId(), change.GetCreatedAt(),
+		change.GetUpdatedAt(), change.OrganizationUUID, change.FromStatus, change.ToStatus, change.Reason,
+		change.Actor)
+	return err
+}
+
+// SetCache stores the organization in the cache. Only the organization's own status is cached:
+// sub-merchants are checked against their parents by walking the chain from the database, so
+// their cached entries stay valid when a parent's status changes.
+func (or *Repository) SetCache(organization *Organization) error {
+	return or.base.Set(organization)
+}
+
+// FindStatusChanges returns the organization's status changes, oldest first.
+func (or *Repository) FindStatusChanges(organizationUUID string) ([]*StatusChange, error) {
+	rows, errQuery := or.findStatusChangesStmt.Query(organizationUUID)
+	if errQuery != nil {
+		return nil, errQuery
+	}
+	defer rows.Close()
+
+	var changes []*StatusChange
+	for rows.Next() {
+		change := NewStatusChange()
+		errScan := rows.Scan(change.ScanDestinations()...)
+		if errScan != nil {
+			return nil, errScan
+		}
+		changes = append(changes, change)
+	}
+	if errRows := rows.Err(); errRows != nil {
+		return nil, errRows
+	}
+
+	return changes, nil
+}
+
+func (or *Repository) FindByUUIDForUpdate(tx *sql.Tx, uuid string) (*Organization, error) {
+	row, errScan := OrganizationRowScanner(tx.QueryRow(findOrganizationByUUIDForUpdateQuery, uuid))
+	if errScan != nil {
+		if errScan == sql.ErrNoRows {
+			return nil, OrganizationNotFound
+		}
+		return nil, errScan
+	}
+	return row, nil
+}
+
 func (or *Repository) FindByUUID(uuid string) (*Organization, error) {
 	row, errScan := OrganizationRowScanner(or.findOrganizationByUUIDStmt.QueryRow(uuid))
 	if errScan != nil {
@@ -91,7 +189,8 @@ func (or *Repository) FindByName(name string) (*Organization, error) {
 func OrganizationRowScanner(row *sql.Row) (*Organization, error) {
 	org := NewOrganization()
 	err := row.Scan(&org.UUID,
-		&org.RandId, &org.CreatedAt, &org.UpdatedAt, &org.Name, &org.Slug, &org.FeesConstant, &org.FeesType)
+		&org.RandId, &org.CreatedAt, &org.UpdatedAt, &org.Name, &org.Slug, &org.FeesConstant, &org.FeesType,
+		&org.FeeRounding, &org.ParentUUID, &org.Status, &org.StatusReason, &org.StatusChangedAt, &org.FeesOverride)
 	if err != nil {
 		return nil, err
 	}
@@ -110,6 +209,10 @@ func NewRepository(writeDB *sql.DB, readDB *sql.DB, redis redis.UniversalClient,
 	if err != nil {
 		panic(err)
 	}
+	updateFeeRoundingStmt, err := writeDB.Prepare(updateFeeRoundingQuery)
+	if err != nil {
+		panic(err)
+	}
 	findOrganizationByUUIDStmt, err := readDB.Prepare(findOrganizationByUUIDQuery)
 	if err != nil {
 		panic(err)
@@ -118,6 +221,10 @@ func NewRepository(writeDB *sql.DB, readDB *sql.DB, redis redis.UniversalClient,
 	if err != nil {
 		panic(err)
 	}
+	findStatusChangesStmt, err := readDB.Prepare(findStatusChangesQuery)
+	if err != nil {
+		panic(err)
+	}
 
 	organizationRepo := &Repository{
 		writeDB:                    writeDB,
@@ -125,8 +232,10 @@ func NewRepository(writeDB *sql.DB, readDB *sql.DB, redis redis.UniversalClient,
 		base:                       base,
 		createOrganizationStmt:     createOrganizationStmt,
 		updateOrganizationStmt:     updateOrganizationStmt,
+		updateFeeRoundingStmt:      updateFeeRoundingStmt,
 		findOrganizationByUUIDStmt: findOrganizationByUUIDStmt,
 		findOrganizationBySlugStmt: findOrganizationBySlugStmt,
+		findStatusChangesStmt:      findStatusChangesStmt,
 	}
 
 	return organizationRepo
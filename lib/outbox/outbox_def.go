@@ -0,0 +1,28 @@
+package outbox
+
+type EventType string
+
+const (
+	EventPaymentCreated        EventType = "payment.created"
+	EventPaymentPaid           EventType = "payment.paid"
+	EventPaymentFailed         EventType = "payment.failed"
+	EventPaymentExpired        EventType = "payment.expired"
+	EventWithdrawCreated       EventType = "withdraw.created"
+	EventWithdrawSucceeded     EventType = "withdraw.succeeded"
+	EventWithdrawFailed        EventType = "withdraw.failed"
+	EventWithdrawReview        EventType = "withdraw.review"
+	EventBalanceCreated        EventType = "balance.created"
+	EventBalanceUpdated        EventType = "balance.updated"
+	EventOrganizationActivated EventType = "organization.activated"
+	EventOrganizationSuspended EventType = "organization.suspended"
+	EventOrganizationClosed    EventType = "organization.closed"
+)
+
+type AggregateType string
+
+const (
+	AggregatePayment      AggregateType = "payment"
+	AggregateWithdraw     AggregateType = "withdraw"
+	AggregateBalance      AggregateType = "balance"
+	AggregateOrganization AggregateType = "organization"
+)
@@ -0,0 +1,66 @@
+package outbox
+
+import (
+	"encoding/json"
+	"github.com/21strive/redifu"
+	"time"
+)
+
+// Event is a domain event written in the same transaction as the change it describes.
+// Sequence is assigned by the database and orders events for the relay.
+type Event struct {
+	*redifu.Record
+	Sequence      int64         `json:"sequence"`
+	EventType     EventType     `json:"eventType"`
+	AggregateType AggregateType `json:"aggregateType"`
+	AggregateUUID string        `json:"aggregateUUID"`
+	// OrganizationUUID is the tenant the event belongs to, used to route it to tenant webhooks.
+	OrganizationUUID string `json:"organizationUUID"`
+	Payload          []byte `json:"payload"`
+}
+
+// StreamValues renders the event as Redis Stream entry fields.
+func (e *Event) StreamValues() map[string]interface{} {
+	return map[string]interface{}{
+		"uuid":             e.GetUUID(),
+		"sequence":         e.Sequence,
+		"eventType":        string(e.EventType),
+		"aggregateType":    string(e.AggregateType),
+		"aggregateUUID":    e.AggregateUUID,
+		"organizationUUID": e.OrganizationUUID,
+		"createdAt":        e.GetCreatedAt().UTC().Format(time.RFC3339Nano),
+		"payload":          string(e.Payload),
+	}
+}
+
+func (e *Event) ScanDestinations() []interface{} {
+	return []interface{}{
+		&e.UUID,
+		&e.RandId,
+		&e.CreatedAt,
+		&e.UpdatedAt,
+		&e.Sequence,
+		&e.EventType,
+		&e.AggregateType,
+		&e.AggregateUUID,
+		&e.OrganizationUUID,
+		&e.Payload,
+	}
+}
+
+func NewEvent(eventType EventType, aggregateType AggregateType, aggregateUUID string, organizationUUID string,
+	aggregate interface{}) (*Event, error) {
+	payload, errMarshal := json.Marshal(aggregate)
+	if errMarshal != nil {
+		return nil, errMarshal
+	}
+
+	event := &Event{}
+	redifu.InitRecord(event)
+	event.EventType = eventType
+	event.AggregateType = aggregateType
+	event.AggregateUUID = aggregateUUID
+	event.OrganizationUUID = organizationUUID
+	event.Payload = payload
+	return event, nil
+}
@@ -0,0 +1,72 @@
+package outbox
+
+import (
+	"database/sql"
+	"github.com/21strive/redifu"
+	"github.com/lib/pq"
+)
+
+var createEventQuery = `
+	INSERT INTO outbox (uuid, randid, created_at, updated_at, event_type, aggregate_type, aggregate_uuid,
+		organization_uuid, payload)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+// findPendingQuery skips rows another relay has locked, so relays can run on every instance.
+var findPendingQuery = `
+	SELECT uuid, randid, created_at, updated_at, sequence, event_type, aggregate_type, aggregate_uuid,
+		organization_uuid, payload
+	FROM outbox WHERE published_at IS NULL ORDER BY sequence LIMIT $1 FOR UPDATE SKIP LOCKED`
+var markPublishedQuery = `UPDATE outbox SET published_at = NOW(), updated_at = NOW() WHERE uuid = ANY($1)`
+
+type RepositoryClient interface {
+	Create(tx *sql.Tx, event *Event) error
+	FindPending(tx *sql.Tx, limit int64) ([]*Event, error)
+	MarkPublished(tx *sql.Tx, events []*Event) error
+}
+
+type Repository struct{}
+
+func (r *Repository) Create(tx *sql.Tx, event *Event) error {
+	_, errExec := tx.Exec(createEventQuery, event.GetUUID(), event.GetRandId(), event.GetCreatedAt(),
+		event.GetUpdatedAt(), event.EventType, event.AggregateType, event.AggregateUUID, event.OrganizationUUID,
+		event.Payload)
+	return errExec
+}
+
+func (r *Repository) FindPending(tx *sql.Tx, limit int64) ([]*Event, error) {
+	rows, errQuery := tx.Query(findPendingQuery, limit)
+	if errQuery != nil {
+		return nil, errQuery
+	}
+	defer rows.Close()
+
+	var events []*Event
+	for rows.Next() {
+		event := &Event{}
+		redifu.InitRecord(event)
+		errScan := rows.Scan(event.ScanDestinations()...)
+		if errScan != nil {
+			return nil, errScan
+		}
+		events = append(events, event)
+	}
+	if errRows := rows.Err(); errRows != nil {
+		return nil, errRows
+	}
+
+	return events, nil
+}
+
+func (r *Repository) MarkPublished(tx *sql.Tx, events []*Event) error {
+	var uuids []string
+	for _, event := range events {
+		uuids = append(uuids, event.GetUUID())
+	}
+
+	_, errExec := tx.Exec(markPublishedQuery, pq.Array(uuids))
+	return errExec
+}
+
+func NewRepository() *Repository {
+	return &Repository{}
+}
@@ -2,6 +2,7 @@ package payment
 
 import (
 	"errors"
+	"time"
 )
 
 type PaymentStatus string
@@ -10,8 +11,18 @@ const (
 	PaymentStatusPending PaymentStatus = "pending"
 	PaymentStatusPaid    PaymentStatus = "paid"
 	PaymentStatusFailed  PaymentStatus = "failed"
+	PaymentStatusExpired PaymentStatus = "expired"
 )
 
+// VendorStatuses maps invoice statuses reported by the payment vendor to payment statuses.
+var VendorStatuses = map[string]PaymentStatus{
+	"PENDING": PaymentStatusPending,
+	"PAID":    PaymentStatusPaid,
+	"SETTLED": PaymentStatusPaid,
+	"EXPIRED": PaymentStatusExpired,
+	"FAILED":  PaymentStatusFailed,
+}
+
 var UnmatchBalance = errors.New("The payment owner must match the account balance.")
 var VendorRequired = errors.New("Vendor is required.")
 var OrganizationRequired = errors.New("Organization is required")
@@ -19,3 +30,29 @@ var ConfigRequired = errors.New("Config is required")
 var PaymentRequired = errors.New("Payment is required")
 var PaymentNotFound = errors.New("Payment not found")
 var FinalAmountLessThanZero = errors.New("Final amount must be greater than zero")
+var AlreadyFinalized = errors.New("Payment is already finalized with a different status")
+var UnknownVendorStatus = errors.New("Unknown payment vendor status")
+var InvalidShare = errors.New("Split share must be either a fixed amount or a percentage of the payment")
+var SplitNeedsShares = errors.New("Split payment needs at least two shares")
+var SharesExceedAmount = errors.New("Split shares exceed the payment amount")
+var DuplicateShareBalance = errors.New("Split shares must credit different balances")
+var ShareCurrencyMismatch = errors.New("Split shares must credit balances of the same currency")
+var ShareFinalizedWithParent = errors.New("Split payment shares are finalized with their parent payment")
+var UnmatchVendor = errors.New("The vendor must match the vendor the payment was created with")
+var UnmatchPaidAmount = errors.New("The amount the vendor collected must match the payment amount")
+var InvalidAmountRange = errors.New("Search amount range must not be negative or end below its start")
+var InvalidDateRange = errors.New("Search date range must end after it starts")
+
+type SearchFilter struct {
+	OrganizationUUID string
+	BalanceUUID      string
+	Statuses         []PaymentStatus
+	MinAmount        int64
+	MaxAmount        int64
+	CreatedFrom      time.Time
+	CreatedTo        time.Time
+	VendorRecordID   string
+	VendorCode       string
+	Cursor           string
+	Limit            int64
+}
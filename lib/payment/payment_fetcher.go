@@ -15,10 +15,33 @@ type FetcherClient interface {
 type Fetcher struct {
 	base              *redifu.Base[*Payment]
 	timelineByBalance *redifu.Timeline[*Payment]
+	appConfig         *config.App
 }
 
+// FetchByBalance reads a page of the balance timeline from the cache. The cache keeps vendor
+// record values but not their schema, so each vendor record is bound to its schema again.
 func (f *Fetcher) FetchByBalance(lastRandId []string, balanceRandId string) ([]*Payment, string, string, error) {
-	return f.timelineByBalance.Fetch([]string{balanceRandId}, lastRandId, nil, nil)
+	payments, validLastRandId, position, errFetch := f.timelineByBalance.Fetch([]string{balanceRandId}, lastRandId,
+		nil, nil)
+	if errFetch != nil {
+		return nil, "", "", errFetch
+	}
+
+	for _, payment := range payments {
+		if payment.PaymentVendor == nil {
+			continue
+		}
+		vendor, errVendor := f.appConfig.PaymentVendor(payment.VendorCode)
+		if errVendor != nil {
+			continue
+		}
+		errBind := payment.PaymentVendor.Bind(vendor.Schema)
+		if errBind != nil {
+			return nil, "", "", errBind
+		}
+	}
+
+	return payments, validLastRandId, position, nil
 }
 
 func (f *Fetcher) IsBlankByBalance(balanceRandId string) (bool, error) {
@@ -40,5 +63,6 @@ func NewFetcher(redis redis.UniversalClient, config *config.App) *Fetcher {
 	return &Fetcher{
 		base:              base,
 		timelineByBalance: timelineByBalance,
+		appConfig:         config,
 	}
 }
@@ -6,24 +6,32 @@ import (
 	"encoding/json"
 	"github.com/21strive/redifu"
 	"paystore/lib/balance"
+	"paystore/lib/fee"
+	"paystore/lib/helper"
 	"paystore/lib/organization"
-	"paystore/user"
+	"paystore/lib/schema"
 	"time"
 )
 
 type Payment struct {
 	*redifu.Record
-	Amount               int64              `json:"amount"`
-	Fees                 int64              `json:"fees"`
-	BalanceBeforePayment int64              `json:"balanceBeforePayment"`
-	BalanceAfterPayment  int64              `json:"balanceAfterPayment"`
-	BalanceUUID          string             `json:"BalanceUUID"`
-	OrganizationUUID     string             `json:"organizationUUID"`
-	VendorRecordID       string             `json:"vendorRecordID"`
-	Status               PaymentStatus      `json:"status"`
-	Hash                 string             `json:"hash"`
-	PaymentVendorRandId  string             `json:"vendorRandId,omitempty"`
-	PaymentVendor        user.PaymentVendor `json:"vendor,omitempty"`
+	Amount               int64          `json:"amount"`
+	Fees                 int64          `json:"fees"`
+	BalanceBeforePayment int64          `json:"balanceBeforePayment"`
+	BalanceAfterPayment  int64          `json:"balanceAfterPayment"`
+	BalanceUUID          string         `json:"BalanceUUID"`
+	OrganizationUUID     string         `json:"organizationUUID"`
+	VendorRecordID       string         `json:"vendorRecordID"`
+	VendorCode           string         `json:"vendorCode"`
+	Channel              string         `json:"channel"`
+	FeeBreakdown         fee.Breakdown  `json:"feeBreakdown"`
+	FeeScheduleUUID      string         `json:"feeScheduleUUID"`
+	ParentPaymentUUID    string         `json:"parentPaymentUUID,omitempty"`
+	FinalizedAt          time.Time      `json:"finalizedAt,omitempty"`
+	Status               PaymentStatus  `json:"status"`
+	Hash                 string         `json:"hash"`
+	PaymentVendorRandId  string         `json:"vendorRandId,omitempty"`
+	PaymentVendor        *schema.Record `json:"vendor,omitempty"`
 }
 
 type PaymentHashPayload struct {
@@ -45,33 +53,83 @@ func (p *Payment) SetBalance(balance *balance.Balance) {
 	p.BalanceUUID = balance.UUID
 }
 
-func (p *Payment) SetAmount(amount int64,
-	currentBalanceAmount int64, assignedOrganization *organization.Organization) error {
-	if assignedOrganization.FeesType == organization.Percent {
-		p.Amount = amount
-		p.Fees = amount * assignedOrganization.FeesConstant / 100 // 1 is the smallest fees amount
-	}
-	if assignedOrganization.FeesType == organization.Fixed {
-		if amount < assignedOrganization.FeesConstant {
-			return FinalAmountLessThanZero
-		}
-		p.Amount = amount - assignedOrganization.FeesConstant
-		p.Fees = assignedOrganization.FeesConstant
+// SetAmount credits amount net of the fee in breakdown; the fee is kept on the payment.
+func (p *Payment) SetAmount(amount int64, currentBalanceAmount int64, breakdown fee.Breakdown) error {
+	if amount < breakdown.Total {
+		return FinalAmountLessThanZero
 	}
+	p.Amount = amount - breakdown.Total
+	p.Fees = breakdown.Total
+	p.FeeBreakdown = breakdown
+	p.FeeScheduleUUID = breakdown.ScheduleUUID
 
-	p.BalanceBeforePayment = currentBalanceAmount
-	p.BalanceAfterPayment = p.BalanceBeforePayment + p.Amount
+	p.SetBalanceSnapshot(currentBalanceAmount)
 	return nil
 }
 
+// SetBalanceSnapshot records the balance just before the payment is collected into it. A
+// pending payment does not move the balance, so the snapshot taken when it is created is
+// taken again when it is paid.
+func (p *Payment) SetBalanceSnapshot(balanceBefore int64) {
+	p.BalanceBeforePayment = balanceBefore
+	p.BalanceAfterPayment = balanceBefore + p.Amount
+}
+
+// SetParent makes the payment a share of the split payment parent, settled when the parent is.
+func (p *Payment) SetParent(parent *Payment) {
+	p.ParentPaymentUUID = parent.GetUUID()
+}
+
+// IsShare reports whether the payment is a share of a split payment other than the parent.
+func (p *Payment) IsShare() bool {
+	return p.ParentPaymentUUID != ""
+}
+
 func (p *Payment) SetOrganization(organization organization.Organization) {
 	p.OrganizationUUID = organization.UUID
 }
 
-func (p *Payment) SetVendorRecord(uuid string) {
+// SetVendorRecord records the vendor record the payment settles against. An empty vendor
+// code keeps the vendor the payment was created with.
+func (p *Payment) SetVendorRecord(vendorCode string, uuid string) {
+	if vendorCode != "" {
+		p.VendorCode = vendorCode
+	}
 	p.VendorRecordID = uuid
 }
 
+// CheckVendor rejects finalizing the payment for a vendor other than the one it was created
+// with. An empty vendorCode keeps the payment's own vendor, and payments recorded before
+// vendors were tracked carry no code and belong to defaultVendorCode.
+func (p *Payment) CheckVendor(vendorCode string, defaultVendorCode string) error {
+	createdWith := p.VendorCode
+	if createdWith == "" {
+		createdWith = defaultVendorCode
+	}
+	if vendorCode != "" && vendorCode != createdWith {
+		return UnmatchVendor
+	}
+	return nil
+}
+
+// Gross is the amount the payer is charged, the fee included.
+func (p *Payment) Gross() int64 {
+	return p.Amount + p.Fees
+}
+
+// CheckPaidAmount rejects a vendor payment whose collected amount is not the gross amount of
+// the payment and its shares, so an underpaid invoice is never credited in full.
+func CheckPaidAmount(collected int64, settling []*Payment) error {
+	var gross int64
+	for _, settlingPayment := range settling {
+		gross += settlingPayment.Gross()
+	}
+	if collected != gross {
+		return UnmatchPaidAmount
+	}
+	return nil
+}
+
 func (p *Payment) GenerateHash(previousPayment *Payment) error {
 	hashPayload := PaymentHashPayload{
 		UUID:                 p.UUID,
@@ -136,15 +194,94 @@ func (p *Payment) ScanDestinations() []interface{} {
 		&p.VendorRecordID,
 		&p.Status,
 		&p.Hash,
+		&p.VendorCode,
+		&p.Fees,
+		&p.Channel,
+		&p.FeeBreakdown,
+		&p.FeeScheduleUUID,
+		&p.ParentPaymentUUID,
+		helper.NullableDestinations([]interface{}{&p.FinalizedAt})[0],
 	}
 }
 
 func (p *Payment) SetPaid() {
 	p.Status = PaymentStatusPaid
+	p.setFinalized(time.Now())
 }
 
 func (p *Payment) SetFailed() {
 	p.Status = PaymentStatusFailed
+	p.setFinalized(time.Now())
+}
+
+func (p *Payment) SetExpired() {
+	p.Status = PaymentStatusExpired
+	p.setFinalized(time.Now())
+}
+
+// setFinalized records when the payment reached its current final status. Settlement counts
+// the payment on that day, however the row is updated afterwards.
+func (p *Payment) setFinalized(at time.Time) {
+	p.FinalizedAt = at
+	p.SetUpdatedAt(at)
+}
+
+// Share is one balance's part of a split payment: either a Fixed amount or PercentBps of
+// the inbound amount.
+type Share struct {
+	BalanceUUID string `json:"balanceUUID"`
+	Fixed       int64  `json:"fixed"`
+	PercentBps  int64  `json:"percentBps"`
+}
+
+func (s Share) Validate() error {
+	if s.BalanceUUID == "" || s.Fixed < 0 || s.PercentBps < 0 || s.PercentBps > fee.BasisPoints {
+		return InvalidShare
+	}
+	if (s.Fixed == 0) == (s.PercentBps == 0) {
+		return InvalidShare
+	}
+	return nil
+}
+
+// AllocateShares divides amount among shares. Percentage shares are rounded down and what
+// rounding leaves over goes to the first share, which becomes the parent payment, so the
+// allocations always add up to amount.
+func AllocateShares(amount int64, shares []Share) ([]int64, error) {
+	if len(shares) < 2 {
+		return nil, SplitNeedsShares
+	}
+
+	balances := make(map[string]bool)
+	allocations := make([]int64, len(shares))
+	var allocated int64
+	for i, share := range shares {
+		errValidate := share.Validate()
+		if errValidate != nil {
+			return nil, errValidate
+		}
+		if balances[share.BalanceUUID] {
+			return nil, DuplicateShareBalance
+		}
+		balances[share.BalanceUUID] = true
+
+		allocations[i] = share.Fixed
+		if share.PercentBps > 0 {
+			allocations[i], _ = fee.RoundDown.Apply(amount, share.PercentBps, fee.BasisPoints)
+		}
+		allocated += allocations[i]
+		if allocated > amount {
+			return nil, SharesExceedAmount
+		}
+	}
+
+	allocations[0] += amount - allocated
+	for _, allocation := range allocations {
+		if allocation == 0 {
+			return nil, InvalidShare
+		}
+	}
+	return allocations, nil
 }
 
 func NewPayment() *Payment {
@@ -163,3 +300,14 @@ func createHash(payload PaymentHashPayload) (string, error) {
 	hash := sha256.Sum256(jsonData)
 	return hex.EncodeToString(hash[:]), nil
 }
+
+// Validate rejects a filter whose amount or creation date range cannot match anything.
+func (f SearchFilter) Validate() error {
+	if f.MinAmount < 0 || f.MaxAmount < 0 || (f.MaxAmount > 0 && f.MinAmount > f.MaxAmount) {
+		return InvalidAmountRange
+	}
+	if !f.CreatedFrom.IsZero() && !f.CreatedTo.IsZero() && !f.CreatedTo.After(f.CreatedFrom) {
+		return InvalidDateRange
+	}
+	return nil
+}
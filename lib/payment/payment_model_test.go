@@ -0,0 +1,156 @@
+package payment
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAllocateShares(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount int64
+		shares []Share
+		want   []int64
+	}{
+		{
+			"percentages",
+			10000,
+			[]Share{{BalanceUUID: "a", PercentBps: 7000}, {BalanceUUID: "b", PercentBps: 3000}},
+			[]int64{7000, 3000},
+		},
+		{
+			"rounding leftover goes to the parent",
+			10001,
+			[]Share{{BalanceUUID: "a", PercentBps: 3333}, {BalanceUUID: "b", PercentBps: 3333},
+				{BalanceUUID: "c", PercentBps: 3334}},
+			[]int64{3334, 3333, 3334},
+		},
+		{
+			"fixed and percentage",
+			50000,
+			[]Share{{BalanceUUID: "a", PercentBps: 1000}, {BalanceUUID: "b", Fixed: 20000}},
+			[]int64{30000, 20000},
+		},
+		{
+			"unallocated remainder goes to the parent",
+			50000,
+			[]Share{{BalanceUUID: "a", Fixed: 1000}, {BalanceUUID: "b", Fixed: 20000}},
+			[]int64{30000, 20000},
+		},
+	}
+	for _, test := range tests {
+		allocations, errAllocate := AllocateShares(test.amount, test.shares)
+		if errAllocate != nil {
+			t.Errorf("%s: AllocateShares: %v", test.name, errAllocate)
+			continue
+		}
+		var total int64
+		for i := range allocations {
+			total += allocations[i]
+			if allocations[i] != test.want[i] {
+				t.Errorf("%s: allocation %d = %d, want %d", test.name, i, allocations[i], test.want[i])
+			}
+		}
+		if total != test.amount {
+			t.Errorf("%s: allocations add up to %d, want %d", test.name, total, test.amount)
+		}
+	}
+}
+
+func TestAllocateSharesRejects(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount int64
+		shares []Share
+		want   error
+	}{
+		{"single share", 1000, []Share{{BalanceUUID: "a", PercentBps: 10000}}, SplitNeedsShares},
+		{"fixed and percentage on one share", 1000,
+			[]Share{{BalanceUUID: "a", Fixed: 10, PercentBps: 10}, {BalanceUUID: "b", Fixed: 10}}, InvalidShare},
+		{"neither fixed nor percentage", 1000, []Share{{BalanceUUID: "a"}, {BalanceUUID: "b", Fixed: 10}}, InvalidShare},
+		{"missing balance", 1000, []Share{{Fixed: 10}, {BalanceUUID: "b", Fixed: 10}}, InvalidShare},
+		{"percentage above 100%", 1000,
+			[]Share{{BalanceUUID: "a", PercentBps: 10001}, {BalanceUUID: "b", Fixed: 10}}, InvalidShare},
+		{"same balance twice", 1000, []Share{{BalanceUUID: "a", Fixed: 10}, {BalanceUUID: "a", Fixed: 10}},
+			DuplicateShareBalance},
+		{"shares above amount", 1000, []Share{{BalanceUUID: "a", Fixed: 600}, {BalanceUUID: "b", Fixed: 500}},
+			SharesExceedAmount},
+		{"share rounded to nothing", 10, []Share{{BalanceUUID: "a", PercentBps: 9000}, {BalanceUUID: "b", PercentBps: 500}},
+			InvalidShare},
+	}
+	for _, test := range tests {
+		if _, errAllocate := AllocateShares(test.amount, test.shares); errAllocate != test.want {
+			t.Errorf("%s: AllocateShares = %v, want %v", test.name, errAllocate, test.want)
+		}
+	}
+}
+
+func TestPaymentCheckVendor(t *testing.T) {
+	tests := []struct {
+		name       string
+		createdVia string
+		vendorCode string
+		want       error
+	}{
+		{"same vendor", "xendit", "xendit", nil},
+		{"kept vendor", "xendit", "", nil},
+		{"other vendor", "xendit", "midtrans", UnmatchVendor},
+		{"recorded before vendors, default vendor", "", "xendit", nil},
+		{"recorded before vendors, other vendor", "", "midtrans", UnmatchVendor},
+	}
+	for _, test := range tests {
+		checked := NewPayment()
+		checked.VendorCode = test.createdVia
+		if errVendor := checked.CheckVendor(test.vendorCode, "xendit"); errVendor != test.want {
+			t.Errorf("%s: CheckVendor = %v, want %v", test.name, errVendor, test.want)
+		}
+	}
+}
+
+func TestCheckPaidAmount(t *testing.T) {
+	parent := &Payment{Amount: 6790, Fees: 210}
+	share := &Payment{Amount: 2910, Fees: 90}
+
+	tests := []struct {
+		name      string
+		collected int64
+		settling  []*Payment
+		want      error
+	}{
+		{"gross of a payment", 7000, []*Payment{parent}, nil},
+		{"net of a payment", 6790, []*Payment{parent}, UnmatchPaidAmount},
+		{"underpaid", 6999, []*Payment{parent}, UnmatchPaidAmount},
+		{"overpaid", 7001, []*Payment{parent}, UnmatchPaidAmount},
+		{"gross of a split payment", 10000, []*Payment{parent, share}, nil},
+		{"only the parent of a split payment", 7000, []*Payment{parent, share}, UnmatchPaidAmount},
+	}
+	for _, test := range tests {
+		if errAmount := CheckPaidAmount(test.collected, test.settling); errAmount != test.want {
+			t.Errorf("%s: CheckPaidAmount = %v, want %v", test.name, errAmount, test.want)
+		}
+	}
+}
+
+func TestSearchFilterValidate(t *testing.T) {
+	day := time.Date(2026, 5, 10, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		filter SearchFilter
+		want   error
+	}{
+		{"no filter", SearchFilter{}, nil},
+		{"amount range", SearchFilter{MinAmount: 100, MaxAmount: 100}, nil},
+		{"open amount range", SearchFilter{MinAmount: 100}, nil},
+		{"negative amount", SearchFilter{MinAmount: -1}, InvalidAmountRange},
+		{"inverted amount range", SearchFilter{MinAmount: 200, MaxAmount: 100}, InvalidAmountRange},
+		{"date range", SearchFilter{CreatedFrom: day, CreatedTo: day.Add(time.Hour)}, nil},
+		{"open date range", SearchFilter{CreatedTo: day}, nil},
+		{"empty date range", SearchFilter{CreatedFrom: day, CreatedTo: day}, InvalidDateRange},
+		{"inverted date range", SearchFilter{CreatedFrom: day, CreatedTo: day.Add(-time.Hour)}, InvalidDateRange},
+	}
+	for _, test := range tests {
+		if errValidate := test.filter.Validate(); errValidate != test.want {
+			t.Errorf("%s: Validate = %v, want %v", test.name, errValidate, test.want)
+		}
+	}
+}
@@ -7,31 +7,67 @@ import (
 	"paystore/config"
 	"paystore/lib/balance"
 	"paystore/lib/builder"
+	"paystore/lib/helper"
 	"paystore/lib/organization"
+	"paystore/lib/schema"
 	"paystore/lib/transaction"
-	vendorModel "paystore/user"
+	"strconv"
+	"strings"
+	"time"
 )
 
-var firstPartSelectQuery = `SELECT p.uuid, p.randid, p.created_at, p.updated_at, p.amount, p.balance_before_payment, p.balance_after_payment, p.balance_uuid, p.organization_uuid, p.hash`
+var firstPartSelectQuery = `SELECT p.uuid, p.randid, p.created_at, p.updated_at, p.amount, p.balance_before_payment, p.balance_after_payment, p.balance_uuid, p.organization_uuid, p.vendor_record_id, p.status, p.hash, p.vendor_code, p.fees, p.channel, p.fee_breakdown, p.fee_schedule_uuid, p.parent_payment_uuid, p.finalized_at`
 var findLatestPaymentQuery = firstPartSelectQuery + ` FROM payment p WHERE p.balance_uuid = $1 ORDER BY created_at DESC LIMIT 1;`
 var findPaymentByUUIDQuery = firstPartSelectQuery + ` FROM payment p WHERE p.uuid = $1;`
+var findPaymentByUUIDForUpdateQuery = firstPartSelectQuery + ` FROM payment p WHERE p.uuid = $1 FOR UPDATE;`
+var findSharesForUpdateQuery = firstPartSelectQuery + ` FROM payment p WHERE p.parent_payment_uuid = $1
+	ORDER BY p.created_at, p.uuid FOR UPDATE;`
+var findSharesQuery = firstPartSelectQuery + ` FROM payment p WHERE p.parent_payment_uuid = $1
+	ORDER BY p.created_at, p.uuid;`
+
+// Split payment shares expire with their parent, so the expirable queries skip them.
+var findExpirableQuery = firstPartSelectQuery + ` FROM payment p WHERE p.status = $1 AND p.created_at < $2
+	AND p.parent_payment_uuid = ''
+	ORDER BY p.created_at LIMIT $3`
+
+// findExpirableWithVendorQuery also expires payments whose invoice expiry date has passed,
+// joining the vendor tables that report an expiry date. $4 is the current time and the vendor
+// codes follow it. A zero expiry date means the vendor did not report one.
+func findExpirableWithVendorQuery(joins []string, conditions []string) string {
+	return firstPartSelectQuery + ` FROM payment p ` + strings.Join(joins, " ") + `
+	WHERE p.status = $1 AND (p.created_at < $2 OR ` + strings.Join(conditions, " OR ") + `)
+	AND p.parent_payment_uuid = '' ORDER BY p.created_at LIMIT $3`
+}
 
 type RepositoryClient interface {
 	Create(tx *sql.Tx, payment *Payment, balance *balance.Balance, organization *organization.Organization) error
 	Update(tx *sql.Tx, payment *Payment) error
 	FindLatestPayment(balance *balance.Balance) (*Payment, error)
 	FindByUUID(uuid string) (*Payment, error)
+	FindByUUIDForUpdate(tx *sql.Tx, uuid string) (*Payment, error)
+	FindShares(parentUUID string) ([]*Payment, error)
+	FindSharesForUpdate(tx *sql.Tx, parentUUID string) ([]*Payment, error)
+	UpsertVendor(tx *sql.Tx, vendor *schema.Record) error
+	SetCache(payment *Payment) error
+	SetVendorCache(vendor *schema.Record) error
 	SeedPartialByBalance(subtraction int64, lastRandId string, balance *balance.Balance) error
+	Search(filter SearchFilter) ([]*Payment, string, error)
+	FindExpirable(createdBefore time.Time, now time.Time, limit int64) ([]*Payment, error)
+	AddToTimeline(payment *Payment, organization *organization.Organization, balance *balance.Balance) error
+	RemoveFromTimeline(payment *Payment, organization *organization.Organization, balance *balance.Balance) error
 }
 
 type Repository struct {
 	readDB                  *sql.DB
 	base                    *redifu.Base[*Payment]
+	vendorRepository        *VendorRepository
 	timelineByAccount       *redifu.Timeline[*Payment]
 	timelineByAccountSeeder *redifu.TimelineSeeder[*Payment]
 	AppConfig               *config.App
 	findLatestPaymentStmt   *sql.Stmt
 	findPaymentByUUIDStmt   *sql.Stmt
+	findExpirableQuery      string
+	expiryVendorCodes       []interface{}
 }
 
 func (br *Repository) Create(tx *sql.Tx, payment *Payment, balance *balance.Balance, organization *organization.Organization) error {
@@ -43,8 +79,9 @@ func (br *Repository) Create(tx *sql.Tx, payment *Payment, balance *balance.Bala
 		INSERT INTO payment (
 			uuid, randid, created_at, updated_at,
 			amount, balance_before_payment, balance_after_payment,
-			balance_uuid, organization_uuid, vendor_record_id, status, hash
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
+			balance_uuid, organization_uuid, vendor_record_id, status, hash, vendor_code,
+			fees, channel, fee_breakdown, fee_schedule_uuid, parent_payment_uuid
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)`
 	_, err := tx.Exec(
 		createPaymentQuery,
 		payment.GetUUID(),
@@ -59,35 +96,36 @@ func (br *Repository) Create(tx *sql.Tx, payment *Payment, balance *balance.Bala
 		payment.VendorRecordID,
 		payment.Status,
 		payment.Hash,
+		payment.VendorCode,
+		payment.Fees,
+		payment.Channel,
+		payment.FeeBreakdown,
+		payment.FeeScheduleUUID,
+		payment.ParentPaymentUUID,
 	)
-	if err != nil {
-		return err
-	}
-
-	errSet := br.base.Set(payment)
-	if errSet != nil {
-		return errSet
-	}
-
-	errSet = br.timelineByAccount.AddItem(payment, []string{organization.GetRandId(), balance.GetRandId()})
-	if errSet != nil {
-		return errSet
-	}
-
 	return err
 }
 
 func (br *Repository) Update(tx *sql.Tx, payment *Payment) error {
 	query := `UPDATE payment SET updated_at = $1, organization_uuid = $2, 
-                   vendor_record_id = $3, status = $4, hash = $5 WHERE uuid = $6`
+                   vendor_record_id = $3, status = $4, hash = $5, vendor_code = $6, finalized_at = $7,
+                   balance_before_payment = $8, balance_after_payment = $9 WHERE uuid = $10`
 	_, errExec := tx.Exec(query, payment.GetUpdatedAt(), payment.OrganizationUUID, payment.VendorRecordID,
-		payment.Status, payment.Hash, payment.GetUUID())
-	if errExec != nil {
-		return errExec
-	}
+		payment.Status, payment.Hash, payment.VendorCode, nullTime(payment.FinalizedAt), payment.BalanceBeforePayment,
+		payment.BalanceAfterPayment, payment.GetUUID())
+	return errExec
+}
 
-	br.base.Set(payment)
-	return nil
+// SetCache stores the payment in the cache. Create and Update only write the row, so callers
+// cache the payment once the transaction that wrote it has committed.
+func (br *Repository) SetCache(payment *Payment) error {
+	return br.base.Set(payment)
+}
+
+// SetVendorCache stores the vendor record in the cache once the transaction that upserted it
+// has committed.
+func (br *Repository) SetVendorCache(vendor *schema.Record) error {
+	return br.vendorRepository.SetCache(vendor)
 }
 
 func (br *Repository) FindLatestPayment(balance *balance.Balance) (*Payment, error) {
@@ -114,18 +152,167 @@ func (br *Repository) FindByUUID(uuid string) (*Payment, error) {
 	return payment, nil
 }
 
+func (br *Repository) FindByUUIDForUpdate(tx *sql.Tx, uuid string) (*Payment, error) {
+	payment, err := PaymentRowScanner(tx.QueryRow(findPaymentByUUIDForUpdateQuery, uuid))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, PaymentNotFound
+		}
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+// FindShares returns the shares of the split payment parentUUID, excluding the parent itself.
+func (br *Repository) FindShares(parentUUID string) ([]*Payment, error) {
+	rows, errQuery := br.readDB.Query(findSharesQuery, parentUUID)
+	if errQuery != nil {
+		return nil, errQuery
+	}
+	return scanPayments(rows)
+}
+
+// FindSharesForUpdate locks the shares of the split payment parentUUID, in creation order.
+func (br *Repository) FindSharesForUpdate(tx *sql.Tx, parentUUID string) ([]*Payment, error) {
+	rows, errQuery := tx.Query(findSharesForUpdateQuery, parentUUID)
+	if errQuery != nil {
+		return nil, errQuery
+	}
+	return scanPayments(rows)
+}
+
+func (br *Repository) UpsertVendor(tx *sql.Tx, vendor *schema.Record) error {
+	return br.vendorRepository.Upsert(tx, vendor)
+}
+
 func (br *Repository) SeedPartialByBalance(subtraction int64, lastRandId string, balance *balance.Balance) error {
-	joinedQuery := builder.JoinBuilder(firstPartSelectQuery, transaction.TypePayment, br.AppConfig)
+	// The balance is $1 and the vendor codes follow it; the seeder appends the page cursor last.
+	joinedQuery, vendorArgs := builder.JoinBuilder(firstPartSelectQuery, transaction.TypePayment, br.AppConfig, 2)
+	queryArgs := append([]interface{}{balance.GetUUID()}, vendorArgs...)
+	cursorPlaceholder := "$" + strconv.Itoa(len(queryArgs)+1)
 
-	rowQuery := joinedQuery + " WHERE p.randid = $1"
-	firstPageQuery := joinedQuery + " WHERE p.balance_uuid = $1 ORDER BY created_at DESC"
-	nextPageQuery := joinedQuery + " WHERE p.balance_uuid = $1 AND created_at < $2 ORDER BY created_at DESC"
+	rowQuery := firstPartSelectQuery + " FROM payment p WHERE p.randid = $1"
+	firstPageQuery := joinedQuery + " WHERE p.balance_uuid = $1 ORDER BY p.created_at DESC"
+	nextPageQuery := joinedQuery + " WHERE p.balance_uuid = $1 AND p.created_at < " + cursorPlaceholder + " ORDER BY p.created_at DESC"
 
 	return br.timelineByAccountSeeder.SeedPartialWithRelation(rowQuery, firstPageQuery, nextPageQuery,
-		PaymentRowScanner, PaymentRowsScanner, []interface{}{balance.GetUUID()},
+		PaymentRowScanner, PaymentRowsScanner(br.AppConfig.StoredPaymentVendors()), queryArgs,
 		subtraction, lastRandId, []string{balance.GetRandId()})
 }
 
+func (br *Repository) Search(filter SearchFilter) ([]*Payment, string, error) {
+	errValidate := filter.Validate()
+	if errValidate != nil {
+		return nil, "", errValidate
+	}
+
+	limit := filter.Limit
+	if limit <= 0 || limit > br.AppConfig.ItemPerPage {
+		limit = br.AppConfig.ItemPerPage
+	}
+
+	filterBuilder := builder.NewFilterBuilder()
+	if filter.OrganizationUUID != "" {
+		filterBuilder.Where("p.organization_uuid", "=", filter.OrganizationUUID)
+	}
+	if filter.BalanceUUID != "" {
+		filterBuilder.Where("p.balance_uuid", "=", filter.BalanceUUID)
+	}
+	if len(filter.Statuses) > 0 {
+		var statuses []interface{}
+		for _, status := range filter.Statuses {
+			statuses = append(statuses, status)
+		}
+		filterBuilder.WhereIn("p.status", statuses)
+	}
+	if filter.MinAmount > 0 {
+		filterBuilder.Where("p.amount", ">=", filter.MinAmount)
+	}
+	if filter.MaxAmount > 0 {
+		filterBuilder.Where("p.amount", "<=", filter.MaxAmount)
+	}
+	if !filter.CreatedFrom.IsZero() {
+		filterBuilder.Where("p.created_at", ">=", filter.CreatedFrom)
+	}
+	if !filter.CreatedTo.IsZero() {
+		filterBuilder.Where("p.created_at", "<", filter.CreatedTo)
+	}
+	if filter.VendorRecordID != "" {
+		filterBuilder.Where("p.vendor_record_id", "=", filter.VendorRecordID)
+	}
+	if filter.VendorCode != "" {
+		filterBuilder.Where("p.vendor_code", "=", filter.VendorCode)
+	}
+	if filter.Cursor != "" {
+		cursorTime, cursorUUID, errDecode := helper.DecodeCursor(filter.Cursor)
+		if errDecode != nil {
+			return nil, "", errDecode
+		}
+		filterBuilder.WhereBefore("p.created_at", "p.uuid", cursorTime, cursorUUID)
+	}
+
+	query := firstPartSelectQuery + ` FROM payment p` + filterBuilder.Clause() +
+		` ORDER BY p.created_at DESC, p.uuid DESC` + filterBuilder.Limit(limit+1)
+	rows, errQuery := br.readDB.Query(query, filterBuilder.Args()...)
+	if errQuery != nil {
+		return nil, "", errQuery
+	}
+	defer rows.Close()
+
+	var payments []*Payment
+	for rows.Next() {
+		payment := NewPayment()
+		errScan := rows.Scan(payment.ScanDestinations()...)
+		if errScan != nil {
+			return nil, "", errScan
+		}
+		payments = append(payments, payment)
+	}
+	if errRows := rows.Err(); errRows != nil {
+		return nil, "", errRows
+	}
+
+	var nextCursor string
+	if int64(len(payments)) > limit {
+		payments = payments[:limit]
+		lastPayment := payments[len(payments)-1]
+		nextCursor = helper.EncodeCursor(lastPayment.GetCreatedAt(), lastPayment.GetUUID())
+	}
+
+	return payments, nextCursor, nil
+}
+
+// FindExpirable returns pending payments created before createdBefore, or whose vendor
+// invoice expired before now, oldest first.
+func (br *Repository) FindExpirable(createdBefore time.Time, now time.Time, limit int64) ([]*Payment, error) {
+	args := []interface{}{PaymentStatusPending, createdBefore, limit}
+	if len(br.expiryVendorCodes) > 0 {
+		args = append(args, now)
+		args = append(args, br.expiryVendorCodes...)
+	}
+
+	rows, errQuery := br.readDB.Query(br.findExpirableQuery, args...)
+	if errQuery != nil {
+		return nil, errQuery
+	}
+	return scanPayments(rows)
+}
+
+func (br *Repository) AddToTimeline(payment *Payment, organization *organization.Organization,
+	balance *balance.Balance) error {
+	return br.timelineByAccount.AddItem(payment, []string{organization.GetRandId(), balance.GetRandId()})
+}
+
+func (br *Repository) RemoveFromTimeline(payment *Payment, organization *organization.Organization,
+	balance *balance.Balance) error {
+	return br.timelineByAccount.RemoveItem(payment, []string{organization.GetRandId(), balance.GetRandId()})
+}
+
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
+
 func NewRepository(readDB *sql.DB, redis redis.UniversalClient, appConfig *config.App) (*Repository, error) {
 	var err error
 
@@ -135,7 +322,7 @@ func NewRepository(readDB *sql.DB, redis redis.UniversalClient, appConfig *confi
 
 	vendorRepo := NewVendorRepository(redis, appConfig)
 
-	vendorRelation := redifu.NewRelation[*vendorModel.PaymentVendor](vendorRepo.GetBase(),
+	vendorRelation := redifu.NewRelation[*schema.Record](vendorRepo.GetBase(),
 		"PaymentVendor", "PaymentVendorRandId")
 
 	basePayment := redifu.NewBase[*Payment](redis, "payment:%s", appConfig.RecordAge)
@@ -154,53 +341,161 @@ func NewRepository(readDB *sql.DB, redis redis.UniversalClient, appConfig *confi
 		panic(err)
 	}
 
+	expirableQuery := findExpirableQuery
+	var expiryJoins, expiryConditions []string
+	var expiryVendorCodes []interface{}
+	for _, vendor := range appConfig.StoredPaymentVendors() {
+		expiryColumn := vendor.Schema.RoleColumn(schema.RoleExpiry)
+		if expiryColumn == "" {
+			continue
+		}
+		// Table and column names are validated by the vendor schema; the code is bound.
+		expiry := vendor.TableAlias + "." + expiryColumn
+		expiryJoins = append(expiryJoins, "LEFT JOIN "+vendor.TableName+" "+vendor.TableAlias+" ON "+
+			vendor.TableAlias+"."+vendor.IDColumn()+" = p.vendor_record_id AND "+
+			vendor.CodeCondition("p.vendor_code", 5+len(expiryVendorCodes)))
+		expiryConditions = append(expiryConditions, "("+expiry+" > '0001-01-01' AND "+expiry+" < $4)")
+		expiryVendorCodes = append(expiryVendorCodes, vendor.Code)
+	}
+	if len(expiryJoins) > 0 {
+		expirableQuery = findExpirableWithVendorQuery(expiryJoins, expiryConditions)
+	}
+
 	return &Repository{
+		readDB:                  readDB,
 		base:                    basePayment,
+		vendorRepository:        vendorRepo,
 		timelineByAccount:       timelineByAccount,
 		timelineByAccountSeeder: timelineByAccountSeeder,
 		AppConfig:               appConfig,
 		findLatestPaymentStmt:   findLatestPaymentStmt,
 		findPaymentByUUIDStmt:   findPaymentByUUIDStmt,
+		findExpirableQuery:      expirableQuery,
+		expiryVendorCodes:       expiryVendorCodes,
 	}, nil
 }
 
+func scanPayments(rows *sql.Rows) ([]*Payment, error) {
+	defer rows.Close()
+
+	var payments []*Payment
+	for rows.Next() {
+		payment := NewPayment()
+		errScan := rows.Scan(payment.ScanDestinations()...)
+		if errScan != nil {
+			return nil, errScan
+		}
+		payments = append(payments, payment)
+	}
+	if errRows := rows.Err(); errRows != nil {
+		return nil, errRows
+	}
+
+	return payments, nil
+}
+
 func PaymentRowScanner(row *sql.Row) (*Payment, error) {
 	payment := NewPayment()
 	err := row.Scan(payment.ScanDestinations()...)
 	return payment, err
 }
 
-func PaymentRowsScanner(rows *sql.Rows, relation map[string]redifu.Relation) (*Payment, error) {
-	payment := NewPayment()
-	paymentVendor := vendorModel.NewPaymentVendor()
-
-	var scanDestinations []interface{}
-	scanDestinations = append(scanDestinations, payment.ScanDestinations()...)
-	scanDestinations = append(scanDestinations, paymentVendor.ScanDestinations()...)
+// PaymentRowsScanner scans rows of the joined timeline query, which carries the columns of
+// every vendor in vendors, in order. Only the vendor that processed the payment joins a row.
+func PaymentRowsScanner(vendors []*config.Vendor) func(rows *sql.Rows, relation map[string]redifu.Relation) (*Payment, error) {
+	return func(rows *sql.Rows, relation map[string]redifu.Relation) (*Payment, error) {
+		payment := NewPayment()
+
+		var scanDestinations []interface{}
+		scanDestinations = append(scanDestinations, payment.ScanDestinations()...)
+		var paymentVendors []*schema.Record
+		for _, vendor := range vendors {
+			paymentVendor := vendor.Schema.NewRecord()
+			paymentVendor.UUID = ""
+			paymentVendors = append(paymentVendors, paymentVendor)
+			scanDestinations = append(scanDestinations, helper.NullableDestinations(paymentVendor.ScanDestinations())...)
+		}
 
-	err := rows.Scan(scanDestinations)
+		err := rows.Scan(scanDestinations...)
+		if err != nil {
+			return nil, err
+		}
 
-	if paymentVendor.UUID != "" {
-		errSet := relation["vendor"].SetItem(paymentVendor)
-		if errSet != nil {
-			return nil, errSet
+		for _, paymentVendor := range paymentVendors {
+			if paymentVendor.UUID == "" {
+				continue
+			}
+			errSet := relation["vendor"].SetItem(paymentVendor)
+			if errSet != nil {
+				return nil, errSet
+			}
+			payment.PaymentVendorRandId = paymentVendor.GetRandId()
+			break
 		}
-	}
 
-	return payment, err
+		return payment, nil
+	}
 }
 
 type VendorRepository struct {
-	base *redifu.Base[*vendorModel.PaymentVendor]
+	base          *redifu.Base[*schema.Record]
+	upsertQuery   map[string]string
+	defaultVendor string
 }
 
-func (r *VendorRepository) GetBase() *redifu.Base[*vendorModel.PaymentVendor] {
+func (r *VendorRepository) GetBase() *redifu.Base[*schema.Record] {
 	return r.base
 }
 
+// Upsert keys vendor rows on the vendor's own ID so repeated webhook deliveries update one row.
+// The row goes to the table of the vendor the record was decoded for.
+func (r *VendorRepository) Upsert(tx *sql.Tx, vendor *schema.Record) error {
+	vendorCode := vendor.VendorCode
+	if vendorCode == "" {
+		vendorCode = r.defaultVendor
+	}
+	upsertQuery, found := r.upsertQuery[vendorCode]
+	if !found {
+		return VendorRequired
+	}
+
+	_, errExec := tx.Exec(upsertQuery, vendor.QueryArgs()...)
+	return errExec
+}
+
+func (r *VendorRepository) SetCache(vendor *schema.Record) error {
+	return r.base.Set(vendor)
+}
+
+// CreateVendorTable creates a table for every stored payment vendor from its schema.
+func CreateVendorTable(writeDB *sql.DB, config *config.App) error {
+	for _, vendor := range config.StoredPaymentVendors() {
+		_, errExec := writeDB.Exec(builder.CreateTableBuilder(vendor.TableName, vendor.ColumnDefinitions(),
+			vendor.IDColumn()))
+		if errExec != nil {
+			return errExec
+		}
+	}
+	return nil
+}
+
 func NewVendorRepository(redis redis.UniversalClient, config *config.App) *VendorRepository {
-	base := redifu.NewBase[*vendorModel.PaymentVendor](redis, "vendor-item:%s", config.RecordAge)
+	base := redifu.NewBase[*schema.Record](redis, "vendor-item:%s", config.RecordAge)
+
+	upsertQuery := make(map[string]string)
+	for _, vendor := range config.StoredPaymentVendors() {
+		upsertQuery[vendor.Code] = builder.UpsertBuilder(vendor.TableName, vendor.ColumnNames(),
+			vendor.IDColumn(), []string{"uuid", "randid", "created_at"})
+	}
+
+	var defaultVendor string
+	if len(config.PaymentVendors) > 0 {
+		defaultVendor = config.PaymentVendors[0].Code
+	}
+
 	return &VendorRepository{
-		base: base,
+		base:          base,
+		upsertQuery:   upsertQuery,
+		defaultVendor: defaultVendor,
 	}
 }
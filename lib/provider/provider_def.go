@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"errors"
+	"paystore/lib/schema"
+	"time"
+)
+
+var InvoiceNotFound = errors.New("Invoice not found")
+var InvoiceNotCancellable = errors.New("Invoice is no longer pending")
+var UnexpectedResponse = errors.New("Unexpected response from payment provider")
+var DisbursementNotFound = errors.New("Disbursement not found")
+var DisbursementRejected = errors.New("Disbursement rejected by provider")
+
+const XenditBaseURL = "https://api.xendit.co"
+
+const (
+	InvoicePending = "PENDING"
+	InvoicePaid    = "PAID"
+	InvoiceExpired = "EXPIRED"
+)
+
+const (
+	DisbursementPending   = "PENDING"
+	DisbursementCompleted = "COMPLETED"
+	DisbursementFailed    = "FAILED"
+)
+
+type InvoiceRequest struct {
+	ExternalID  string
+	Amount      int64
+	Currency    string
+	Description string
+	PayerEmail  string
+	Duration    time.Duration
+}
+
+// PaymentProvider creates and manages the invoice a customer pays against. ExternalID on the
+// request is the paystore payment UUID, so callbacks can be matched back to the payment.
+// Invoices are returned as records of the payment vendor schema.
+type PaymentProvider interface {
+	CreateInvoice(request InvoiceRequest) (*schema.Record, error)
+	GetInvoice(vendorID string) (*schema.Record, error)
+	CancelInvoice(vendorID string) (*schema.Record, error)
+}
+
+type Destination struct {
+	ChannelCode       string
+	AccountNumber     string
+	AccountHolderName string
+}
+
+type DisbursementRequest struct {
+	ReferenceID string
+	Amount      int64
+	Currency    string
+	Description string
+	Destination Destination
+}
+
+// DisbursementProvider sends money out for a withdraw. ReferenceID on the request is the
+// paystore withdraw UUID. Implementations return DisbursementRejected when the provider
+// definitively refuses the payout, so callers can tell it apart from transport failures.
+// Disbursements are returned as records of the withdraw vendor schema.
+type DisbursementProvider interface {
+	CreateDisbursement(request DisbursementRequest) (*schema.Record, error)
+	GetDisbursement(vendorID string) (*schema.Record, error)
+}
@@ -0,0 +1,230 @@
+package provider
+
+import (
+	"github.com/21strive/item"
+	"paystore/lib/schema"
+	"sync"
+	"time"
+)
+
+// MemoryProvider is an in-process PaymentProvider for tests and local development.
+type MemoryProvider struct {
+	mutex    sync.Mutex
+	schema   *schema.Schema
+	invoices map[string]*schema.Record
+}
+
+func (m *MemoryProvider) CreateInvoice(request InvoiceRequest) (*schema.Record, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	now := time.Now()
+	vendorID := "inv_" + item.RandId()
+	invoice := m.schema.NewRecord()
+	errSet := invoice.SetValues(map[string]interface{}{
+		"email":   request.PayerEmail,
+		"updated": now,
+	})
+	if errSet != nil {
+		return nil, errSet
+	}
+	errSet = setRoles(invoice, map[schema.Role]interface{}{
+		schema.RoleID:         vendorID,
+		schema.RoleReference:  request.ExternalID,
+		schema.RoleStatus:     InvoicePending,
+		schema.RoleAmount:     request.Amount,
+		schema.RoleCurrency:   request.Currency,
+		schema.RoleInvoiceURL: "https://invoice.local/" + vendorID,
+		schema.RoleCreated:    now,
+	})
+	if errSet != nil {
+		return nil, errSet
+	}
+	if request.Duration > 0 {
+		errSet = invoice.SetRole(schema.RoleExpiry, now.Add(request.Duration))
+		if errSet != nil {
+			return nil, errSet
+		}
+	}
+
+	m.invoices[vendorID] = invoice
+	return invoice.Clone(), nil
+}
+
+func (m *MemoryProvider) GetInvoice(vendorID string) (*schema.Record, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	invoice, found := m.invoices[vendorID]
+	if !found {
+		return nil, InvoiceNotFound
+	}
+
+	return invoice.Clone(), nil
+}
+
+func (m *MemoryProvider) CancelInvoice(vendorID string) (*schema.Record, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	invoice, found := m.invoices[vendorID]
+	if !found {
+		return nil, InvoiceNotFound
+	}
+	if invoice.Status() != InvoicePending {
+		return nil, InvoiceNotCancellable
+	}
+
+	errSet := invoice.SetRole(schema.RoleStatus, InvoiceExpired)
+	if errSet != nil {
+		return nil, errSet
+	}
+	errSet = invoice.Set("updated", time.Now())
+	if errSet != nil {
+		return nil, errSet
+	}
+	return invoice.Clone(), nil
+}
+
+// Pay simulates the customer settling an invoice in full.
+func (m *MemoryProvider) Pay(vendorID string) (*schema.Record, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	invoice, found := m.invoices[vendorID]
+	if !found {
+		return nil, InvoiceNotFound
+	}
+
+	paidAt := time.Now()
+	errSet := setRoles(invoice, map[schema.Role]interface{}{
+		schema.RoleStatus:     InvoicePaid,
+		schema.RolePaidAmount: invoice.Amount(),
+	})
+	if errSet != nil {
+		return nil, errSet
+	}
+	errSet = invoice.SetValues(map[string]interface{}{"paid_at": paidAt, "updated": paidAt})
+	if errSet != nil {
+		return nil, errSet
+	}
+	return invoice.Clone(), nil
+}
+
+func NewMemoryProvider(vendorSchema *schema.Schema) *MemoryProvider {
+	return &MemoryProvider{
+		schema:   vendorSchema,
+		invoices: make(map[string]*schema.Record),
+	}
+}
+
+// MemoryDisbursementProvider is an in-process DisbursementProvider for tests and local development.
+type MemoryDisbursementProvider struct {
+	mutex         sync.Mutex
+	schema        *schema.Schema
+	disbursements map[string]*schema.Record
+	arrivalDelay  time.Duration
+	RejectChannel map[string]bool
+}
+
+func (m *MemoryDisbursementProvider) CreateDisbursement(request DisbursementRequest) (*schema.Record, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.RejectChannel[request.Destination.ChannelCode] {
+		return nil, DisbursementRejected
+	}
+
+	now := time.Now()
+	vendorID := "disb_" + item.RandId()
+	disbursement := m.schema.NewRecord()
+	errSet := disbursement.SetValues(map[string]interface{}{
+		"description":            request.Description,
+		"channel_code":           request.Destination.ChannelCode,
+		"account_number":         request.Destination.AccountNumber,
+		"account_holder_name":    request.Destination.AccountHolderName,
+		"updated":                now,
+		"estimated_arrival_time": now.Add(m.arrivalDelay),
+	})
+	if errSet != nil {
+		return nil, errSet
+	}
+	errSet = setRoles(disbursement, map[schema.Role]interface{}{
+		schema.RoleID:        vendorID,
+		schema.RoleReference: request.ReferenceID,
+		schema.RoleStatus:    DisbursementPending,
+		schema.RoleAmount:    request.Amount,
+		schema.RoleCurrency:  request.Currency,
+		schema.RoleCreated:   now,
+	})
+	if errSet != nil {
+		return nil, errSet
+	}
+
+	m.disbursements[vendorID] = disbursement
+	return disbursement.Clone(), nil
+}
+
+func (m *MemoryDisbursementProvider) GetDisbursement(vendorID string) (*schema.Record, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	disbursement, found := m.disbursements[vendorID]
+	if !found {
+		return nil, DisbursementNotFound
+	}
+
+	return disbursement.Clone(), nil
+}
+
+// Complete simulates the provider confirming the payout reached the destination account.
+func (m *MemoryDisbursementProvider) Complete(vendorID string) (*schema.Record, error) {
+	return m.settle(vendorID, DisbursementCompleted, "")
+}
+
+// Fail simulates the provider giving up on the payout with the given failure code.
+func (m *MemoryDisbursementProvider) Fail(vendorID string, failureCode string) (*schema.Record, error) {
+	return m.settle(vendorID, DisbursementFailed, failureCode)
+}
+
+func (m *MemoryDisbursementProvider) settle(vendorID string, status string, failureCode string) (*schema.Record, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	disbursement, found := m.disbursements[vendorID]
+	if !found {
+		return nil, DisbursementNotFound
+	}
+
+	errSet := setRoles(disbursement, map[schema.Role]interface{}{
+		schema.RoleStatus:      status,
+		schema.RoleFailureCode: failureCode,
+	})
+	if errSet != nil {
+		return nil, errSet
+	}
+	errSet = disbursement.Set("updated", time.Now())
+	if errSet != nil {
+		return nil, errSet
+	}
+	return disbursement.Clone(), nil
+}
+
+func NewMemoryDisbursementProvider(vendorSchema *schema.Schema, arrivalDelay time.Duration) *MemoryDisbursementProvider {
+	return &MemoryDisbursementProvider{
+		schema:        vendorSchema,
+		disbursements: make(map[string]*schema.Record),
+		arrivalDelay:  arrivalDelay,
+		RejectChannel: make(map[string]bool),
+	}
+}
+
+func setRoles(record *schema.Record, values map[schema.Role]interface{}) error {
+	for role, value := range values {
+		errSet := record.SetRole(role, value)
+		if errSet != nil {
+			return errSet
+		}
+	}
+	return nil
+}
@@ -0,0 +1,215 @@
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"paystore/lib/schema"
+	"paystore/user"
+	"testing"
+	"time"
+)
+
+func paymentSchema(t *testing.T) *schema.Schema {
+	t.Helper()
+	schemas, errParse := schema.Parse(user.DefaultVendorSchema)
+	if errParse != nil {
+		t.Fatalf("parse default vendor schema: %v", errParse)
+	}
+	return schemas.Payment[0]
+}
+
+func TestXenditCreateInvoice(t *testing.T) {
+	expiry := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	var received xenditInvoiceRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v2/invoices" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if username, _, ok := r.BasicAuth(); !ok || username != "secret" {
+			t.Errorf("secret key not sent as basic auth username, got %q", username)
+		}
+		if errDecode := json.NewDecoder(r.Body).Decode(&received); errDecode != nil {
+			t.Errorf("decode request: %v", errDecode)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":          "inv-1",
+			"external_id": received.ExternalID,
+			"status":      InvoicePending,
+			"amount":      received.Amount,
+			"currency":    received.Currency,
+			"invoice_url": "https://checkout.example/inv-1",
+			"expiry_date": expiry,
+		})
+	}))
+	defer server.Close()
+
+	xendit := NewXenditInvoiceProvider(server.URL+"/", "secret", server.Client(), paymentSchema(t))
+	invoice, errCreate := xendit.CreateInvoice(InvoiceRequest{
+		ExternalID: "payment-1",
+		Amount:     15000,
+		Currency:   "IDR",
+		Duration:   2 * time.Hour,
+	})
+	if errCreate != nil {
+		t.Fatalf("CreateInvoice: %v", errCreate)
+	}
+
+	if received.InvoiceDuration != 7200 {
+		t.Errorf("invoice duration sent = %d, want 7200", received.InvoiceDuration)
+	}
+	if invoice.ID() != "inv-1" || invoice.Reference() != "payment-1" || invoice.Status() != InvoicePending {
+		t.Errorf("unexpected invoice id=%q reference=%q status=%q", invoice.ID(), invoice.Reference(), invoice.Status())
+	}
+	if invoice.Amount() != 15000 || invoice.Currency() != "IDR" {
+		t.Errorf("unexpected amount %d %s", invoice.Amount(), invoice.Currency())
+	}
+	if invoice.InvoiceURL() != "https://checkout.example/inv-1" {
+		t.Errorf("invoice url = %q", invoice.InvoiceURL())
+	}
+	if !invoice.Expiry().Equal(expiry) {
+		t.Errorf("expiry = %v, want %v", invoice.Expiry(), expiry)
+	}
+}
+
+func TestXenditGetAndCancelInvoice(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.EscapedPath())
+		status := InvoicePaid
+		if r.Method == http.MethodPost {
+			status = InvoiceExpired
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"id": "inv/2", "status": status})
+	}))
+	defer server.Close()
+
+	xendit := NewXenditInvoiceProvider(server.URL, "secret", server.Client(), paymentSchema(t))
+	invoice, errGet := xendit.GetInvoice("inv/2")
+	if errGet != nil {
+		t.Fatalf("GetInvoice: %v", errGet)
+	}
+	if invoice.Status() != InvoicePaid {
+		t.Errorf("status = %q, want %q", invoice.Status(), InvoicePaid)
+	}
+
+	invoice, errCancel := xendit.CancelInvoice("inv/2")
+	if errCancel != nil {
+		t.Fatalf("CancelInvoice: %v", errCancel)
+	}
+	if invoice.Status() != InvoiceExpired {
+		t.Errorf("status = %q, want %q", invoice.Status(), InvoiceExpired)
+	}
+
+	want := []string{"GET /v2/invoices/inv%2F2", "POST /invoices/inv%2F2/expire!"}
+	if len(paths) != len(want) {
+		t.Fatalf("requests = %v, want %v", paths, want)
+	}
+	for i := range want {
+		if paths[i] != want[i] {
+			t.Errorf("request %d = %q, want %q", i, paths[i], want[i])
+		}
+	}
+}
+
+func TestXenditErrorResponses(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		want       error
+	}{
+		{"not found", http.StatusNotFound, InvoiceNotFound},
+		{"server error", http.StatusInternalServerError, UnexpectedResponse},
+		{"bad request", http.StatusBadRequest, UnexpectedResponse},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(test.statusCode)
+			}))
+			defer server.Close()
+
+			xendit := NewXenditInvoiceProvider(server.URL, "secret", server.Client(), paymentSchema(t))
+			_, errGet := xendit.GetInvoice("inv-3")
+			if !errors.Is(errGet, test.want) {
+				t.Errorf("error = %v, want %v", errGet, test.want)
+			}
+		})
+	}
+}
+
+func withdrawSchema(t *testing.T) *schema.Schema {
+	t.Helper()
+	schemas, errParse := schema.Parse(user.DefaultVendorSchema)
+	if errParse != nil {
+		t.Fatalf("parse default vendor schema: %v", errParse)
+	}
+	return schemas.Withdraw[0]
+}
+
+func TestXenditCreateDisbursement(t *testing.T) {
+	var received xenditDisbursementRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/disbursements" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if key := r.Header.Get("X-IDEMPOTENCY-KEY"); key != "withdraw-1" {
+			t.Errorf("idempotency key = %q, want withdraw-1", key)
+		}
+		if errDecode := json.NewDecoder(r.Body).Decode(&received); errDecode != nil {
+			t.Errorf("decode request: %v", errDecode)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":          "disb-1",
+			"external_id": received.ExternalID,
+			"amount":      received.Amount,
+			"bank_code":   received.BankCode,
+			"status":      DisbursementPending,
+		})
+	}))
+	defer server.Close()
+
+	xendit := NewXenditDisbursementProvider(server.URL, "secret", server.Client(), withdrawSchema(t))
+	disbursement, errCreate := xendit.CreateDisbursement(DisbursementRequest{
+		ReferenceID: "withdraw-1",
+		Amount:      50000,
+		Currency:    "IDR",
+		Destination: Destination{ChannelCode: "BCA", AccountNumber: "123", AccountHolderName: "Jane"},
+	})
+	if errCreate != nil {
+		t.Fatalf("CreateDisbursement: %v", errCreate)
+	}
+
+	if received.BankCode != "BCA" || received.AccountNumber != "123" || received.AccountHolderName != "Jane" {
+		t.Errorf("unexpected destination sent: %+v", received)
+	}
+	if disbursement.ID() != "disb-1" || disbursement.Reference() != "withdraw-1" || disbursement.Status() != DisbursementPending {
+		t.Errorf("unexpected disbursement id=%q reference=%q status=%q", disbursement.ID(), disbursement.Reference(),
+			disbursement.Status())
+	}
+	if disbursement.Amount() != 50000 || disbursement.Currency() != "IDR" {
+		t.Errorf("unexpected amount %d %s", disbursement.Amount(), disbursement.Currency())
+	}
+}
+
+func TestXenditDisbursementErrorResponses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	xendit := NewXenditDisbursementProvider(server.URL, "secret", server.Client(), withdrawSchema(t))
+	_, errCreate := xendit.CreateDisbursement(DisbursementRequest{ReferenceID: "withdraw-2", Amount: 1})
+	if !errors.Is(errCreate, DisbursementRejected) {
+		t.Errorf("create error = %v, want %v", errCreate, DisbursementRejected)
+	}
+	_, errGet := xendit.GetDisbursement("disb-2")
+	if !errors.Is(errGet, DisbursementNotFound) {
+		t.Errorf("get error = %v, want %v", errGet, DisbursementNotFound)
+	}
+}
@@ -0,0 +1,25 @@
+package reconciliation
+
+import "errors"
+
+type Kind string
+
+const (
+	KindPayment  Kind = "payment"
+	KindWithdraw Kind = "withdraw"
+)
+
+type Result string
+
+const (
+	ResultMatched           Result = "matched"
+	ResultAmountMismatch    Result = "amount_mismatch"
+	ResultStatusMismatch    Result = "status_mismatch"
+	ResultMissingInPaystore Result = "missing_in_paystore"
+	ResultMissingAtVendor   Result = "missing_at_vendor"
+)
+
+var ReportNotFound = errors.New("Reconciliation report not found")
+var VendorTableRequired = errors.New("Vendor table is required for reconciliation")
+var InvalidPeriod = errors.New("Period end must be after period start")
+var UnsupportedKind = errors.New("Unsupported reconciliation kind")
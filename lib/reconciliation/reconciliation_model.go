@@ -0,0 +1,150 @@
+package reconciliation
+
+import (
+	"github.com/21strive/redifu"
+	"time"
+)
+
+type Report struct {
+	*redifu.Record
+	Kind                   Kind      `json:"kind"`
+	PeriodStart            time.Time `json:"periodStart"`
+	PeriodEnd              time.Time `json:"periodEnd"`
+	MatchedCount           int64     `json:"matchedCount"`
+	AmountMismatchCount    int64     `json:"amountMismatchCount"`
+	StatusMismatchCount    int64     `json:"statusMismatchCount"`
+	MissingInPaystoreCount int64     `json:"missingInPaystoreCount"`
+	MissingAtVendorCount   int64     `json:"missingAtVendorCount"`
+	AutoFinalizedCount     int64     `json:"autoFinalizedCount"`
+	Items                  []*Item   `json:"items,omitempty"`
+}
+
+// Item is one comparison between a paystore row and the vendor row it should match.
+// Statuses are stored in paystore terms; the vendor's raw status is kept for review.
+type Item struct {
+	*redifu.Record
+	ReportUUID        string `json:"reportUUID"`
+	Result            Result `json:"result"`
+	RecordUUID        string `json:"recordUUID"`
+	BalanceUUID       string `json:"balanceUUID"`
+	VendorRecordID    string `json:"vendorRecordID"`
+	PaystoreAmount    int64  `json:"paystoreAmount"`
+	VendorAmount      int64  `json:"vendorAmount"`
+	PaystoreStatus    string `json:"paystoreStatus"`
+	VendorStatus      string `json:"vendorStatus"`
+	VendorRawStatus   string `json:"vendorRawStatus"`
+	PaystoreCurrency  string `json:"paystoreCurrency"`
+	VendorCurrency    string `json:"vendorCurrency"`
+	VendorFailureCode string `json:"vendorFailureCode,omitempty"`
+	AutoFinalized     bool   `json:"autoFinalized"`
+}
+
+func (i *Item) Classify() {
+	switch {
+	case i.RecordUUID == "":
+		i.Result = ResultMissingInPaystore
+	case i.VendorRecordID == "":
+		i.Result = ResultMissingAtVendor
+	case i.PaystoreAmount != i.VendorAmount || i.PaystoreCurrency != i.VendorCurrency:
+		i.Result = ResultAmountMismatch
+	case i.PaystoreStatus != i.VendorStatus:
+		i.Result = ResultStatusMismatch
+	default:
+		i.Result = ResultMatched
+	}
+}
+
+// IsSafeToFinalize reports whether paystore is only behind the vendor: the row is still
+// pending, the vendor reached a final status, and amount and currency agree.
+func (i *Item) IsSafeToFinalize(pendingStatus string) bool {
+	return i.Result == ResultStatusMismatch && i.PaystoreStatus == pendingStatus && i.VendorStatus != pendingStatus &&
+		i.VendorStatus != ""
+}
+
+func (i *Item) SetAutoFinalized() {
+	i.AutoFinalized = true
+}
+
+func (i *Item) ScanDestinations() []interface{} {
+	return []interface{}{
+		&i.UUID,
+		&i.RandId,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ReportUUID,
+		&i.Result,
+		&i.RecordUUID,
+		&i.BalanceUUID,
+		&i.VendorRecordID,
+		&i.PaystoreAmount,
+		&i.VendorAmount,
+		&i.PaystoreStatus,
+		&i.VendorStatus,
+		&i.VendorRawStatus,
+		&i.PaystoreCurrency,
+		&i.VendorCurrency,
+		&i.VendorFailureCode,
+		&i.AutoFinalized,
+	}
+}
+
+func (r *Report) AddItem(item *Item) {
+	item.ReportUUID = r.GetUUID()
+	r.Items = append(r.Items, item)
+}
+
+func (r *Report) Tally() {
+	r.MatchedCount, r.AmountMismatchCount, r.StatusMismatchCount = 0, 0, 0
+	r.MissingInPaystoreCount, r.MissingAtVendorCount, r.AutoFinalizedCount = 0, 0, 0
+
+	for _, item := range r.Items {
+		switch item.Result {
+		case ResultMatched:
+			r.MatchedCount++
+		case ResultAmountMismatch:
+			r.AmountMismatchCount++
+		case ResultStatusMismatch:
+			r.StatusMismatchCount++
+		case ResultMissingInPaystore:
+			r.MissingInPaystoreCount++
+		case ResultMissingAtVendor:
+			r.MissingAtVendorCount++
+		}
+		if item.AutoFinalized {
+			r.AutoFinalizedCount++
+		}
+	}
+}
+
+func (r *Report) ScanDestinations() []interface{} {
+	return []interface{}{
+		&r.UUID,
+		&r.RandId,
+		&r.CreatedAt,
+		&r.UpdatedAt,
+		&r.Kind,
+		&r.PeriodStart,
+		&r.PeriodEnd,
+		&r.MatchedCount,
+		&r.AmountMismatchCount,
+		&r.StatusMismatchCount,
+		&r.MissingInPaystoreCount,
+		&r.MissingAtVendorCount,
+		&r.AutoFinalizedCount,
+	}
+}
+
+func NewReport(kind Kind, periodStart time.Time, periodEnd time.Time) *Report {
+	report := &Report{}
+	redifu.InitRecord(report)
+	report.Kind = kind
+	report.PeriodStart = periodStart
+	report.PeriodEnd = periodEnd
+	return report
+}
+
+func NewItem() *Item {
+	item := &Item{}
+	redifu.InitRecord(item)
+	return item
+}
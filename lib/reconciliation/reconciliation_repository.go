@@ -0,0 +1,261 @@
+package reconciliation
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/21strive/redifu"
+	"github.com/redis/go-redis/v9"
+	"paystore/config"
+	"paystore/lib/payment"
+	"paystore/lib/schema"
+	"paystore/lib/withdraw"
+	"strings"
+	"time"
+)
+
+var createReportQuery = `
+	INSERT INTO reconciliation_report (uuid, randid, created_at, updated_at, kind, period_start, period_end,
+		matched_count, amount_mismatch_count, status_mismatch_count, missing_in_paystore_count,
+		missing_at_vendor_count, auto_finalized_count)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`
+var createItemQuery = `
+	INSERT INTO reconciliation_item (uuid, randid, created_at, updated_at, report_uuid, result, record_uuid,
+		balance_uuid, vendor_record_id, paystore_amount, vendor_amount, paystore_status, vendor_status,
+		vendor_raw_status, paystore_currency, vendor_currency, vendor_failure_code, auto_finalized)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)`
+var findReportByUUIDQuery = `
+	SELECT uuid, randid, created_at, updated_at, kind, period_start, period_end, matched_count,
+		amount_mismatch_count, status_mismatch_count, missing_in_paystore_count, missing_at_vendor_count,
+		auto_finalized_count
+	FROM reconciliation_report WHERE uuid = $1`
+var findItemsQuery = `
+	SELECT uuid, randid, created_at, updated_at, report_uuid, result, record_uuid, balance_uuid, vendor_record_id,
+		paystore_amount, vendor_amount, paystore_status, vendor_status, vendor_raw_status, paystore_currency,
+		vendor_currency, vendor_failure_code, auto_finalized
+	FROM reconciliation_item WHERE report_uuid = $1 AND ($2 = '' OR result = $2) ORDER BY created_at, uuid`
+
+// Both comparison queries pair each paystore row in the period with the vendor row that
+// references it, and add vendor rows in the period that reference no paystore row at all.
+// The vendor side is rendered by vendorSelect from the columns each stored vendor maps.
+// comparePaymentQuery matches a split payment's invoice against its parent payment, whose amount
+// includes the gross amount of every share.
+var comparePaymentQuery = `
+	WITH p AS (
+		SELECT p.uuid, p.balance_uuid, p.amount + p.fees + COALESCE((
+			SELECT SUM(s.amount + s.fees) FROM payment s WHERE s.parent_payment_uuid = p.uuid
+		), 0) AS amount, p.status, b.currency
+		FROM payment p JOIN balance b ON b.uuid = p.balance_uuid
+		WHERE p.created_at >= $1 AND p.created_at < $2 AND p.parent_payment_uuid = ''
+	), v AS (
+		%[1]s
+		WHERE reference IN (SELECT uuid FROM p) OR (created >= $1 AND created < $2)
+	)
+	SELECT COALESCE(p.uuid, ''), COALESCE(p.balance_uuid, ''), COALESCE(v.id, ''), COALESCE(p.amount, 0),
+		COALESCE(v.amount, 0), COALESCE(p.status, ''), COALESCE(v.status, ''), COALESCE(p.currency, ''),
+		COALESCE(v.currency, ''), COALESCE(v.failure_code, '')
+	FROM p FULL OUTER JOIN v ON v.reference = p.uuid
+	WHERE p.uuid IS NOT NULL OR NOT EXISTS (SELECT 1 FROM payment x WHERE x.uuid = v.reference)`
+var compareWithdrawQuery = `
+	WITH w AS (
+		SELECT w.uuid, w.balance_uuid, w.amount, w.status, b.currency
+		FROM withdraw w JOIN balance b ON b.uuid = w.balance_uuid
+		WHERE w.created_at >= $1 AND w.created_at < $2
+	), v AS (
+		%[1]s
+		WHERE reference IN (SELECT uuid FROM w) OR (created >= $1 AND created < $2)
+	)
+	SELECT COALESCE(w.uuid, ''), COALESCE(w.balance_uuid, ''), COALESCE(v.id, ''), COALESCE(w.amount, 0),
+		COALESCE(v.amount, 0), COALESCE(w.status, ''), COALESCE(v.status, ''), COALESCE(w.currency, ''),
+		COALESCE(v.currency, ''), COALESCE(v.failure_code, '')
+	FROM w FULL OUTER JOIN v ON v.reference = w.uuid
+	WHERE w.uuid IS NOT NULL OR NOT EXISTS (SELECT 1 FROM withdraw x WHERE x.uuid = v.reference)`
+
+type RepositoryClient interface {
+	Compare(kind Kind, periodStart time.Time, periodEnd time.Time) (*Report, error)
+	Create(tx *sql.Tx, report *Report) error
+	FindByUUID(uuid string) (*Report, error)
+	FindItems(reportUUID string, result Result) ([]*Item, error)
+}
+
+type Repository struct {
+	readDB               *sql.DB
+	base                 *redifu.Base[*Report]
+	AppConfig            *config.App
+	findReportByUUIDStmt *sql.Stmt
+	findItemsStmt        *sql.Stmt
+	comparePaymentQuery  string
+	compareWithdrawQuery string
+}
+
+func (r *Repository) Compare(kind Kind, periodStart time.Time, periodEnd time.Time) (*Report, error) {
+	if !periodEnd.After(periodStart) {
+		return nil, InvalidPeriod
+	}
+
+	var query string
+	switch kind {
+	case KindPayment:
+		query = r.comparePaymentQuery
+	case KindWithdraw:
+		query = r.compareWithdrawQuery
+	default:
+		return nil, UnsupportedKind
+	}
+	if query == "" {
+		return nil, VendorTableRequired
+	}
+
+	rows, errQuery := r.readDB.Query(query, periodStart, periodEnd)
+	if errQuery != nil {
+		return nil, errQuery
+	}
+	defer rows.Close()
+
+	report := NewReport(kind, periodStart, periodEnd)
+	for rows.Next() {
+		item := NewItem()
+		errScan := rows.Scan(&item.RecordUUID, &item.BalanceUUID, &item.VendorRecordID, &item.PaystoreAmount,
+			&item.VendorAmount, &item.PaystoreStatus, &item.VendorRawStatus, &item.PaystoreCurrency,
+			&item.VendorCurrency, &item.VendorFailureCode)
+		if errScan != nil {
+			return nil, errScan
+		}
+
+		if kind == KindPayment {
+			item.VendorStatus = string(payment.VendorStatuses[item.VendorRawStatus])
+		} else {
+			item.VendorStatus = string(withdraw.VendorStatuses[item.VendorRawStatus])
+		}
+		item.Classify()
+		report.AddItem(item)
+	}
+	if errRows := rows.Err(); errRows != nil {
+		return nil, errRows
+	}
+
+	report.Tally()
+	return report, nil
+}
+
+func (r *Repository) Create(tx *sql.Tx, report *Report) error {
+	_, errExec := tx.Exec(createReportQuery, report.GetUUID(), report.GetRandId(), report.GetCreatedAt(),
+		report.GetUpdatedAt(), report.Kind, report.PeriodStart, report.PeriodEnd, report.MatchedCount,
+		report.AmountMismatchCount, report.StatusMismatchCount, report.MissingInPaystoreCount,
+		report.MissingAtVendorCount, report.AutoFinalizedCount)
+	if errExec != nil {
+		return errExec
+	}
+
+	for _, item := range report.Items {
+		_, errExec = tx.Exec(createItemQuery, item.GetUUID(), item.GetRandId(), item.GetCreatedAt(),
+			item.GetUpdatedAt(), item.ReportUUID, item.Result, item.RecordUUID, item.BalanceUUID,
+			item.VendorRecordID, item.PaystoreAmount, item.VendorAmount, item.PaystoreStatus, item.VendorStatus,
+			item.VendorRawStatus, item.PaystoreCurrency, item.VendorCurrency, item.VendorFailureCode,
+			item.AutoFinalized)
+		if errExec != nil {
+			return errExec
+		}
+	}
+
+	summary := *report
+	summary.Items = nil
+	return r.base.Set(&summary)
+}
+
+func (r *Repository) FindByUUID(uuid string) (*Report, error) {
+	report := NewReport("", time.Time{}, time.Time{})
+	errScan := r.findReportByUUIDStmt.QueryRow(uuid).Scan(report.ScanDestinations()...)
+	if errScan != nil {
+		if errScan == sql.ErrNoRows {
+			return nil, ReportNotFound
+		}
+		return nil, errScan
+	}
+
+	return report, nil
+}
+
+func (r *Repository) FindItems(reportUUID string, result Result) ([]*Item, error) {
+	rows, errQuery := r.findItemsStmt.Query(reportUUID, result)
+	if errQuery != nil {
+		return nil, errQuery
+	}
+	defer rows.Close()
+
+	var items []*Item
+	for rows.Next() {
+		item := NewItem()
+		errScan := rows.Scan(item.ScanDestinations()...)
+		if errScan != nil {
+			return nil, errScan
+		}
+		items = append(items, item)
+	}
+	if errRows := rows.Err(); errRows != nil {
+		return nil, errRows
+	}
+
+	return items, nil
+}
+
+func NewRepository(readDB *sql.DB, redis redis.UniversalClient, config *config.App) *Repository {
+	base := redifu.NewBase[*Report](redis, "reconciliation:%s", config.RecordAge)
+
+	findReportByUUIDStmt, err := readDB.Prepare(findReportByUUIDQuery)
+	if err != nil {
+		panic(err)
+	}
+	findItemsStmt, err := readDB.Prepare(findItemsQuery)
+	if err != nil {
+		panic(err)
+	}
+
+	var comparePayment, compareWithdraw string
+	if paymentVendors := config.StoredPaymentVendors(); len(paymentVendors) > 0 {
+		comparePayment = fmt.Sprintf(comparePaymentQuery, vendorSelect(paymentVendors))
+	}
+	if withdrawVendors := config.StoredWithdrawVendors(); len(withdrawVendors) > 0 {
+		compareWithdraw = fmt.Sprintf(compareWithdrawQuery, vendorSelect(withdrawVendors))
+	}
+
+	return &Repository{
+		readDB:               readDB,
+		base:                 base,
+		AppConfig:            config,
+		findReportByUUIDStmt: findReportByUUIDStmt,
+		findItemsStmt:        findItemsStmt,
+		comparePaymentQuery:  comparePayment,
+		compareWithdrawQuery: compareWithdraw,
+	}
+}
+
+// vendorSelect renders the vendor rows of a comparison, across all vendor tables, with the
+// columns the comparison reads under fixed names.
+func vendorSelect(vendors []*config.Vendor) string {
+	var selects []string
+	for _, vendor := range vendors {
+		selects = append(selects, vendorTableSelect(vendor.TableName, vendor.Schema))
+	}
+	return "SELECT * FROM (" + strings.Join(selects, " UNION ALL ") + ") vendor_row"
+}
+
+// vendorTableSelect reads one vendor table. Amounts are rounded to the smallest currency unit,
+// and the paid amount wins over the invoiced amount when the schema maps one and the vendor
+// reported it.
+func vendorTableSelect(tableName string, vendorSchema *schema.Schema) string {
+	amount := "ROUND(" + vendorSchema.RoleColumn(schema.RoleAmount) + ")::BIGINT"
+	if paidAmount := vendorSchema.RoleColumn(schema.RolePaidAmount); paidAmount != "" {
+		amount = "COALESCE(NULLIF(ROUND(" + paidAmount + ")::BIGINT, 0), " + amount + ")"
+	}
+	failureCode := "''"
+	if column := vendorSchema.RoleColumn(schema.RoleFailureCode); column != "" {
+		failureCode = column
+	}
+	created := vendorSchema.RoleColumn(schema.RoleCreated)
+
+	return "SELECT " + vendorSchema.RoleColumn(schema.RoleID) + " AS id, " +
+		vendorSchema.RoleColumn(schema.RoleReference) + " AS reference, " + amount + " AS amount, " +
+		vendorSchema.RoleColumn(schema.RoleStatus) + " AS status, " +
+		vendorSchema.RoleColumn(schema.RoleCurrency) + " AS currency, " + failureCode + " AS failure_code, " +
+		created + " AS created FROM " + tableName
+}
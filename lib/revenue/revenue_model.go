@@ -0,0 +1,45 @@
+package revenue
+
+import (
+	"github.com/21strive/redifu"
+	"paystore/lib/balance"
+)
+
+// Source is what a fee was charged on.
+type Source string
+
+const (
+	SourcePayment  Source = "payment"
+	SourceWithdraw Source = "withdraw"
+)
+
+// Entry is one fee credited to an organization's revenue balance, kept as the balance history.
+// SourceUUID is the payment or withdraw the fee was charged on.
+type Entry struct {
+	*redifu.Record
+	BalanceUUID      string `json:"balanceUUID"`
+	OrganizationUUID string `json:"organizationUUID"`
+	Source           Source `json:"source"`
+	SourceUUID       string `json:"sourceUUID"`
+	Amount           int64  `json:"amount"`
+	BalanceBefore    int64  `json:"balanceBefore"`
+	BalanceAfter     int64  `json:"balanceAfter"`
+}
+
+// Credit collects amount into the revenue balance and records the movement.
+func (e *Entry) Credit(revenueBalance *balance.Balance, amount int64) {
+	e.BalanceUUID = revenueBalance.GetUUID()
+	e.OrganizationUUID = revenueBalance.OrganizationUUID
+	e.Amount = amount
+	e.BalanceBefore = revenueBalance.Balance
+	revenueBalance.Collect(amount)
+	e.BalanceAfter = revenueBalance.Balance
+}
+
+func NewEntry(source Source, sourceUUID string) *Entry {
+	entry := &Entry{}
+	redifu.InitRecord(entry)
+	entry.Source = source
+	entry.SourceUUID = sourceUUID
+	return entry
+}
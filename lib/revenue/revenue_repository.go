@@ -0,0 +1,23 @@
+package revenue
+
+import "database/sql"
+
+var createEntryQuery = `INSERT INTO fee_revenue (uuid, randid, created_at, updated_at, balance_uuid, organization_uuid,
+	source, source_uuid, amount, balance_before, balance_after) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+
+type RepositoryClient interface {
+	Create(tx *sql.Tx, entry *Entry) error
+}
+
+type Repository struct{}
+
+func (r *Repository) Create(tx *sql.Tx, entry *Entry) error {
+	_, errExec := tx.Exec(createEntryQuery, entry.GetUUID(), entry.GetRandId(), entry.GetCreatedAt(),
+		entry.GetUpdatedAt(), entry.BalanceUUID, entry.OrganizationUUID, entry.Source, entry.SourceUUID,
+		entry.Amount, entry.BalanceBefore, entry.BalanceAfter)
+	return errExec
+}
+
+func NewRepository() *Repository {
+	return &Repository{}
+}
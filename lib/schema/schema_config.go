@@ -0,0 +1,65 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Schemas is the vendor schema file: the payment vendors and the withdraw vendors of the
+// deployment. The first vendor of each list is the default one, used for records that do
+// not name a vendor.
+type Schemas struct {
+	Payment  []*Schema `json:"payment"`
+	Withdraw []*Schema `json:"withdraw"`
+}
+
+// Parse decodes and validates a vendor schema file.
+func Parse(data []byte) (*Schemas, error) {
+	var schemas Schemas
+	errDecode := json.Unmarshal(data, &schemas)
+	if errDecode != nil {
+		return nil, errDecode
+	}
+
+	errPayment := validateAll(schemas.Payment)
+	if errPayment != nil {
+		return nil, fmt.Errorf("payment vendor schema: %w", errPayment)
+	}
+	errWithdraw := validateAll(schemas.Withdraw)
+	if errWithdraw != nil {
+		return nil, fmt.Errorf("withdraw vendor schema: %w", errWithdraw)
+	}
+
+	return &schemas, nil
+}
+
+func validateAll(vendorSchemas []*Schema) error {
+	if len(vendorSchemas) == 0 {
+		return SchemaRequired
+	}
+
+	codes := make(map[string]bool)
+	for _, vendorSchema := range vendorSchemas {
+		errValidate := vendorSchema.Validate()
+		if errValidate != nil {
+			if vendorSchema != nil && vendorSchema.Code != "" {
+				return fmt.Errorf("%s: %w", vendorSchema.Code, errValidate)
+			}
+			return errValidate
+		}
+		if codes[vendorSchema.Code] {
+			return fmt.Errorf("%w: %s", DuplicateVendorCode, vendorSchema.Code)
+		}
+		codes[vendorSchema.Code] = true
+	}
+	return nil
+}
+
+func Load(path string) (*Schemas, error) {
+	data, errRead := os.ReadFile(path)
+	if errRead != nil {
+		return nil, errRead
+	}
+	return Parse(data)
+}
@@ -0,0 +1,78 @@
+package schema
+
+import "errors"
+
+// ColumnType is the type of a vendor column. It decides both the Postgres type of the
+// column and how webhook values and scanned rows are converted.
+type ColumnType string
+
+const (
+	TypeText      ColumnType = "text"
+	TypeInteger   ColumnType = "integer"
+	TypeDecimal   ColumnType = "decimal"
+	TypeBoolean   ColumnType = "boolean"
+	TypeTimestamp ColumnType = "timestamp"
+	TypeTextArray ColumnType = "text[]"
+)
+
+var sqlTypes = map[ColumnType]string{
+	TypeText:      "TEXT",
+	TypeInteger:   "BIGINT",
+	TypeDecimal:   "DOUBLE PRECISION",
+	TypeBoolean:   "BOOL",
+	TypeTimestamp: "TIMESTAMP WITH TIME ZONE",
+	TypeTextArray: "TEXT[]",
+}
+
+// Role marks the column paystore itself reads. Columns without a role are stored and
+// returned as-is but never interpreted.
+type Role string
+
+const (
+	// RoleID is the vendor's own identifier; vendor rows are upserted and joined on it.
+	RoleID Role = "id"
+	// RoleReference holds the paystore payment or withdraw UUID the vendor record refers to.
+	RoleReference   Role = "reference"
+	RoleStatus      Role = "status"
+	RoleAmount      Role = "amount"
+	RolePaidAmount  Role = "paid_amount"
+	RoleCurrency    Role = "currency"
+	RoleCreated     Role = "created"
+	RoleExpiry      Role = "expiry"
+	RoleFailureCode Role = "failure_code"
+	RoleInvoiceURL  Role = "invoice_url"
+)
+
+// RequiredRoles must be mapped by every schema.
+var RequiredRoles = []Role{RoleID, RoleReference, RoleStatus, RoleAmount, RoleCurrency, RoleCreated}
+
+// roleTypes lists the column types each role can be read from.
+var roleTypes = map[Role][]ColumnType{
+	RoleID:          {TypeText},
+	RoleReference:   {TypeText},
+	RoleStatus:      {TypeText},
+	RoleAmount:      {TypeInteger, TypeDecimal},
+	RolePaidAmount:  {TypeInteger, TypeDecimal},
+	RoleCurrency:    {TypeText},
+	RoleCreated:     {TypeTimestamp},
+	RoleExpiry:      {TypeTimestamp},
+	RoleFailureCode: {TypeText},
+	RoleInvoiceURL:  {TypeText},
+}
+
+// recordColumns are the redifu.Record columns every vendor table starts with.
+var recordColumns = []string{"uuid", "randid", "created_at", "updated_at"}
+
+var SchemaRequired = errors.New("Vendor schema is required")
+var InvalidVendorCode = errors.New("Vendor code must be a lowercase identifier")
+var DuplicateVendorCode = errors.New("Vendor code is defined more than once")
+var InvalidTableName = errors.New("Vendor table name must be a lowercase SQL identifier")
+var InvalidColumnName = errors.New("Vendor column name must be a lowercase SQL identifier")
+var ReservedColumn = errors.New("Vendor column name is reserved")
+var DuplicateColumn = errors.New("Vendor column is defined more than once")
+var UnknownColumnType = errors.New("Unknown vendor column type")
+var UnknownRole = errors.New("Unknown vendor column role")
+var DuplicateRole = errors.New("Vendor column role is mapped more than once")
+var MissingRole = errors.New("Vendor schema does not map a required role")
+var RoleTypeMismatch = errors.New("Vendor column type cannot hold its role")
+var UnconvertibleValue = errors.New("Vendor value cannot be converted to the column type")
@@ -0,0 +1,507 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/21strive/item"
+	"github.com/21strive/redifu"
+	"github.com/lib/pq"
+	"math"
+	"paystore/lib/helper"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var columnNamePattern = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+var vendorCodePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// Column maps one vendor table column to a value in the vendor's JSON payload. Path is a
+// dot separated list of object keys, e.g. "customer.email".
+type Column struct {
+	Name string     `json:"name"`
+	Type ColumnType `json:"type"`
+	Path string     `json:"path"`
+	Role Role       `json:"role,omitempty"`
+}
+
+func (c Column) keys() []string {
+	path := c.Path
+	if path == "" {
+		path = c.Name
+	}
+	return strings.Split(path, ".")
+}
+
+// Schema describes the table of one vendor, identified by its vendor code. Column order is
+// the table's column order after the record columns, and the order values are scanned and
+// written in. Table is optional; see config.App for how a missing table name is resolved.
+type Schema struct {
+	Code    string   `json:"code"`
+	Table   string   `json:"table,omitempty"`
+	Columns []Column `json:"columns"`
+	roles   map[Role]int
+	index   map[string]int
+}
+
+// Validate checks the column definitions and indexes the roles. A schema must be validated
+// before it is used.
+func (s *Schema) Validate() error {
+	if s == nil || len(s.Columns) == 0 {
+		return SchemaRequired
+	}
+	if !vendorCodePattern.MatchString(s.Code) {
+		return fmt.Errorf("%w: %q", InvalidVendorCode, s.Code)
+	}
+	if s.Table != "" && !columnNamePattern.MatchString(s.Table) {
+		return fmt.Errorf("%w: %q", InvalidTableName, s.Table)
+	}
+
+	reserved := make(map[string]bool)
+	for _, name := range recordColumns {
+		reserved[name] = true
+	}
+
+	roles := make(map[Role]int)
+	index := make(map[string]int)
+	for i, column := range s.Columns {
+		if !columnNamePattern.MatchString(column.Name) {
+			return fmt.Errorf("%w: %q", InvalidColumnName, column.Name)
+		}
+		if reserved[column.Name] {
+			return fmt.Errorf("%w: %s", ReservedColumn, column.Name)
+		}
+		if _, found := index[column.Name]; found {
+			return fmt.Errorf("%w: %s", DuplicateColumn, column.Name)
+		}
+		if _, known := sqlTypes[column.Type]; !known {
+			return fmt.Errorf("%w: %s has type %q", UnknownColumnType, column.Name, column.Type)
+		}
+		index[column.Name] = i
+
+		if column.Role == "" {
+			continue
+		}
+		allowed, known := roleTypes[column.Role]
+		if !known {
+			return fmt.Errorf("%w: %s has role %q", UnknownRole, column.Name, column.Role)
+		}
+		if _, found := roles[column.Role]; found {
+			return fmt.Errorf("%w: %s", DuplicateRole, column.Role)
+		}
+		if !hasType(allowed, column.Type) {
+			return fmt.Errorf("%w: %s is %s but %s needs one of %v", RoleTypeMismatch, column.Name,
+				column.Type, column.Role, allowed)
+		}
+		roles[column.Role] = i
+	}
+
+	for _, role := range RequiredRoles {
+		if _, found := roles[role]; !found {
+			return fmt.Errorf("%w: %s", MissingRole, role)
+		}
+	}
+
+	s.roles = roles
+	s.index = index
+	return nil
+}
+
+// ColumnNames lists every column of the vendor table, record columns first.
+func (s *Schema) ColumnNames() []string {
+	names := append([]string{}, recordColumns...)
+	for _, column := range s.Columns {
+		names = append(names, column.Name)
+	}
+	return names
+}
+
+func (s *Schema) ColumnDefinitions() []helper.ColumnDefinition {
+	definitions := []helper.ColumnDefinition{
+		{Name: "uuid", SQLType: "TEXT"},
+		{Name: "randid", SQLType: "TEXT"},
+		{Name: "created_at", SQLType: sqlTypes[TypeTimestamp]},
+		{Name: "updated_at", SQLType: sqlTypes[TypeTimestamp]},
+	}
+	for _, column := range s.Columns {
+		definitions = append(definitions, helper.ColumnDefinition{Name: column.Name, SQLType: sqlTypes[column.Type]})
+	}
+	return definitions
+}
+
+// RoleColumn returns the name of the column mapped to role, or an empty string when the
+// schema does not map it.
+func (s *Schema) RoleColumn(role Role) string {
+	i, found := s.roles[role]
+	if !found {
+		return ""
+	}
+	return s.Columns[i].Name
+}
+
+// Decode extracts every column from a vendor JSON payload. Missing or null values decode
+// to the zero value of the column type.
+func (s *Schema) Decode(body []byte) (*Record, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var document interface{}
+	errDecode := decoder.Decode(&document)
+	if errDecode != nil {
+		return nil, errDecode
+	}
+
+	record := s.NewRecord()
+	for _, column := range s.Columns {
+		value := lookup(document, column.keys())
+		if value == nil {
+			continue
+		}
+
+		converted, errConvert := convert(column.Type, value)
+		if errConvert != nil {
+			return nil, fmt.Errorf("%w: %s: %v", UnconvertibleValue, column.Path, errConvert)
+		}
+		record.Values[column.Name] = converted
+	}
+
+	return record, nil
+}
+
+func (s *Schema) NewRecord() *Record {
+	record := &Record{
+		VendorCode: s.Code,
+		Values:     make(map[string]interface{}, len(s.Columns)),
+		schema:     s,
+	}
+	redifu.InitRecord(record)
+	for _, column := range s.Columns {
+		record.Values[column.Name] = zero(column.Type)
+	}
+	return record
+}
+
+// Record is one vendor row. Values are keyed by column name and hold string, int64,
+// float64, bool, time.Time or []string depending on the column type.
+type Record struct {
+	*redifu.Record
+	VendorCode string                 `json:"vendorCode"`
+	Values     map[string]interface{} `json:"values"`
+	schema     *Schema
+}
+
+// ScanDestinations follows Schema.ColumnNames.
+func (r *Record) ScanDestinations() []interface{} {
+	destinations := []interface{}{
+		&r.UUID,
+		&r.RandId,
+		&r.CreatedAt,
+		&r.UpdatedAt,
+	}
+	for _, column := range r.schema.Columns {
+		destinations = append(destinations, &columnScanner{record: r, column: column})
+	}
+	return destinations
+}
+
+// QueryArgs renders the record as arguments in Schema.ColumnNames order.
+func (r *Record) QueryArgs() []interface{} {
+	args := []interface{}{r.UUID, r.RandId, r.CreatedAt, r.UpdatedAt}
+	for _, column := range r.schema.Columns {
+		value := r.Values[column.Name]
+		if list, ok := value.([]string); ok {
+			value = pq.Array(list)
+		}
+		args = append(args, value)
+	}
+	return args
+}
+
+// Set stores value in the named column. Columns the schema does not define are ignored,
+// which lets provider adapters fill optional columns without knowing the schema.
+func (r *Record) Set(column string, value interface{}) error {
+	i, found := r.schema.index[column]
+	if !found {
+		return nil
+	}
+
+	converted, errConvert := convert(r.schema.Columns[i].Type, value)
+	if errConvert != nil {
+		return fmt.Errorf("%w: %s: %v", UnconvertibleValue, column, errConvert)
+	}
+	r.Values[column] = converted
+	return nil
+}
+
+// SetRole stores value in the column mapped to role, if any.
+func (r *Record) SetRole(role Role, value interface{}) error {
+	return r.Set(r.schema.RoleColumn(role), value)
+}
+
+// SetValues stores several columns at once; see Set.
+func (r *Record) SetValues(values map[string]interface{}) error {
+	for column, value := range values {
+		errSet := r.Set(column, value)
+		if errSet != nil {
+			return errSet
+		}
+	}
+	return nil
+}
+
+// role reads the value of the column mapped to role. A nil record, or one read back from
+// the cache without its schema, reads as zero values.
+func (r *Record) role(role Role) interface{} {
+	if r == nil || r.schema == nil {
+		return nil
+	}
+	return r.Values[r.schema.RoleColumn(role)]
+}
+
+func (r *Record) text(role Role) string {
+	value, _ := r.role(role).(string)
+	return value
+}
+
+func (r *Record) amount(role Role) int64 {
+	switch value := r.role(role).(type) {
+	case int64:
+		return value
+	case float64:
+		return int64(math.Round(value))
+	}
+	return 0
+}
+
+func (r *Record) timestamp(role Role) time.Time {
+	value, _ := r.role(role).(time.Time)
+	return value
+}
+
+func (r *Record) ID() string          { return r.text(RoleID) }
+func (r *Record) Reference() string   { return r.text(RoleReference) }
+func (r *Record) Status() string      { return r.text(RoleStatus) }
+func (r *Record) Currency() string    { return r.text(RoleCurrency) }
+func (r *Record) FailureCode() string { return r.text(RoleFailureCode) }
+func (r *Record) InvoiceURL() string  { return r.text(RoleInvoiceURL) }
+func (r *Record) Amount() int64       { return r.amount(RoleAmount) }
+func (r *Record) PaidAmount() int64   { return r.amount(RolePaidAmount) }
+func (r *Record) Created() time.Time  { return r.timestamp(RoleCreated) }
+func (r *Record) Expiry() time.Time   { return r.timestamp(RoleExpiry) }
+
+// Collected is the amount the vendor reports collecting: the paid amount when the schema maps
+// one and the vendor reported it, and the invoiced amount otherwise.
+func (r *Record) Collected() int64 {
+	if paidAmount := r.PaidAmount(); paidAmount != 0 {
+		return paidAmount
+	}
+	return r.Amount()
+}
+
+// Clone copies the record so the copy can be changed independently. A record read back from
+// the cache without its schema is copied without one too.
+func (r *Record) Clone() *Record {
+	var record *Record
+	if r.schema != nil {
+		record = r.schema.NewRecord()
+	} else {
+		record = &Record{
+			Record: &redifu.Record{Foundation: &item.Foundation{}},
+			Values: make(map[string]interface{}, len(r.Values)),
+		}
+	}
+	record.VendorCode = r.VendorCode
+	record.UUID = r.UUID
+	record.RandId = r.RandId
+	record.CreatedAt = r.CreatedAt
+	record.UpdatedAt = r.UpdatedAt
+	for name, value := range r.Values {
+		if list, ok := value.([]string); ok {
+			value = append([]string{}, list...)
+		}
+		record.Values[name] = value
+	}
+	return record
+}
+
+// Bind attaches the schema to a record read back from the cache, which keeps the values but
+// not the schema, and converts the decoded values back to the column types.
+func (r *Record) Bind(s *Schema) error {
+	decoded := r.Values
+	r.schema = s
+	r.Values = make(map[string]interface{}, len(s.Columns))
+	for _, column := range s.Columns {
+		r.Values[column.Name] = zero(column.Type)
+	}
+	for column, value := range decoded {
+		if value == nil {
+			continue
+		}
+		errSet := r.Set(column, value)
+		if errSet != nil {
+			return errSet
+		}
+	}
+	return nil
+}
+
+// columnScanner converts a scanned column into the record's value for it. NULL, which
+// LEFT JOINed vendor rows produce, leaves the zero value in place.
+type columnScanner struct {
+	record *Record
+	column Column
+}
+
+func (c *columnScanner) Scan(src interface{}) error {
+	if src == nil {
+		return nil
+	}
+
+	if c.column.Type == TypeTextArray {
+		var list []string
+		errScan := pq.Array(&list).Scan(src)
+		if errScan != nil {
+			return errScan
+		}
+		c.record.Values[c.column.Name] = list
+		return nil
+	}
+	if raw, ok := src.([]byte); ok {
+		src = string(raw)
+	}
+
+	value, errConvert := convert(c.column.Type, src)
+	if errConvert != nil {
+		return fmt.Errorf("%w: %s: %v", UnconvertibleValue, c.column.Name, errConvert)
+	}
+	c.record.Values[c.column.Name] = value
+	return nil
+}
+
+func lookup(document interface{}, keys []string) interface{} {
+	current := document
+	for _, key := range keys {
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		current = object[key]
+	}
+	return current
+}
+
+func zero(columnType ColumnType) interface{} {
+	switch columnType {
+	case TypeInteger:
+		return int64(0)
+	case TypeDecimal:
+		return float64(0)
+	case TypeBoolean:
+		return false
+	case TypeTimestamp:
+		return time.Time{}
+	case TypeTextArray:
+		return []string{}
+	}
+	return ""
+}
+
+// convert coerces JSON, database and Go values into the canonical Go type of columnType.
+func convert(columnType ColumnType, value interface{}) (interface{}, error) {
+	switch columnType {
+	case TypeText:
+		switch typed := value.(type) {
+		case string:
+			return typed, nil
+		case json.Number:
+			return typed.String(), nil
+		case int64, float64, bool:
+			return fmt.Sprint(typed), nil
+		}
+	case TypeInteger:
+		switch typed := value.(type) {
+		case int64:
+			return typed, nil
+		case int:
+			return int64(typed), nil
+		case float64:
+			return int64(math.Round(typed)), nil
+		case json.Number:
+			return parseInteger(typed.String())
+		case string:
+			return parseInteger(typed)
+		}
+	case TypeDecimal:
+		switch typed := value.(type) {
+		case float64:
+			return typed, nil
+		case int64:
+			return float64(typed), nil
+		case int:
+			return float64(typed), nil
+		case json.Number:
+			return typed.Float64()
+		case string:
+			return strconv.ParseFloat(typed, 64)
+		}
+	case TypeBoolean:
+		switch typed := value.(type) {
+		case bool:
+			return typed, nil
+		case string:
+			return strconv.ParseBool(typed)
+		}
+	case TypeTimestamp:
+		switch typed := value.(type) {
+		case time.Time:
+			return typed, nil
+		case string:
+			if typed == "" {
+				return time.Time{}, nil
+			}
+			return time.Parse(time.RFC3339Nano, typed)
+		}
+	case TypeTextArray:
+		switch typed := value.(type) {
+		case []string:
+			return typed, nil
+		case []interface{}:
+			list := make([]string, 0, len(typed))
+			for _, entry := range typed {
+				text, errConvert := convert(TypeText, entry)
+				if errConvert != nil {
+					return nil, errConvert
+				}
+				list = append(list, text.(string))
+			}
+			return list, nil
+		}
+	}
+
+	return nil, fmt.Errorf("cannot convert %T to %s", value, columnType)
+}
+
+// parseInteger accepts amounts that vendors render with a fractional part, such as "10000.0".
+func parseInteger(text string) (int64, error) {
+	integer, errParse := strconv.ParseInt(text, 10, 64)
+	if errParse == nil {
+		return integer, nil
+	}
+
+	decimal, errFloat := strconv.ParseFloat(text, 64)
+	if errFloat != nil {
+		return 0, errParse
+	}
+	return int64(math.Round(decimal)), nil
+}
+
+func hasType(types []ColumnType, columnType ColumnType) bool {
+	for _, candidate := range types {
+		if candidate == columnType {
+			return true
+		}
+	}
+	return false
+}
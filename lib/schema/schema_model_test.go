@@ -0,0 +1,126 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func testSchema(t *testing.T) *Schema {
+	t.Helper()
+	vendorSchema := &Schema{
+		Code: "default",
+		Columns: []Column{
+			{Name: "id", Type: TypeText, Role: RoleID},
+			{Name: "external_id", Type: TypeText, Role: RoleReference},
+			{Name: "status", Type: TypeText, Role: RoleStatus},
+			{Name: "amount", Type: TypeInteger, Role: RoleAmount},
+			{Name: "currency", Type: TypeText, Role: RoleCurrency},
+			{Name: "created", Type: TypeTimestamp, Role: RoleCreated},
+			{Name: "email_to", Type: TypeTextArray},
+		},
+	}
+	if errValidate := vendorSchema.Validate(); errValidate != nil {
+		t.Fatalf("Validate: %v", errValidate)
+	}
+	return vendorSchema
+}
+
+// cached round-trips a record through JSON the way the cache stores it, which drops the schema.
+func cached(t *testing.T, record *Record) *Record {
+	t.Helper()
+	encoded, errEncode := json.Marshal(record)
+	if errEncode != nil {
+		t.Fatalf("encode: %v", errEncode)
+	}
+	var decoded Record
+	if errDecode := json.Unmarshal(encoded, &decoded); errDecode != nil {
+		t.Fatalf("decode: %v", errDecode)
+	}
+	return &decoded
+}
+
+func TestRecordBindAfterCache(t *testing.T) {
+	vendorSchema := testSchema(t)
+	created := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	record := vendorSchema.NewRecord()
+	errSet := record.SetValues(map[string]interface{}{
+		"id": "inv-1", "status": "PAID", "amount": int64(12500), "created": created,
+		"email_to": []string{"a@example.com"},
+	})
+	if errSet != nil {
+		t.Fatalf("SetValues: %v", errSet)
+	}
+
+	fromCache := cached(t, record)
+	if fromCache.ID() != "" {
+		t.Errorf("record without schema read ID %q", fromCache.ID())
+	}
+
+	if errBind := fromCache.Bind(vendorSchema); errBind != nil {
+		t.Fatalf("Bind: %v", errBind)
+	}
+	if fromCache.ID() != "inv-1" || fromCache.Status() != "PAID" || fromCache.Amount() != 12500 {
+		t.Errorf("unexpected values after Bind: %v", fromCache.Values)
+	}
+	if !fromCache.Created().Equal(created) {
+		t.Errorf("created = %v, want %v", fromCache.Created(), created)
+	}
+	if list, _ := fromCache.Values["email_to"].([]string); len(list) != 1 || list[0] != "a@example.com" {
+		t.Errorf("email_to = %#v", fromCache.Values["email_to"])
+	}
+}
+
+func TestRecordCloneWithoutSchema(t *testing.T) {
+	record := testSchema(t).NewRecord()
+	if errSet := record.Set("id", "inv-2"); errSet != nil {
+		t.Fatalf("Set: %v", errSet)
+	}
+
+	fromCache := cached(t, record)
+	clone := fromCache.Clone()
+	if clone.UUID != record.UUID || clone.Values["id"] != "inv-2" {
+		t.Errorf("clone lost values: %v %v", clone.UUID, clone.Values)
+	}
+
+	clone.Values["id"] = "changed"
+	if fromCache.Values["id"] != "inv-2" {
+		t.Errorf("clone shares values with the original")
+	}
+}
+
+func TestRecordCollected(t *testing.T) {
+	vendorSchema := &Schema{
+		Code: "default",
+		Columns: []Column{
+			{Name: "id", Type: TypeText, Role: RoleID},
+			{Name: "external_id", Type: TypeText, Role: RoleReference},
+			{Name: "status", Type: TypeText, Role: RoleStatus},
+			{Name: "amount", Type: TypeInteger, Role: RoleAmount},
+			{Name: "paid_amount", Type: TypeDecimal, Role: RolePaidAmount},
+			{Name: "currency", Type: TypeText, Role: RoleCurrency},
+			{Name: "created", Type: TypeTimestamp, Role: RoleCreated},
+		},
+	}
+	if errValidate := vendorSchema.Validate(); errValidate != nil {
+		t.Fatalf("Validate: %v", errValidate)
+	}
+
+	record := vendorSchema.NewRecord()
+	record.Set("amount", int64(10000))
+	if collected := record.Collected(); collected != 10000 {
+		t.Errorf("without a paid amount, collected %d, want the invoiced 10000", collected)
+	}
+	record.Set("paid_amount", 9999.6)
+	if collected := record.Collected(); collected != 10000 {
+		t.Errorf("collected %d, want the paid amount rounded to 10000", collected)
+	}
+	record.Set("paid_amount", float64(4000))
+	if collected := record.Collected(); collected != 4000 {
+		t.Errorf("collected %d, want the paid amount 4000", collected)
+	}
+
+	if collected := testSchema(t).NewRecord().Collected(); collected != 0 {
+		t.Errorf("empty record collected %d", collected)
+	}
+}
@@ -0,0 +1,20 @@
+package settlement
+
+import (
+	"errors"
+	"time"
+)
+
+// DayLayout is how settlement days are written in requests and reports.
+const DayLayout = "2006-01-02"
+
+var DayNotEnded = errors.New("Settlement day has not ended yet")
+var InvalidRange = errors.New("Settlement range end must not be before its start")
+var OrganizationRequired = errors.New("Organization is required")
+
+type Filter struct {
+	OrganizationUUID string
+	Currency         string
+	From             time.Time
+	To               time.Time
+}
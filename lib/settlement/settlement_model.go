@@ -0,0 +1,70 @@
+package settlement
+
+import (
+	"github.com/21strive/redifu"
+	"time"
+)
+
+// Report is the settlement of one organization in one currency over one UTC day. Payment
+// figures count payments paid or failed during the day and withdraw figures count withdraws
+// that succeeded or failed during the day, both gross of fees unless named otherwise.
+// ClosingLiability is what the organization's balances in the currency held when the day
+// ended: everything collected so far less everything withdrawn so far, fees included.
+type Report struct {
+	*redifu.Record
+	OrganizationUUID    string    `json:"organizationUUID"`
+	Currency            string    `json:"currency"`
+	Day                 time.Time `json:"day"`
+	GrossCollected      int64     `json:"grossCollected"`
+	Fees                int64     `json:"fees"`
+	NetCredited         int64     `json:"netCredited"`
+	PaidCount           int64     `json:"paidCount"`
+	FailedPaymentCount  int64     `json:"failedPaymentCount"`
+	Withdrawn           int64     `json:"withdrawn"`
+	WithdrawFees        int64     `json:"withdrawFees"`
+	WithdrawCount       int64     `json:"withdrawCount"`
+	FailedWithdrawCount int64     `json:"failedWithdrawCount"`
+	ClosingLiability    int64     `json:"closingLiability"`
+}
+
+// IsEmpty reports whether the organization had no activity during the day and held nothing
+// at its end, so there is nothing to report.
+func (r *Report) IsEmpty() bool {
+	return r.PaidCount == 0 && r.FailedPaymentCount == 0 && r.WithdrawCount == 0 && r.FailedWithdrawCount == 0 &&
+		r.ClosingLiability == 0
+}
+
+func (r *Report) ScanDestinations() []interface{} {
+	return []interface{}{
+		&r.UUID,
+		&r.RandId,
+		&r.CreatedAt,
+		&r.UpdatedAt,
+		&r.OrganizationUUID,
+		&r.Currency,
+		&r.Day,
+		&r.GrossCollected,
+		&r.Fees,
+		&r.NetCredited,
+		&r.PaidCount,
+		&r.FailedPaymentCount,
+		&r.Withdrawn,
+		&r.WithdrawFees,
+		&r.WithdrawCount,
+		&r.FailedWithdrawCount,
+		&r.ClosingLiability,
+	}
+}
+
+// Day returns the start of the UTC day at falls in.
+func Day(at time.Time) time.Time {
+	year, month, day := at.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func NewReport(day time.Time) *Report {
+	report := &Report{}
+	redifu.InitRecord(report)
+	report.Day = Day(day)
+	return report
+}
@@ -0,0 +1,177 @@
+package settlement
+
+import (
+	"database/sql"
+	"paystore/lib/builder"
+	"paystore/lib/payment"
+	"paystore/lib/withdraw"
+	"time"
+)
+
+// computeQuery settles the day [$1, $2) for every organization and currency. Payments and
+// withdraws are counted on the day they reached a final status, recorded in finalized_at, so
+// later updates of the row do not move them to another day; the running totals up to $2 give
+// the closing liability.
+var computeQuery = `
+	WITH p AS (
+		SELECT p.organization_uuid, b.currency,
+			COALESCE(SUM(p.amount + p.fees) FILTER (WHERE p.status = $3 AND p.finalized_at >= $1), 0) AS gross_collected,
+			COALESCE(SUM(p.fees) FILTER (WHERE p.status = $3 AND p.finalized_at >= $1), 0) AS fees,
+			COALESCE(SUM(p.amount) FILTER (WHERE p.status = $3 AND p.finalized_at >= $1), 0) AS net_credited,
+			COUNT(*) FILTER (WHERE p.status = $3 AND p.finalized_at >= $1) AS paid_count,
+			COUNT(*) FILTER (WHERE p.status = $4 AND p.finalized_at >= $1) AS failed_count,
+			COALESCE(SUM(p.amount + p.fees) FILTER (WHERE p.status = $3), 0) AS collected_to_date
+		FROM payment p JOIN balance b ON b.uuid = p.balance_uuid
+		WHERE p.status IN ($3, $4) AND p.finalized_at < $2
+		GROUP BY p.organization_uuid, b.currency
+	), w AS (
+		SELECT w.organization_uuid, b.currency,
+			COALESCE(SUM(w.amount) FILTER (WHERE w.status = $5 AND w.finalized_at >= $1), 0) AS withdrawn,
+			COALESCE(SUM(w.fees) FILTER (WHERE w.status = $5 AND w.finalized_at >= $1), 0) AS withdraw_fees,
+			COUNT(*) FILTER (WHERE w.status = $5 AND w.finalized_at >= $1) AS withdraw_count,
+			COUNT(*) FILTER (WHERE w.status = $6 AND w.finalized_at >= $1) AS failed_count,
+			COALESCE(SUM(w.amount + w.fees) FILTER (WHERE w.status = $5), 0) AS withdrawn_to_date
+		FROM withdraw w JOIN balance b ON b.uuid = w.balance_uuid
+		WHERE w.status IN ($5, $6) AND w.finalized_at < $2
+		GROUP BY w.organization_uuid, b.currency
+	)
+	SELECT COALESCE(p.organization_uuid, w.organization_uuid), COALESCE(p.currency, w.currency),
+		COALESCE(p.gross_collected, 0), COALESCE(p.fees, 0), COALESCE(p.net_credited, 0), COALESCE(p.paid_count, 0),
+		COALESCE(p.failed_count, 0), COALESCE(w.withdrawn, 0), COALESCE(w.withdraw_fees, 0),
+		COALESCE(w.withdraw_count, 0), COALESCE(w.failed_count, 0),
+		COALESCE(p.collected_to_date, 0) - COALESCE(w.withdrawn_to_date, 0)
+	FROM p FULL OUTER JOIN w ON w.organization_uuid = p.organization_uuid AND w.currency = p.currency
+	ORDER BY 1, 2`
+
+// upsertReportQuery keeps the UUID and creation time of a report that is recomputed.
+var upsertReportQuery = `
+	INSERT INTO settlement_report (uuid, randid, created_at, updated_at, organization_uuid, currency, day,
+		gross_collected, fees, net_credited, paid_count, failed_payment_count, withdrawn, withdraw_fees,
+		withdraw_count, failed_withdraw_count, closing_liability)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+	ON CONFLICT (organization_uuid, currency, day) DO UPDATE SET updated_at = EXCLUDED.updated_at,
+		gross_collected = EXCLUDED.gross_collected, fees = EXCLUDED.fees, net_credited = EXCLUDED.net_credited,
+		paid_count = EXCLUDED.paid_count, failed_payment_count = EXCLUDED.failed_payment_count,
+		withdrawn = EXCLUDED.withdrawn, withdraw_fees = EXCLUDED.withdraw_fees,
+		withdraw_count = EXCLUDED.withdraw_count, failed_withdraw_count = EXCLUDED.failed_withdraw_count,
+		closing_liability = EXCLUDED.closing_liability
+	RETURNING uuid, randid, created_at`
+var deleteStaleReportsQuery = `DELETE FROM settlement_report WHERE day = $1 AND updated_at < $2`
+var selectReportQuery = `
+	SELECT uuid, randid, created_at, updated_at, organization_uuid, currency, day, gross_collected, fees,
+		net_credited, paid_count, failed_payment_count, withdrawn, withdraw_fees, withdraw_count,
+		failed_withdraw_count, closing_liability
+	FROM settlement_report`
+
+type RepositoryClient interface {
+	Compute(tx *sql.Tx, day time.Time) ([]*Report, error)
+	Save(tx *sql.Tx, day time.Time, reports []*Report, computedAt time.Time) error
+	Find(filter Filter) ([]*Report, error)
+}
+
+type Repository struct {
+	readDB *sql.DB
+}
+
+// Compute settles the UTC day day falls in, without storing the reports. It reads through tx,
+// the transaction the reports are saved in, so they reflect the primary rather than a replica
+// that may lag behind it.
+func (r *Repository) Compute(tx *sql.Tx, day time.Time) ([]*Report, error) {
+	dayStart := Day(day)
+	rows, errQuery := tx.Query(computeQuery, dayStart, dayStart.AddDate(0, 0, 1), payment.PaymentStatusPaid,
+		payment.PaymentStatusFailed, withdraw.StatusSuccess, withdraw.StatusFailed)
+	if errQuery != nil {
+		return nil, errQuery
+	}
+	defer rows.Close()
+
+	var reports []*Report
+	for rows.Next() {
+		report := NewReport(dayStart)
+		errScan := rows.Scan(&report.OrganizationUUID, &report.Currency, &report.GrossCollected, &report.Fees,
+			&report.NetCredited, &report.PaidCount, &report.FailedPaymentCount, &report.Withdrawn,
+			&report.WithdrawFees, &report.WithdrawCount, &report.FailedWithdrawCount, &report.ClosingLiability)
+		if errScan != nil {
+			return nil, errScan
+		}
+		if report.IsEmpty() {
+			continue
+		}
+		reports = append(reports, report)
+	}
+	if errRows := rows.Err(); errRows != nil {
+		return nil, errRows
+	}
+
+	return reports, nil
+}
+
+// Save replaces the stored reports of day with reports. Reports already stored for the same
+// organization and currency are updated in place; those no longer computed are removed.
+func (r *Repository) Save(tx *sql.Tx, day time.Time, reports []*Report, computedAt time.Time) error {
+	for _, report := range reports {
+		report.SetUpdatedAt(computedAt)
+		errScan := tx.QueryRow(upsertReportQuery, report.GetUUID(), report.GetRandId(), report.GetCreatedAt(),
+			report.GetUpdatedAt(), report.OrganizationUUID, report.Currency, report.Day, report.GrossCollected,
+			report.Fees, report.NetCredited, report.PaidCount, report.FailedPaymentCount, report.Withdrawn,
+			report.WithdrawFees, report.WithdrawCount, report.FailedWithdrawCount,
+			report.ClosingLiability).Scan(&report.UUID, &report.RandId, &report.CreatedAt)
+		if errScan != nil {
+			return errScan
+		}
+	}
+
+	_, errExec := tx.Exec(deleteStaleReportsQuery, Day(day), computedAt)
+	return errExec
+}
+
+// Find returns the stored reports of an organization, oldest day first. A zero From or To
+// leaves that end of the range open; both ends are inclusive.
+func (r *Repository) Find(filter Filter) ([]*Report, error) {
+	if filter.OrganizationUUID == "" {
+		return nil, OrganizationRequired
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && filter.To.Before(filter.From) {
+		return nil, InvalidRange
+	}
+
+	filterBuilder := builder.NewFilterBuilder()
+	filterBuilder.Where("organization_uuid", "=", filter.OrganizationUUID)
+	if filter.Currency != "" {
+		filterBuilder.Where("currency", "=", filter.Currency)
+	}
+	if !filter.From.IsZero() {
+		filterBuilder.Where("day", ">=", Day(filter.From))
+	}
+	if !filter.To.IsZero() {
+		filterBuilder.Where("day", "<=", Day(filter.To))
+	}
+
+	rows, errQuery := r.readDB.Query(selectReportQuery+filterBuilder.Clause()+` ORDER BY day, currency`,
+		filterBuilder.Args()...)
+	if errQuery != nil {
+		return nil, errQuery
+	}
+	defer rows.Close()
+
+	var reports []*Report
+	for rows.Next() {
+		report := NewReport(time.Time{})
+		errScan := rows.Scan(report.ScanDestinations()...)
+		if errScan != nil {
+			return nil, errScan
+		}
+		reports = append(reports, report)
+	}
+	if errRows := rows.Err(); errRows != nil {
+		return nil, errRows
+	}
+
+	return reports, nil
+}
+
+func NewRepository(readDB *sql.DB) *Repository {
+	return &Repository{
+		readDB: readDB,
+	}
+}
@@ -0,0 +1,130 @@
+package settlement
+
+import (
+	"database/sql"
+	"os"
+	"paystore/lib/payment"
+	"paystore/lib/withdraw"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+)
+
+// settlementTables shadows the tables the settlement reads and writes with temporary ones,
+// holding only the columns it uses, so the test leaves the database as it found it.
+var settlementTables = `
+	CREATE TEMP TABLE balance (uuid VARCHAR(255) PRIMARY KEY, currency VARCHAR(3) NOT NULL) ON COMMIT DROP;
+	CREATE TEMP TABLE payment (
+		uuid VARCHAR(255) PRIMARY KEY, organization_uuid VARCHAR(255) NOT NULL,
+		balance_uuid VARCHAR(255) NOT NULL, amount BIGINT NOT NULL, fees BIGINT NOT NULL,
+		status VARCHAR(20) NOT NULL, updated_at TIMESTAMP NOT NULL, finalized_at TIMESTAMP
+	) ON COMMIT DROP;
+	CREATE TEMP TABLE withdraw (
+		uuid VARCHAR(255) PRIMARY KEY, organization_uuid VARCHAR(255) NOT NULL,
+		balance_uuid VARCHAR(255) NOT NULL, amount BIGINT NOT NULL, fees BIGINT NOT NULL,
+		status VARCHAR(20) NOT NULL, updated_at TIMESTAMP NOT NULL, finalized_at TIMESTAMP
+	) ON COMMIT DROP;
+	CREATE TEMP TABLE settlement_report (
+		uuid VARCHAR(255) PRIMARY KEY, randid VARCHAR(255) NOT NULL, created_at TIMESTAMP NOT NULL,
+		updated_at TIMESTAMP NOT NULL, organization_uuid VARCHAR(255) NOT NULL, currency VARCHAR(3) NOT NULL,
+		day DATE NOT NULL, gross_collected BIGINT NOT NULL, fees BIGINT NOT NULL, net_credited BIGINT NOT NULL,
+		paid_count BIGINT NOT NULL, failed_payment_count BIGINT NOT NULL, withdrawn BIGINT NOT NULL,
+		withdraw_fees BIGINT NOT NULL, withdraw_count BIGINT NOT NULL, failed_withdraw_count BIGINT NOT NULL,
+		closing_liability BIGINT NOT NULL, UNIQUE (organization_uuid, currency, day)
+	) ON COMMIT DROP;`
+
+// testTx opens a transaction on the Postgres at PAYSTORE_TEST_DATABASE_URL with the settlement
+// tables in place, skipping the test without one. The transaction is rolled back afterwards.
+func testTx(t *testing.T) *sql.Tx {
+	t.Helper()
+	dataSource := os.Getenv("PAYSTORE_TEST_DATABASE_URL")
+	if dataSource == "" {
+		t.Skip("PAYSTORE_TEST_DATABASE_URL is not set")
+	}
+	db, errOpen := sql.Open("postgres", dataSource)
+	if errOpen != nil {
+		t.Fatalf("open: %v", errOpen)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	tx, errBegin := db.Begin()
+	if errBegin != nil {
+		t.Fatalf("begin: %v", errBegin)
+	}
+	t.Cleanup(func() { tx.Rollback() })
+	if _, errCreate := tx.Exec(settlementTables); errCreate != nil {
+		t.Fatalf("create tables: %v", errCreate)
+	}
+	return tx
+}
+
+func insertMovement(t *testing.T, tx *sql.Tx, table string, uuid string, amount int64, fees int64, status string,
+	updatedAt time.Time, finalizedAt time.Time) {
+	t.Helper()
+	_, errInsert := tx.Exec(`INSERT INTO `+table+` (uuid, organization_uuid, balance_uuid, amount, fees, status,
+		updated_at, finalized_at) VALUES ($1, 'organization', 'balance', $2, $3, $4, $5, $6)`,
+		uuid, amount, fees, status, updatedAt, sql.NullTime{Time: finalizedAt, Valid: !finalizedAt.IsZero()})
+	if errInsert != nil {
+		t.Fatalf("insert %s %s: %v", table, uuid, errInsert)
+	}
+}
+
+func TestRepositoryComputeByFinalizedDay(t *testing.T) {
+	tx := testTx(t)
+	if _, errInsert := tx.Exec(`INSERT INTO balance (uuid, currency) VALUES ('balance', 'IDR')`); errInsert != nil {
+		t.Fatalf("insert balance: %v", errInsert)
+	}
+
+	day := time.Date(2026, 5, 10, 0, 0, 0, 0, time.UTC)
+	before, during, after := day.Add(-time.Hour), day.Add(6*time.Hour), day.Add(30*time.Hour)
+	paid, failed := string(payment.PaymentStatusPaid), string(payment.PaymentStatusFailed)
+	// Paid the day before, but updated during the day: counted before, only in the liability.
+	insertMovement(t, tx, "payment", "p1", 9700, 300, paid, during, before)
+	insertMovement(t, tx, "payment", "p2", 19400, 600, paid, during, during)
+	// Paid during the day but updated after it: still counted during the day.
+	insertMovement(t, tx, "payment", "p3", 4850, 150, paid, after, during)
+	insertMovement(t, tx, "payment", "p4", 1000, 0, failed, during, during)
+	insertMovement(t, tx, "payment", "p5", 5000, 0, string(payment.PaymentStatusPending), during, time.Time{})
+	insertMovement(t, tx, "payment", "p6", 7000, 0, paid, after, after)
+	insertMovement(t, tx, "withdraw", "w1", 8000, 500, string(withdraw.StatusSuccess), after, during)
+	insertMovement(t, tx, "withdraw", "w2", 3000, 500, string(withdraw.StatusFailed), during, during)
+
+	repository := NewRepository(nil)
+	reports, errCompute := repository.Compute(tx, day.Add(12*time.Hour))
+	if errCompute != nil {
+		t.Fatalf("Compute: %v", errCompute)
+	}
+	if len(reports) != 1 {
+		t.Fatalf("%d reports, want 1", len(reports))
+	}
+
+	report := reports[0]
+	want := Report{
+		OrganizationUUID: "organization", Currency: "IDR", Day: day,
+		GrossCollected: 25000, Fees: 750, NetCredited: 24250, PaidCount: 2, FailedPaymentCount: 1,
+		Withdrawn: 8000, WithdrawFees: 500, WithdrawCount: 1, FailedWithdrawCount: 1,
+		ClosingLiability: 10000 + 20000 + 5000 - 8500,
+	}
+	got := *report
+	got.Record = nil
+	if got != want {
+		t.Errorf("report = %+v, want %+v", got, want)
+	}
+
+	computedAt := day.Add(48 * time.Hour)
+	if errSave := repository.Save(tx, day, reports, computedAt); errSave != nil {
+		t.Fatalf("Save: %v", errSave)
+	}
+	recomputed, _ := repository.Compute(tx, day)
+	if errSave := repository.Save(tx, day, recomputed, computedAt.Add(time.Hour)); errSave != nil {
+		t.Fatalf("Save again: %v", errSave)
+	}
+	if recomputed[0].GetUUID() != report.GetUUID() {
+		t.Errorf("recomputed report got a new UUID %q, want %q", recomputed[0].GetUUID(), report.GetUUID())
+	}
+	var stored int
+	if errCount := tx.QueryRow(`SELECT COUNT(*) FROM settlement_report`).Scan(&stored); errCount != nil || stored != 1 {
+		t.Errorf("%d reports stored, want 1 (%v)", stored, errCount)
+	}
+}
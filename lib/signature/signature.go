@@ -0,0 +1,29 @@
+package signature
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+)
+
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func Verify(secret string, payload []byte, givenSignature string) bool {
+	expected, errDecode := hex.DecodeString(givenSignature)
+	if errDecode != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+func VerifyToken(expectedToken string, givenToken string) bool {
+	return subtle.ConstantTimeCompare([]byte(expectedToken), []byte(givenToken)) == 1
+}
@@ -0,0 +1,23 @@
+package statement
+
+import "errors"
+
+type EntryType string
+
+const (
+	EntryPayment  EntryType = "payment"
+	EntryFee      EntryType = "fee"
+	EntryWithdraw EntryType = "withdraw"
+	// EntryRevenue is a fee credited to a revenue balance; its record is the payment charged.
+	EntryRevenue EntryType = "revenue"
+)
+
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatCSV  Format = "csv"
+)
+
+var InvalidPeriod = errors.New("Period end must be after period start")
+var UnsupportedFormat = errors.New("Unsupported statement format")
@@ -0,0 +1,176 @@
+package statement
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+type Entry struct {
+	Type           EntryType `json:"type"`
+	RecordUUID     string    `json:"recordUUID"`
+	OccurredAt     time.Time `json:"occurredAt"`
+	Amount         int64     `json:"amount"`
+	RunningBalance int64     `json:"runningBalance"`
+}
+
+type Discrepancy struct {
+	RecordUUID string `json:"recordUUID"`
+	Expected   int64  `json:"expected"`
+	Recorded   int64  `json:"recorded"`
+	Field      string `json:"field"`
+}
+
+type Statement struct {
+	BalanceUUID    string        `json:"balanceUUID"`
+	Currency       string        `json:"currency"`
+	PeriodStart    time.Time     `json:"periodStart"`
+	PeriodEnd      time.Time     `json:"periodEnd"`
+	OpeningBalance int64         `json:"openingBalance"`
+	ClosingBalance int64         `json:"closingBalance"`
+	Entries        []Entry       `json:"entries"`
+	Discrepancies  []Discrepancy `json:"discrepancies"`
+}
+
+func (s *Statement) AddPayment(recordUUID string, occurredAt time.Time, amount int64, fees int64,
+	balanceBeforePayment int64, balanceAfterPayment int64) {
+	s.verify(recordUUID, balanceBeforePayment, balanceAfterPayment, amount)
+
+	s.ClosingBalance += amount + fees
+	s.Entries = append(s.Entries, Entry{
+		Type:           EntryPayment,
+		RecordUUID:     recordUUID,
+		OccurredAt:     occurredAt,
+		Amount:         amount + fees,
+		RunningBalance: s.ClosingBalance,
+	})
+
+	if fees > 0 {
+		s.ClosingBalance -= fees
+		s.Entries = append(s.Entries, Entry{
+			Type:           EntryFee,
+			RecordUUID:     recordUUID,
+			OccurredAt:     occurredAt,
+			Amount:         -fees,
+			RunningBalance: s.ClosingBalance,
+		})
+	}
+}
+
+func (s *Statement) AddWithdraw(recordUUID string, occurredAt time.Time, amount int64, fees int64,
+	balanceBeforeWithdraw int64, balanceAfterWithdraw int64) {
+	s.verify(recordUUID, balanceBeforeWithdraw, balanceAfterWithdraw, -amount-fees)
+
+	s.ClosingBalance -= amount
+	s.Entries = append(s.Entries, Entry{
+		Type:           EntryWithdraw,
+		RecordUUID:     recordUUID,
+		OccurredAt:     occurredAt,
+		Amount:         -amount,
+		RunningBalance: s.ClosingBalance,
+	})
+
+	if fees > 0 {
+		s.ClosingBalance -= fees
+		s.Entries = append(s.Entries, Entry{
+			Type:           EntryFee,
+			RecordUUID:     recordUUID,
+			OccurredAt:     occurredAt,
+			Amount:         -fees,
+			RunningBalance: s.ClosingBalance,
+		})
+	}
+}
+
+func (s *Statement) AddRevenue(paymentUUID string, occurredAt time.Time, amount int64,
+	balanceBefore int64, balanceAfter int64) {
+	s.verify(paymentUUID, balanceBefore, balanceAfter, amount)
+
+	s.ClosingBalance += amount
+	s.Entries = append(s.Entries, Entry{
+		Type:           EntryRevenue,
+		RecordUUID:     paymentUUID,
+		OccurredAt:     occurredAt,
+		Amount:         amount,
+		RunningBalance: s.ClosingBalance,
+	})
+}
+
+// verify cross-checks the balance snapshot stored on the record against the running balance.
+func (s *Statement) verify(recordUUID string, balanceBefore int64, balanceAfter int64, movement int64) {
+	if balanceBefore != s.ClosingBalance {
+		s.Discrepancies = append(s.Discrepancies, Discrepancy{
+			RecordUUID: recordUUID, Expected: s.ClosingBalance, Recorded: balanceBefore, Field: "balanceBefore",
+		})
+	}
+	if balanceAfter != balanceBefore+movement {
+		s.Discrepancies = append(s.Discrepancies, Discrepancy{
+			RecordUUID: recordUUID, Expected: balanceBefore + movement, Recorded: balanceAfter, Field: "balanceAfter",
+		})
+	}
+}
+
+func (s *Statement) IsConsistent() bool {
+	return len(s.Discrepancies) == 0
+}
+
+func (s *Statement) Render(format Format) ([]byte, string, error) {
+	switch format {
+	case FormatJSON, "":
+		content, err := s.JSON()
+		return content, "application/json", err
+	case FormatCSV:
+		content, err := s.CSV()
+		return content, "text/csv", err
+	default:
+		return nil, "", UnsupportedFormat
+	}
+}
+
+func (s *Statement) JSON() ([]byte, error) {
+	return json.Marshal(s)
+}
+
+func (s *Statement) CSV() ([]byte, error) {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+
+	records := [][]string{
+		{"occurred_at", "type", "record_uuid", "amount", "running_balance"},
+		{s.PeriodStart.Format(time.RFC3339), "opening", "", "", strconv.FormatInt(s.OpeningBalance, 10)},
+	}
+	for _, entry := range s.Entries {
+		records = append(records, []string{
+			entry.OccurredAt.Format(time.RFC3339),
+			string(entry.Type),
+			entry.RecordUUID,
+			strconv.FormatInt(entry.Amount, 10),
+			strconv.FormatInt(entry.RunningBalance, 10),
+		})
+	}
+	records = append(records,
+		[]string{s.PeriodEnd.Format(time.RFC3339), "closing", "", "", strconv.FormatInt(s.ClosingBalance, 10)})
+
+	errWrite := writer.WriteAll(records)
+	if errWrite != nil {
+		return nil, errWrite
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func NewStatement(balanceUUID string, currency string, periodStart time.Time, periodEnd time.Time,
+	openingBalance int64) *Statement {
+	return &Statement{
+		BalanceUUID:    balanceUUID,
+		Currency:       currency,
+		PeriodStart:    periodStart,
+		PeriodEnd:      periodEnd,
+		OpeningBalance: openingBalance,
+		ClosingBalance: openingBalance,
+		Entries:        []Entry{},
+		Discrepancies:  []Discrepancy{},
+	}
+}
@@ -0,0 +1,123 @@
+package statement
+
+import (
+	"database/sql"
+	"paystore/lib/balance"
+	"paystore/lib/payment"
+	"paystore/lib/withdraw"
+	"time"
+)
+
+var findBalanceCurrencyQuery = `SELECT currency FROM balance WHERE uuid = $1`
+
+// Payments and withdraws move the balance when they are finalized, not when they are created,
+// so they are placed in the period, and ordered, by finalized_at. Revenue entries are created
+// as the fee is credited.
+var openingBalanceQuery = `
+	SELECT
+		(SELECT COALESCE(SUM(amount), 0) FROM payment
+			WHERE balance_uuid = $1 AND status = $2 AND finalized_at < $3) -
+		(SELECT COALESCE(SUM(amount + fees), 0) FROM withdraw
+			WHERE balance_uuid = $1 AND status = $4 AND finalized_at < $3) +
+		(SELECT COALESCE(SUM(amount), 0) FROM fee_revenue WHERE balance_uuid = $1 AND created_at < $3)`
+var movementQuery = `
+	SELECT entry_type, uuid, occurred_at, amount, fees, balance_before, balance_after FROM (
+		SELECT 'payment' AS entry_type, uuid, finalized_at AS occurred_at, amount, fees,
+			balance_before_payment AS balance_before, balance_after_payment AS balance_after
+		FROM payment WHERE balance_uuid = $1 AND status = $2 AND finalized_at >= $3 AND finalized_at < $4
+		UNION ALL
+		SELECT 'withdraw' AS entry_type, uuid, finalized_at AS occurred_at, amount, fees,
+			balance_before_withdraw AS balance_before, balance_after_withdraw AS balance_after
+		FROM withdraw WHERE balance_uuid = $1 AND status = $5 AND finalized_at >= $3 AND finalized_at < $4
+		UNION ALL
+		SELECT 'revenue' AS entry_type, source_uuid AS uuid, created_at AS occurred_at, amount, 0 AS fees,
+			balance_before, balance_after
+		FROM fee_revenue WHERE balance_uuid = $1 AND created_at >= $3 AND created_at < $4
+	) movement ORDER BY occurred_at ASC, uuid ASC`
+
+type RepositoryClient interface {
+	Generate(balanceUUID string, periodStart time.Time, periodEnd time.Time) (*Statement, error)
+}
+
+type Repository struct {
+	findBalanceCurrencyStmt *sql.Stmt
+	openingBalanceStmt      *sql.Stmt
+	movementStmt            *sql.Stmt
+}
+
+func (r *Repository) Generate(balanceUUID string, periodStart time.Time, periodEnd time.Time) (*Statement, error) {
+	if !periodEnd.After(periodStart) {
+		return nil, InvalidPeriod
+	}
+
+	var currency string
+	errFind := r.findBalanceCurrencyStmt.QueryRow(balanceUUID).Scan(&currency)
+	if errFind != nil {
+		if errFind == sql.ErrNoRows {
+			return nil, balance.BalanceNotFound
+		}
+		return nil, errFind
+	}
+
+	var openingBalance int64
+	errOpening := r.openingBalanceStmt.QueryRow(balanceUUID, payment.PaymentStatusPaid, periodStart,
+		withdraw.StatusSuccess).Scan(&openingBalance)
+	if errOpening != nil {
+		return nil, errOpening
+	}
+
+	statement := NewStatement(balanceUUID, currency, periodStart, periodEnd, openingBalance)
+
+	rows, errQuery := r.movementStmt.Query(balanceUUID, payment.PaymentStatusPaid, periodStart, periodEnd,
+		withdraw.StatusSuccess)
+	if errQuery != nil {
+		return nil, errQuery
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var entryType EntryType
+		var recordUUID string
+		var occurredAt time.Time
+		var amount, fees, balanceBefore, balanceAfter int64
+
+		errScan := rows.Scan(&entryType, &recordUUID, &occurredAt, &amount, &fees, &balanceBefore, &balanceAfter)
+		if errScan != nil {
+			return nil, errScan
+		}
+
+		if entryType == EntryPayment {
+			statement.AddPayment(recordUUID, occurredAt, amount, fees, balanceBefore, balanceAfter)
+		} else if entryType == EntryRevenue {
+			statement.AddRevenue(recordUUID, occurredAt, amount, balanceBefore, balanceAfter)
+		} else {
+			statement.AddWithdraw(recordUUID, occurredAt, amount, fees, balanceBefore, balanceAfter)
+		}
+	}
+	if errRows := rows.Err(); errRows != nil {
+		return nil, errRows
+	}
+
+	return statement, nil
+}
+
+func NewRepository(readDB *sql.DB) *Repository {
+	findBalanceCurrencyStmt, err := readDB.Prepare(findBalanceCurrencyQuery)
+	if err != nil {
+		panic(err)
+	}
+	openingBalanceStmt, err := readDB.Prepare(openingBalanceQuery)
+	if err != nil {
+		panic(err)
+	}
+	movementStmt, err := readDB.Prepare(movementQuery)
+	if err != nil {
+		panic(err)
+	}
+
+	return &Repository{
+		findBalanceCurrencyStmt: findBalanceCurrencyStmt,
+		openingBalanceStmt:      openingBalanceStmt,
+		movementStmt:            movementStmt,
+	}
+}
@@ -0,0 +1,132 @@
+package statement
+
+import (
+	"database/sql"
+	"os"
+	"paystore/lib/fee"
+	"paystore/lib/payment"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+)
+
+// statementTables shadows the tables a statement reads with temporary ones, holding only the
+// columns it uses. They live as long as the session, which the test keeps to one connection.
+var statementTables = `
+	CREATE TEMP TABLE balance (uuid VARCHAR(255) PRIMARY KEY, currency VARCHAR(3) NOT NULL);
+	CREATE TEMP TABLE payment (
+		uuid VARCHAR(255) PRIMARY KEY, balance_uuid VARCHAR(255) NOT NULL, status VARCHAR(20) NOT NULL,
+		amount BIGINT NOT NULL, fees BIGINT NOT NULL, created_at TIMESTAMP NOT NULL, finalized_at TIMESTAMP,
+		balance_before_payment BIGINT NOT NULL, balance_after_payment BIGINT NOT NULL
+	);
+	CREATE TEMP TABLE withdraw (
+		uuid VARCHAR(255) PRIMARY KEY, balance_uuid VARCHAR(255) NOT NULL, status VARCHAR(20) NOT NULL,
+		amount BIGINT NOT NULL, fees BIGINT NOT NULL, created_at TIMESTAMP NOT NULL, finalized_at TIMESTAMP,
+		balance_before_withdraw BIGINT NOT NULL, balance_after_withdraw BIGINT NOT NULL
+	);
+	CREATE TEMP TABLE fee_revenue (
+		balance_uuid VARCHAR(255) NOT NULL, source_uuid VARCHAR(255) NOT NULL, created_at TIMESTAMP NOT NULL,
+		amount BIGINT NOT NULL, balance_before BIGINT NOT NULL, balance_after BIGINT NOT NULL
+	);`
+
+// testDB connects to the Postgres at PAYSTORE_TEST_DATABASE_URL with the statement tables in
+// place, skipping the test without one.
+func testDB(t *testing.T) *sql.DB {
+	t.Helper()
+	dataSource := os.Getenv("PAYSTORE_TEST_DATABASE_URL")
+	if dataSource == "" {
+		t.Skip("PAYSTORE_TEST_DATABASE_URL is not set")
+	}
+	db, errOpen := sql.Open("postgres", dataSource)
+	if errOpen != nil {
+		t.Fatalf("open: %v", errOpen)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	if _, errCreate := db.Exec(statementTables); errCreate != nil {
+		t.Fatalf("create tables: %v", errCreate)
+	}
+	return db
+}
+
+func insertPayment(t *testing.T, db *sql.DB, uuid string, inserted *payment.Payment, createdAt time.Time) {
+	t.Helper()
+	_, errInsert := db.Exec(`INSERT INTO payment (uuid, balance_uuid, status, amount, fees, created_at, finalized_at,
+		balance_before_payment, balance_after_payment) VALUES ($1, 'balance', $2, $3, $4, $5, $6, $7, $8)`,
+		uuid, inserted.Status, inserted.Amount, inserted.Fees, createdAt,
+		sql.NullTime{Time: inserted.FinalizedAt, Valid: !inserted.FinalizedAt.IsZero()},
+		inserted.BalanceBeforePayment, inserted.BalanceAfterPayment)
+	if errInsert != nil {
+		t.Fatalf("insert payment %s: %v", uuid, errInsert)
+	}
+}
+
+func TestRepositoryGenerateByFinalizedAt(t *testing.T) {
+	db := testDB(t)
+	if _, errInsert := db.Exec(`INSERT INTO balance (uuid, currency) VALUES ('balance', 'IDR')`); errInsert != nil {
+		t.Fatalf("insert balance: %v", errInsert)
+	}
+
+	periodStart := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	periodEnd := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	var balance int64
+	newPayment := func(amount int64, fees int64) *payment.Payment {
+		created := payment.NewPayment()
+		created.SetAmount(amount, balance, fee.Breakdown{Total: fees})
+		return created
+	}
+	pay := func(paid *payment.Payment, at time.Time) {
+		paid.SetBalanceSnapshot(balance)
+		paid.SetPaid()
+		paid.FinalizedAt = at
+		balance += paid.Amount
+	}
+
+	// Created in April and paid in May: it moves the balance in May.
+	early := newPayment(10000, 300)
+	// Two payments pending at the same time, paid in the opposite order they were created in.
+	first, second := newPayment(20000, 600), newPayment(5000, 150)
+	// Created in May but paid in June: it belongs to June's statement.
+	late := newPayment(7000, 0)
+	pay(early, periodStart.Add(time.Hour))
+	pay(second, periodStart.Add(48*time.Hour))
+	pay(first, periodStart.Add(72*time.Hour))
+	pay(late, periodEnd.Add(time.Hour))
+	pending := newPayment(3000, 0)
+
+	insertPayment(t, db, "early", early, periodStart.Add(-time.Hour))
+	insertPayment(t, db, "first", first, periodStart.Add(24*time.Hour))
+	insertPayment(t, db, "second", second, periodStart.Add(25*time.Hour))
+	insertPayment(t, db, "late", late, periodEnd.Add(-time.Hour))
+	insertPayment(t, db, "pending", pending, periodStart.Add(26*time.Hour))
+
+	generated, errGenerate := NewRepository(db).Generate("balance", periodStart, periodEnd)
+	if errGenerate != nil {
+		t.Fatalf("Generate: %v", errGenerate)
+	}
+	if !generated.IsConsistent() {
+		t.Errorf("discrepancies %+v", generated.Discrepancies)
+	}
+	if generated.OpeningBalance != 0 || generated.ClosingBalance != 9700+4850+19400 {
+		t.Errorf("opening %d, closing %d", generated.OpeningBalance, generated.ClosingBalance)
+	}
+	var order []string
+	for _, entry := range generated.Entries {
+		if entry.Type == EntryPayment {
+			order = append(order, entry.RecordUUID)
+		}
+	}
+	if len(order) != 3 || order[0] != "early" || order[1] != "second" || order[2] != "first" {
+		t.Errorf("payments in order %v, want [early second first]", order)
+	}
+
+	june, errGenerate := NewRepository(db).Generate("balance", periodEnd, periodEnd.AddDate(0, 1, 0))
+	if errGenerate != nil {
+		t.Fatalf("Generate June: %v", errGenerate)
+	}
+	if june.OpeningBalance != generated.ClosingBalance || len(june.Entries) != 1 || !june.IsConsistent() {
+		t.Errorf("June opens at %d with %d entries (%+v)", june.OpeningBalance, len(june.Entries), june.Discrepancies)
+	}
+}
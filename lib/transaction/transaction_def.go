@@ -5,4 +5,6 @@ type TransactionType string
 const (
 	TypePayment  TransactionType = "payment"
 	TypeWithdraw TransactionType = "withdraw"
+	// TypeRevenue credits a payment's fee to the organization's revenue balance.
+	TypeRevenue TransactionType = "revenue"
 )
@@ -0,0 +1,34 @@
+package webhook
+
+import "errors"
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliveryDelivered DeliveryStatus = "delivered"
+	DeliveryFailed    DeliveryStatus = "failed"
+)
+
+// Headers sent with every delivery. The signature is the hex HMAC-SHA256 of
+// "<timestamp>.<body>" keyed with the organization's webhook secret.
+const (
+	SignatureHeader = "X-Paystore-Signature"
+	TimestampHeader = "X-Paystore-Timestamp"
+	EventHeader     = "X-Paystore-Event"
+	DeliveryHeader  = "X-Paystore-Delivery"
+)
+
+var EndpointNotFound = errors.New("Webhook endpoint not found")
+var DeliveryNotFound = errors.New("Webhook delivery not found")
+var InvalidEndpointURL = errors.New("Webhook endpoint URL must be an absolute http or https URL")
+var PrivateEndpointAddress = errors.New("Webhook endpoint must not resolve to a private, loopback or link-local address")
+
+type DeliveryFilter struct {
+	OrganizationUUID string
+	EndpointUUID     string
+	EventType        string
+	Status           DeliveryStatus
+	Cursor           string
+	Limit            int64
+}
@@ -0,0 +1,261 @@
+package webhook
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/21strive/redifu"
+	"github.com/lib/pq"
+	"net"
+	"net/url"
+	"paystore/lib/helper"
+	"paystore/lib/signature"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Endpoint is a callback URL registered by an organization. EventTypes filters which events
+// it receives: empty means all, and an entry ending in ".*" matches every event of that aggregate.
+type Endpoint struct {
+	*redifu.Record
+	OrganizationUUID string   `json:"organizationUUID"`
+	URL              string   `json:"url"`
+	EventTypes       []string `json:"eventTypes"`
+	Active           bool     `json:"active"`
+}
+
+// SetURL accepts only http and https URLs whose host resolves to public addresses, so an
+// endpoint cannot be used to reach paystore's own network. The dispatcher checks the address
+// again when it connects, since the host may resolve differently by then.
+func (e *Endpoint) SetURL(endpointURL string) error {
+	parsed, errParse := url.Parse(endpointURL)
+	if errParse != nil || !parsed.IsAbs() || (parsed.Scheme != "http" && parsed.Scheme != "https") ||
+		parsed.Hostname() == "" {
+		return InvalidEndpointURL
+	}
+
+	addresses, errLookup := lookupHost(parsed.Hostname())
+	if errLookup != nil {
+		return InvalidEndpointURL
+	}
+	for _, address := range addresses {
+		if !IsPublicAddress(address) {
+			return PrivateEndpointAddress
+		}
+	}
+
+	e.URL = endpointURL
+	return nil
+}
+
+// lookupHost resolves the host of an endpoint URL; IP literals resolve to themselves.
+var lookupHost = func(host string) ([]net.IP, error) {
+	if ip := net.ParseIP(host); ip != nil {
+		return []net.IP{ip}, nil
+	}
+	return net.LookupIP(host)
+}
+
+// deniedNetworks are the special-purpose ranges of the IANA registries that are not globally
+// reachable, or that translate to IPv4 addresses which may not be: NAT64, 6to4 and Teredo.
+// On cloud hosts several of them, such as carrier-grade NAT, reach internal services.
+var deniedNetworks = parseNetworks(
+	"0.0.0.0/8", "10.0.0.0/8", "100.64.0.0/10", "127.0.0.0/8", "169.254.0.0/16", "172.16.0.0/12",
+	"192.0.0.0/24", "192.0.2.0/24", "192.88.99.0/24", "192.168.0.0/16", "198.18.0.0/15", "198.51.100.0/24",
+	"203.0.113.0/24", "224.0.0.0/4", "240.0.0.0/4",
+	"::/128", "::1/128", "64:ff9b::/96", "64:ff9b:1::/48", "100::/64", "2001::/23", "2001:db8::/32",
+	"2002::/16", "fc00::/7", "fe80::/10", "ff00::/8",
+)
+
+func parseNetworks(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, errParse := net.ParseCIDR(cidr)
+		if errParse != nil {
+			panic(errParse)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
+
+// IsPublicAddress reports whether ip may be the target of a webhook delivery: it must not be
+// in any of deniedNetworks. An IPv4-mapped IPv6 address is checked as the IPv4 address it carries.
+func IsPublicAddress(ip net.IP) bool {
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	if len(ip) != net.IPv4len && len(ip) != net.IPv6len {
+		return false
+	}
+	for _, network := range deniedNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// DialControl refuses connections to addresses that are not public. It runs after name
+// resolution, for every connection including redirects, so it also covers hosts that
+// resolved to a public address when the endpoint was registered.
+func DialControl(network string, address string, conn syscall.RawConn) error {
+	host, _, errSplit := net.SplitHostPort(address)
+	if errSplit != nil {
+		return errSplit
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !IsPublicAddress(ip) {
+		return PrivateEndpointAddress
+	}
+	return nil
+}
+
+func (e *Endpoint) Matches(eventType string) bool {
+	if len(e.EventTypes) == 0 {
+		return true
+	}
+
+	for _, filter := range e.EventTypes {
+		if filter == eventType {
+			return true
+		}
+		if strings.HasSuffix(filter, ".*") && strings.HasPrefix(eventType, strings.TrimSuffix(filter, "*")) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *Endpoint) ScanDestinations() []interface{} {
+	return []interface{}{
+		&e.UUID,
+		&e.RandId,
+		&e.CreatedAt,
+		&e.UpdatedAt,
+		&e.OrganizationUUID,
+		&e.URL,
+		pq.Array(&e.EventTypes),
+		&e.Active,
+	}
+}
+
+// Delivery is one event sent to one endpoint, kept as the delivery log.
+type Delivery struct {
+	*redifu.Record
+	EndpointUUID     string          `json:"endpointUUID"`
+	OrganizationUUID string          `json:"organizationUUID"`
+	EventUUID        string          `json:"eventUUID"`
+	EventType        string          `json:"eventType"`
+	URL              string          `json:"url"`
+	Payload          json.RawMessage `json:"payload"`
+	Status           DeliveryStatus  `json:"status"`
+	Attempts         int64           `json:"attempts"`
+	ReplayCount      int64           `json:"replayCount"`
+	NextAttemptAt    time.Time       `json:"nextAttemptAt"`
+	LastResponseCode int             `json:"lastResponseCode"`
+	LastError        string          `json:"lastError,omitempty"`
+	DeliveredAt      time.Time       `json:"deliveredAt,omitempty"`
+}
+
+func (d *Delivery) Sign(secret string, timestamp time.Time) (string, string) {
+	unix := strconv.FormatInt(timestamp.Unix(), 10)
+	return unix, signature.Sign(secret, append([]byte(unix+"."), d.Payload...))
+}
+
+// RecordAttempt stores the outcome of one POST. A 2xx response delivers the event; any other
+// outcome is retried after backoff doubled per attempt, until maxAttempts marks it failed.
+func (d *Delivery) RecordAttempt(responseCode int, errAttempt error, now time.Time, backoff time.Duration,
+	maxAttempts int64) {
+	d.Attempts++
+	d.LastResponseCode = responseCode
+	d.LastError = ""
+	if errAttempt != nil {
+		d.LastError = errAttempt.Error()
+	}
+	d.SetUpdatedAt(now)
+
+	if errAttempt == nil && responseCode >= 200 && responseCode < 300 {
+		d.Status = DeliveryDelivered
+		d.DeliveredAt = now
+		return
+	}
+	if d.Attempts >= maxAttempts {
+		d.Status = DeliveryFailed
+		return
+	}
+	d.NextAttemptAt = now.Add(backoff << min(d.Attempts-1, 16))
+}
+
+// Replay queues the delivery again from a fresh attempt count, whatever its outcome so far.
+func (d *Delivery) Replay(now time.Time) {
+	d.Status = DeliveryPending
+	d.Attempts = 0
+	d.ReplayCount++
+	d.NextAttemptAt = now
+	d.SetUpdatedAt(now)
+}
+
+func (d *Delivery) ScanDestinations() []interface{} {
+	return []interface{}{
+		&d.UUID,
+		&d.RandId,
+		&d.CreatedAt,
+		&d.UpdatedAt,
+		&d.EndpointUUID,
+		&d.OrganizationUUID,
+		&d.EventUUID,
+		&d.EventType,
+		&d.URL,
+		&d.Payload,
+		&d.Status,
+		&d.Attempts,
+		&d.ReplayCount,
+		&d.NextAttemptAt,
+		&d.LastResponseCode,
+		&d.LastError,
+		helper.NullableDestinations([]interface{}{&d.DeliveredAt})[0],
+	}
+}
+
+func NewEndpoint(organizationUUID string, eventTypes []string) *Endpoint {
+	endpoint := &Endpoint{}
+	redifu.InitRecord(endpoint)
+	endpoint.OrganizationUUID = organizationUUID
+	endpoint.EventTypes = eventTypes
+	endpoint.Active = true
+	return endpoint
+}
+
+func NewDelivery(endpoint *Endpoint, eventUUID string, eventType string, payload []byte) *Delivery {
+	delivery := &Delivery{}
+	redifu.InitRecord(delivery)
+	delivery.EndpointUUID = endpoint.GetUUID()
+	delivery.OrganizationUUID = endpoint.OrganizationUUID
+	delivery.EventUUID = eventUUID
+	delivery.EventType = eventType
+	delivery.URL = endpoint.URL
+	delivery.Payload = payload
+	delivery.Status = DeliveryPending
+	delivery.NextAttemptAt = delivery.GetCreatedAt()
+	return delivery
+}
+
+func NewSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, errRand := rand.Read(secret); errRand != nil {
+		return "", errRand
+	}
+	return hex.EncodeToString(secret), nil
+}
+
+// EventBody is the JSON document POSTed to tenant endpoints.
+type EventBody struct {
+	ID               string          `json:"id"`
+	Type             string          `json:"type"`
+	OrganizationUUID string          `json:"organizationUUID"`
+	CreatedAt        string          `json:"createdAt"`
+	Data             json.RawMessage `json:"data"`
+}
@@ -0,0 +1,112 @@
+package webhook
+
+import (
+	"net"
+	"testing"
+)
+
+func TestEndpointSetURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want error
+	}{
+		{"https://8.8.8.8/hooks", nil},
+		{"http://[2001:4860:4860::8888]:8080/hooks", nil},
+		{"ftp://8.8.8.8/hooks", InvalidEndpointURL},
+		{"/hooks", InvalidEndpointURL},
+		{"http://127.0.0.1/hooks", PrivateEndpointAddress},
+		{"http://[::1]/hooks", PrivateEndpointAddress},
+		{"http://10.1.2.3/hooks", PrivateEndpointAddress},
+		{"http://172.16.0.1/hooks", PrivateEndpointAddress},
+		{"http://192.168.1.1/hooks", PrivateEndpointAddress},
+		{"http://169.254.169.254/latest/meta-data", PrivateEndpointAddress},
+		{"http://[fe80::1]/hooks", PrivateEndpointAddress},
+		{"http://0.0.0.0/hooks", PrivateEndpointAddress},
+	}
+	for _, test := range tests {
+		endpoint := NewEndpoint("organization", nil)
+		errURL := endpoint.SetURL(test.url)
+		if errURL != test.want {
+			t.Errorf("SetURL(%q) = %v, want %v", test.url, errURL, test.want)
+		}
+	}
+}
+
+func TestIsPublicAddress(t *testing.T) {
+	tests := []struct {
+		address string
+		public  bool
+	}{
+		{"8.8.8.8", true},
+		{"100.63.255.255", true},
+		{"100.128.0.0", true},
+		{"2001:4860:4860::8888", true},
+		{"::ffff:8.8.8.8", true},
+		{"0.1.2.3", false},
+		{"10.0.0.1", false},
+		{"100.64.0.1", false},
+		{"100.127.255.254", false},
+		{"127.0.0.1", false},
+		{"169.254.169.254", false},
+		{"172.31.255.255", false},
+		{"192.0.0.8", false},
+		{"192.0.2.1", false},
+		{"192.88.99.1", false},
+		{"192.168.0.1", false},
+		{"198.18.0.1", false},
+		{"198.19.255.255", false},
+		{"198.51.100.1", false},
+		{"203.0.113.10", false},
+		{"224.0.0.1", false},
+		{"240.0.0.1", false},
+		{"255.255.255.255", false},
+		{"::", false},
+		{"::1", false},
+		{"::ffff:127.0.0.1", false},
+		{"::ffff:100.64.0.1", false},
+		{"64:ff9b::a00:1", false},
+		{"64:ff9b:1::1", false},
+		{"100::1", false},
+		{"2001::1", false},
+		{"2001:db8::1", false},
+		{"2002:a00:1::1", false},
+		{"fd00::1", false},
+		{"fe80::1", false},
+		{"ff02::1", false},
+	}
+	for _, test := range tests {
+		if public := IsPublicAddress(net.ParseIP(test.address)); public != test.public {
+			t.Errorf("IsPublicAddress(%s) = %v, want %v", test.address, public, test.public)
+		}
+	}
+	if IsPublicAddress(nil) {
+		t.Errorf("a nil address is public")
+	}
+}
+
+func TestEndpointSetURLChecksResolvedAddresses(t *testing.T) {
+	defer func(original func(string) ([]net.IP, error)) { lookupHost = original }(lookupHost)
+	lookupHost = func(host string) ([]net.IP, error) {
+		return []net.IP{net.ParseIP("8.8.8.8"), net.ParseIP("10.0.0.5")}, nil
+	}
+
+	endpoint := NewEndpoint("organization", nil)
+	errURL := endpoint.SetURL("https://hooks.example.com/paystore")
+	if errURL != PrivateEndpointAddress {
+		t.Errorf("SetURL = %v, want %v", errURL, PrivateEndpointAddress)
+	}
+	if endpoint.URL != "" {
+		t.Errorf("URL was set to %q", endpoint.URL)
+	}
+}
+
+func TestDialControl(t *testing.T) {
+	if errDial := DialControl("tcp", "8.8.8.8:443", nil); errDial != nil {
+		t.Errorf("public address refused: %v", errDial)
+	}
+	for _, address := range []string{"127.0.0.1:80", "[::1]:443", "10.0.0.1:8080", "169.254.169.254:80"} {
+		if errDial := DialControl("tcp", address, nil); errDial != PrivateEndpointAddress {
+			t.Errorf("DialControl(%q) = %v, want %v", address, errDial, PrivateEndpointAddress)
+		}
+	}
+}
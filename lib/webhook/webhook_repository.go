@@ -0,0 +1,258 @@
+package webhook
+
+import (
+	"database/sql"
+	"github.com/lib/pq"
+	"paystore/config"
+	"paystore/lib/builder"
+	"paystore/lib/helper"
+	"time"
+)
+
+var endpointColumns = `uuid, randid, created_at, updated_at, organization_uuid, url, event_types, active`
+var deliveryColumns = `uuid, randid, created_at, updated_at, endpoint_uuid, organization_uuid, event_uuid, event_type, url,
+	payload, status, attempts, replay_count, next_attempt_at, last_response_code, last_error, delivered_at`
+
+var createEndpointQuery = `INSERT INTO webhook_endpoint (` + endpointColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+var deactivateEndpointQuery = `UPDATE webhook_endpoint SET active = false, updated_at = NOW()
+	WHERE uuid = $1 AND organization_uuid = $2 AND active`
+var findActiveEndpointsQuery = `SELECT ` + endpointColumns + ` FROM webhook_endpoint
+	WHERE organization_uuid = $1 AND active ORDER BY created_at`
+var findSecretQuery = `SELECT secret FROM webhook_secret WHERE organization_uuid = $1`
+var createSecretQuery = `INSERT INTO webhook_secret (organization_uuid, secret) VALUES ($1, $2)
+	ON CONFLICT (organization_uuid) DO NOTHING`
+var rotateSecretQuery = `INSERT INTO webhook_secret (organization_uuid, secret) VALUES ($1, $2)
+	ON CONFLICT (organization_uuid) DO UPDATE SET secret = EXCLUDED.secret, updated_at = NOW()`
+
+// createDeliveryQuery ignores an event already fanned out to the endpoint, so a redelivered
+// stream entry does not queue a second delivery.
+var createDeliveryQuery = `INSERT INTO webhook_delivery (` + deliveryColumns + `)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+	ON CONFLICT (endpoint_uuid, event_uuid) DO NOTHING`
+
+// claimDueQuery leases due deliveries by pushing their next attempt past the lease, so
+// dispatchers on other instances skip them while this one posts them.
+var claimDueQuery = `UPDATE webhook_delivery SET next_attempt_at = $1 WHERE uuid IN (
+		SELECT uuid FROM webhook_delivery WHERE status = $2 AND next_attempt_at <= $3
+		ORDER BY next_attempt_at LIMIT $4 FOR UPDATE SKIP LOCKED)
+	RETURNING ` + deliveryColumns
+var updateDeliveryQuery = `UPDATE webhook_delivery SET updated_at = $1, status = $2, attempts = $3, replay_count = $4,
+	next_attempt_at = $5, last_response_code = $6, last_error = $7, delivered_at = $8 WHERE uuid = $9`
+var findDeliveryByUUIDQuery = `SELECT ` + deliveryColumns + ` FROM webhook_delivery WHERE uuid = $1`
+
+type RepositoryClient interface {
+	CreateEndpoint(endpoint *Endpoint) error
+	DeactivateEndpoint(organizationUUID string, endpointUUID string) error
+	FindActiveEndpoints(organizationUUID string) ([]*Endpoint, error)
+	FindOrCreateSecret(organizationUUID string) (string, error)
+	RotateSecret(organizationUUID string) (string, error)
+	CreateDelivery(delivery *Delivery) error
+	ClaimDue(now time.Time, lease time.Duration, limit int64) ([]*Delivery, error)
+	UpdateDelivery(delivery *Delivery) error
+	FindDeliveryByUUID(uuid string) (*Delivery, error)
+	SearchDeliveries(filter DeliveryFilter) ([]*Delivery, string, error)
+}
+
+type Repository struct {
+	writeDB   *sql.DB
+	readDB    *sql.DB
+	AppConfig *config.App
+}
+
+func (r *Repository) CreateEndpoint(endpoint *Endpoint) error {
+	_, errExec := r.writeDB.Exec(createEndpointQuery, endpoint.GetUUID(), endpoint.GetRandId(),
+		endpoint.GetCreatedAt(), endpoint.GetUpdatedAt(), endpoint.OrganizationUUID, endpoint.URL,
+		pq.Array(endpoint.EventTypes), endpoint.Active)
+	return errExec
+}
+
+func (r *Repository) DeactivateEndpoint(organizationUUID string, endpointUUID string) error {
+	result, errExec := r.writeDB.Exec(deactivateEndpointQuery, endpointUUID, organizationUUID)
+	if errExec != nil {
+		return errExec
+	}
+
+	affected, errAffected := result.RowsAffected()
+	if errAffected != nil {
+		return errAffected
+	}
+	if affected == 0 {
+		return EndpointNotFound
+	}
+	return nil
+}
+
+func (r *Repository) FindActiveEndpoints(organizationUUID string) ([]*Endpoint, error) {
+	rows, errQuery := r.readDB.Query(findActiveEndpointsQuery, organizationUUID)
+	if errQuery != nil {
+		return nil, errQuery
+	}
+	defer rows.Close()
+
+	var endpoints []*Endpoint
+	for rows.Next() {
+		endpoint := NewEndpoint("", nil)
+		errScan := rows.Scan(endpoint.ScanDestinations()...)
+		if errScan != nil {
+			return nil, errScan
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+	if errRows := rows.Err(); errRows != nil {
+		return nil, errRows
+	}
+
+	return endpoints, nil
+}
+
+// FindOrCreateSecret reads from the write database so a secret created moments ago by
+// another instance is seen before replication catches up.
+func (r *Repository) FindOrCreateSecret(organizationUUID string) (string, error) {
+	secret, errNew := NewSecret()
+	if errNew != nil {
+		return "", errNew
+	}
+
+	_, errExec := r.writeDB.Exec(createSecretQuery, organizationUUID, secret)
+	if errExec != nil {
+		return "", errExec
+	}
+
+	errScan := r.writeDB.QueryRow(findSecretQuery, organizationUUID).Scan(&secret)
+	if errScan != nil {
+		return "", errScan
+	}
+	return secret, nil
+}
+
+func (r *Repository) RotateSecret(organizationUUID string) (string, error) {
+	secret, errNew := NewSecret()
+	if errNew != nil {
+		return "", errNew
+	}
+
+	_, errExec := r.writeDB.Exec(rotateSecretQuery, organizationUUID, secret)
+	if errExec != nil {
+		return "", errExec
+	}
+	return secret, nil
+}
+
+func (r *Repository) CreateDelivery(delivery *Delivery) error {
+	_, errExec := r.writeDB.Exec(createDeliveryQuery, deliveryValues(delivery)...)
+	return errExec
+}
+
+func (r *Repository) ClaimDue(now time.Time, lease time.Duration, limit int64) ([]*Delivery, error) {
+	rows, errQuery := r.writeDB.Query(claimDueQuery, now.Add(lease), DeliveryPending, now, limit)
+	if errQuery != nil {
+		return nil, errQuery
+	}
+	return scanDeliveries(rows)
+}
+
+func (r *Repository) UpdateDelivery(delivery *Delivery) error {
+	_, errExec := r.writeDB.Exec(updateDeliveryQuery, delivery.GetUpdatedAt(), delivery.Status, delivery.Attempts,
+		delivery.ReplayCount, delivery.NextAttemptAt, delivery.LastResponseCode, delivery.LastError,
+		nullTime(delivery.DeliveredAt), delivery.GetUUID())
+	return errExec
+}
+
+func (r *Repository) FindDeliveryByUUID(uuid string) (*Delivery, error) {
+	delivery := NewDelivery(NewEndpoint("", nil), "", "", nil)
+	errScan := r.readDB.QueryRow(findDeliveryByUUIDQuery, uuid).Scan(delivery.ScanDestinations()...)
+	if errScan != nil {
+		if errScan == sql.ErrNoRows {
+			return nil, DeliveryNotFound
+		}
+		return nil, errScan
+	}
+	return delivery, nil
+}
+
+func (r *Repository) SearchDeliveries(filter DeliveryFilter) ([]*Delivery, string, error) {
+	limit := filter.Limit
+	if limit <= 0 || limit > r.AppConfig.ItemPerPage {
+		limit = r.AppConfig.ItemPerPage
+	}
+
+	filterBuilder := builder.NewFilterBuilder()
+	filterBuilder.Where("organization_uuid", "=", filter.OrganizationUUID)
+	if filter.EndpointUUID != "" {
+		filterBuilder.Where("endpoint_uuid", "=", filter.EndpointUUID)
+	}
+	if filter.EventType != "" {
+		filterBuilder.Where("event_type", "=", filter.EventType)
+	}
+	if filter.Status != "" {
+		filterBuilder.Where("status", "=", filter.Status)
+	}
+	if filter.Cursor != "" {
+		cursorTime, cursorUUID, errDecode := helper.DecodeCursor(filter.Cursor)
+		if errDecode != nil {
+			return nil, "", errDecode
+		}
+		filterBuilder.WhereBefore("created_at", "uuid", cursorTime, cursorUUID)
+	}
+
+	query := `SELECT ` + deliveryColumns + ` FROM webhook_delivery` + filterBuilder.Clause() +
+		` ORDER BY created_at DESC, uuid DESC` + filterBuilder.Limit(limit+1)
+	rows, errQuery := r.readDB.Query(query, filterBuilder.Args()...)
+	if errQuery != nil {
+		return nil, "", errQuery
+	}
+
+	deliveries, errScan := scanDeliveries(rows)
+	if errScan != nil {
+		return nil, "", errScan
+	}
+
+	var nextCursor string
+	if int64(len(deliveries)) > limit {
+		deliveries = deliveries[:limit]
+		lastDelivery := deliveries[len(deliveries)-1]
+		nextCursor = helper.EncodeCursor(lastDelivery.GetCreatedAt(), lastDelivery.GetUUID())
+	}
+
+	return deliveries, nextCursor, nil
+}
+
+func scanDeliveries(rows *sql.Rows) ([]*Delivery, error) {
+	defer rows.Close()
+
+	var deliveries []*Delivery
+	for rows.Next() {
+		delivery := NewDelivery(NewEndpoint("", nil), "", "", nil)
+		errScan := rows.Scan(delivery.ScanDestinations()...)
+		if errScan != nil {
+			return nil, errScan
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	if errRows := rows.Err(); errRows != nil {
+		return nil, errRows
+	}
+
+	return deliveries, nil
+}
+
+func deliveryValues(delivery *Delivery) []interface{} {
+	return []interface{}{
+		delivery.GetUUID(), delivery.GetRandId(), delivery.GetCreatedAt(), delivery.GetUpdatedAt(),
+		delivery.EndpointUUID, delivery.OrganizationUUID, delivery.EventUUID, delivery.EventType, delivery.URL,
+		delivery.Payload, delivery.Status, delivery.Attempts, delivery.ReplayCount, delivery.NextAttemptAt,
+		delivery.LastResponseCode, delivery.LastError, nullTime(delivery.DeliveredAt),
+	}
+}
+
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
+
+func NewRepository(writeDB *sql.DB, readDB *sql.DB, config *config.App) *Repository {
+	return &Repository{
+		writeDB:   writeDB,
+		readDB:    readDB,
+		AppConfig: config,
+	}
+}
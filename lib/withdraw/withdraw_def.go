@@ -1,6 +1,9 @@
 package withdraw
 
-import "errors"
+import (
+	"errors"
+	"time"
+)
 
 type WithdrawStatus string
 
@@ -8,6 +11,40 @@ const (
 	StatusPending WithdrawStatus = "pending"
 	StatusSuccess WithdrawStatus = "success"
 	StatusFailed  WithdrawStatus = "failed"
+	// StatusReview marks a withdraw whose outcome could not be confirmed with the vendor
+	// after repeated polling; it stays unsettled until an operator finalizes it.
+	StatusReview WithdrawStatus = "review"
 )
 
+// VendorStatuses maps disbursement statuses reported by the withdraw vendor to withdraw statuses.
+var VendorStatuses = map[string]WithdrawStatus{
+	"PENDING":   StatusPending,
+	"ACCEPTED":  StatusPending,
+	"COMPLETED": StatusSuccess,
+	"SUCCEEDED": StatusSuccess,
+	"FAILED":    StatusFailed,
+	"REVERSED":  StatusFailed,
+}
+
 var WithdrawNotFound = errors.New("Withdraw not found")
+var UnmatchBalance = errors.New("The withdraw owner must match the account balance.")
+var AlreadyFinalized = errors.New("Withdraw is already finalized with a different status")
+var UnknownVendorStatus = errors.New("Unknown withdraw vendor status")
+var VendorRequired = errors.New("Vendor is required.")
+var UnmatchVendor = errors.New("The vendor must match the vendor the withdraw was created with")
+var InvalidAmountRange = errors.New("Search amount range must not be negative or end below its start")
+var InvalidDateRange = errors.New("Search date range must end after it starts")
+
+type SearchFilter struct {
+	OrganizationUUID string
+	BalanceUUID      string
+	Statuses         []WithdrawStatus
+	MinAmount        int64
+	MaxAmount        int64
+	CreatedFrom      time.Time
+	CreatedTo        time.Time
+	VendorRecordID   string
+	VendorCode       string
+	Cursor           string
+	Limit            int64
+}
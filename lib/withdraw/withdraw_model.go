@@ -3,20 +3,33 @@ package withdraw
 import (
 	"github.com/21strive/redifu"
 	"paystore/lib/balance"
+	"paystore/lib/fee"
+	"paystore/lib/helper"
 	"paystore/lib/organization"
+	"paystore/lib/schema"
 	"time"
 )
 
 type Withdraw struct {
 	*redifu.Record
 	Amount               int64          `json:"amount"`
+	Fees                 int64          `json:"fees"`
 	BalanceBeforePayment int64          `json:"balanceBeforePayment"`
 	BalanceAfterPayment  int64          `json:"balanceAfterPayment"`
 	BalanceUUID          string         `json:"BalanceUUID"`
 	OrganizationUUID     string         `json:"organizationUUID"`
 	VendorRecordID       string         `json:"vendorRecordID"`
+	VendorCode           string         `json:"vendorCode"`
+	Channel              string         `json:"channel"`
+	FeeBreakdown         fee.Breakdown  `json:"feeBreakdown"`
 	Status               WithdrawStatus `json:"status"`
 	Hash                 string         `json:"hash"`
+	FailureCode          string         `json:"failureCode,omitempty"`
+	PollAttempts         int64          `json:"pollAttempts"`
+	NextPollAt           time.Time      `json:"nextPollAt"`
+	FinalizedAt          time.Time      `json:"finalizedAt,omitempty"`
+	WithdrawVendorRandId string         `json:"vendorRandId,omitempty"`
+	WithdrawVendor       *schema.Record `json:"vendor,omitempty"`
 }
 
 type WithdrawHashPayload struct {
@@ -37,26 +50,89 @@ func (w *Withdraw) SetBalance(balance *balance.Balance) {
 	w.BalanceUUID = balance.UUID
 }
 
-func (w *Withdraw) SetAmount(amount int64, currentBalanceAmount int64) {
+// SetAmount debits amount plus the fee in breakdown; the vendor's transfer fee is passed on
+// to the balance rather than taken out of the amount sent.
+func (w *Withdraw) SetAmount(amount int64, currentBalanceAmount int64, breakdown fee.Breakdown) {
 	w.Amount = amount
-	w.BalanceBeforePayment = currentBalanceAmount
-	w.BalanceAfterPayment = w.BalanceBeforePayment - w.Amount
+	w.Fees = breakdown.Total
+	w.FeeBreakdown = breakdown
+	w.SetBalanceSnapshot(currentBalanceAmount)
+}
+
+// SetBalanceSnapshot records the balance just before the withdraw is debited from it. A
+// pending withdraw does not move the balance, so the snapshot taken when it is created is
+// taken again when it succeeds.
+func (w *Withdraw) SetBalanceSnapshot(balanceBefore int64) {
+	w.BalanceBeforePayment = balanceBefore
+	w.BalanceAfterPayment = balanceBefore - w.Debit()
+}
+
+// Debit is what settling the withdraw takes from the balance.
+func (w *Withdraw) Debit() int64 {
+	return w.Amount + w.Fees
 }
 
 func (w *Withdraw) SetOrganization(organization *organization.Organization) {
 	w.OrganizationUUID = organization.UUID
 }
 
-func (w *Withdraw) SetVendorRecord(uuid string) {
+// SetVendorRecord records the vendor record the withdraw settles against. An empty vendor
+// code keeps the vendor the withdraw was created with. Like every other change to the
+// withdraw it bumps updated_at, so the ledger export picks it up again.
+func (w *Withdraw) SetVendorRecord(vendorCode string, uuid string) {
+	if vendorCode != "" {
+		w.VendorCode = vendorCode
+	}
 	w.VendorRecordID = uuid
+	w.SetUpdatedAt(time.Now())
+}
+
+// CheckVendor rejects finalizing the withdraw for a vendor other than the one it was created
+// with. An empty vendorCode keeps the withdraw's own vendor, and withdraws recorded before
+// vendors were tracked carry no code and belong to defaultVendorCode.
+func (w *Withdraw) CheckVendor(vendorCode string, defaultVendorCode string) error {
+	createdWith := w.VendorCode
+	if createdWith == "" {
+		createdWith = defaultVendorCode
+	}
+	if vendorCode != "" && vendorCode != createdWith {
+		return UnmatchVendor
+	}
+	return nil
 }
 
 func (w *Withdraw) SetSuccess() {
 	w.Status = StatusSuccess
+	w.setFinalized(time.Now())
 }
 
 func (w *Withdraw) SetFailed() {
 	w.Status = StatusFailed
+	w.setFinalized(time.Now())
+}
+
+// setFinalized records when the withdraw reached its final status. Settlement counts the
+// withdraw on that day, however the row is updated afterwards.
+func (w *Withdraw) setFinalized(at time.Time) {
+	w.FinalizedAt = at
+	w.SetUpdatedAt(at)
+}
+
+func (w *Withdraw) SetReview() {
+	w.Status = StatusReview
+	w.SetUpdatedAt(time.Now())
+}
+
+// SchedulePoll records a status poll that did not settle the withdraw and sets when to poll next.
+func (w *Withdraw) SchedulePoll(nextPollAt time.Time) {
+	w.PollAttempts++
+	w.NextPollAt = nextPollAt
+	w.SetUpdatedAt(time.Now())
+}
+
+func (w *Withdraw) SetFailureCode(failureCode string) {
+	w.FailureCode = failureCode
+	w.SetUpdatedAt(time.Now())
 }
 
 func (w *Withdraw) ScanDestinations() []interface{} {
@@ -73,6 +149,14 @@ func (w *Withdraw) ScanDestinations() []interface{} {
 		&w.VendorRecordID,
 		&w.Status,
 		&w.Hash,
+		&w.FailureCode,
+		&w.PollAttempts,
+		&w.NextPollAt,
+		&w.VendorCode,
+		&w.Fees,
+		&w.Channel,
+		&w.FeeBreakdown,
+		helper.NullableDestinations([]interface{}{&w.FinalizedAt})[0],
 	}
 }
 
@@ -80,5 +164,17 @@ func NewWithdraw() *Withdraw {
 	withdraw := &Withdraw{}
 	redifu.InitRecord(withdraw)
 	withdraw.Status = StatusPending
+	withdraw.NextPollAt = withdraw.GetCreatedAt()
 	return withdraw
 }
+
+// Validate rejects a filter whose amount or creation date range cannot match anything.
+func (f SearchFilter) Validate() error {
+	if f.MinAmount < 0 || f.MaxAmount < 0 || (f.MaxAmount > 0 && f.MinAmount > f.MaxAmount) {
+		return InvalidAmountRange
+	}
+	if !f.CreatedFrom.IsZero() && !f.CreatedTo.IsZero() && !f.CreatedTo.After(f.CreatedFrom) {
+		return InvalidDateRange
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package withdraw
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWithdrawCheckVendor(t *testing.T) {
+	tests := []struct {
+		name       string
+		createdVia string
+		vendorCode string
+		want       error
+	}{
+		{"same vendor", "xendit", "xendit", nil},
+		{"kept vendor", "xendit", "", nil},
+		{"other vendor", "xendit", "flip", UnmatchVendor},
+		{"recorded before vendors, default vendor", "", "xendit", nil},
+		{"recorded before vendors, other vendor", "", "flip", UnmatchVendor},
+	}
+	for _, test := range tests {
+		checked := NewWithdraw()
+		checked.VendorCode = test.createdVia
+		if errVendor := checked.CheckVendor(test.vendorCode, "xendit"); errVendor != test.want {
+			t.Errorf("%s: CheckVendor = %v, want %v", test.name, errVendor, test.want)
+		}
+	}
+}
+
+func TestSearchFilterValidate(t *testing.T) {
+	day := time.Date(2026, 5, 10, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		filter SearchFilter
+		want   error
+	}{
+		{"no filter", SearchFilter{}, nil},
+		{"amount range", SearchFilter{MinAmount: 100, MaxAmount: 100}, nil},
+		{"open amount range", SearchFilter{MinAmount: 100}, nil},
+		{"negative amount", SearchFilter{MinAmount: -1}, InvalidAmountRange},
+		{"inverted amount range", SearchFilter{MinAmount: 200, MaxAmount: 100}, InvalidAmountRange},
+		{"date range", SearchFilter{CreatedFrom: day, CreatedTo: day.Add(time.Hour)}, nil},
+		{"open date range", SearchFilter{CreatedTo: day}, nil},
+		{"empty date range", SearchFilter{CreatedFrom: day, CreatedTo: day}, InvalidDateRange},
+		{"inverted date range", SearchFilter{CreatedFrom: day, CreatedTo: day.Add(-time.Hour)}, InvalidDateRange},
+	}
+	for _, test := range tests {
+		if errValidate := test.filter.Validate(); errValidate != test.want {
+			t.Errorf("%s: Validate = %v, want %v", test.name, errValidate, test.want)
+		}
+	}
+}
@@ -7,23 +7,38 @@ import (
 	"paystore/config"
 	"paystore/lib/balance"
 	"paystore/lib/builder"
+	"paystore/lib/helper"
 	"paystore/lib/organization"
+	"paystore/lib/schema"
 	"paystore/lib/transaction"
-	vendorModel "paystore/user"
+	"strconv"
+	"time"
 )
 
-var firstPartSelectQuery = `SELECT w.uuid, w.randid, w.created_at, w.updated_at, w.amount, w.balance_before_withdraw, w.balance_after_withdraw, w.balance_uuid, w.organization_uuid, w.vendor_record_id, w.status, w.hash`
+var firstPartSelectQuery = `SELECT w.uuid, w.randid, w.created_at, w.updated_at, w.amount, w.balance_before_withdraw, w.balance_after_withdraw, w.balance_uuid, w.organization_uuid, w.vendor_record_id, w.status, w.hash, w.failure_code, w.poll_attempts, w.next_poll_at, w.vendor_code, w.fees, w.channel, w.fee_breakdown, w.finalized_at`
 var findWithdrawByUUIDQuery = firstPartSelectQuery + ` FROM withdraw w WHERE w.uuid = $1;`
+var findWithdrawByUUIDForUpdateQuery = firstPartSelectQuery + ` FROM withdraw w WHERE w.uuid = $1 FOR UPDATE;`
+var findStuckQuery = firstPartSelectQuery + ` FROM withdraw w WHERE w.status = $1 AND w.created_at < $2
+	AND w.next_poll_at <= $3 ORDER BY w.next_poll_at LIMIT $4`
 
 type RepositoryClient interface {
 	Create(tx *sql.Tx, withdraw *Withdraw, balance *balance.Balance, organization *organization.Organization) error
 	Update(tx *sql.Tx, withdraw *Withdraw) error
 	FindByUUID(uuid string) (*Withdraw, error)
+	FindByUUIDForUpdate(tx *sql.Tx, uuid string) (*Withdraw, error)
+	UpsertVendor(tx *sql.Tx, vendor *schema.Record) error
+	SetCache(withdraw *Withdraw) error
+	AddToTimeline(withdraw *Withdraw, organization *organization.Organization, balance *balance.Balance) error
+	SetVendorCache(vendor *schema.Record) error
 	SeedPartialByBalance(subtraction int64, lastRandId string, balance *balance.Balance) error
+	Search(filter SearchFilter) ([]*Withdraw, string, error)
+	FindStuck(createdBefore time.Time, now time.Time, limit int64) ([]*Withdraw, error)
 }
 
 type Repository struct {
+	readDB                  *sql.DB
 	base                    *redifu.Base[*Withdraw]
+	vendorRepository        *VendorRepository
 	timelineByBalance       *redifu.Timeline[*Withdraw]
 	timelineSeederByBalance *redifu.TimelineSeeder[*Withdraw]
 	findWithdrawByUUIDStmt  *sql.Stmt
@@ -37,36 +52,45 @@ func (r *Repository) Close() {
 func (r *Repository) Create(tx *sql.Tx, withdraw *Withdraw, balance *balance.Balance,
 	organization *organization.Organization) error {
 	query := `
-		INSERT INTO withdraw (uuid, randid, created_at, updated_at, amount, balance_before_payment, 
-		balance_after_payment, balance_uuid, organization_uuid, vendor_record_id, status, hash) 
-		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+		INSERT INTO withdraw (uuid, randid, created_at, updated_at, amount, balance_before_withdraw, 
+		balance_after_withdraw, balance_uuid, organization_uuid, vendor_record_id, status, hash, next_poll_at,
+		vendor_code, fees, channel, fee_breakdown) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)`
 
 	_, err := tx.Exec(query, withdraw.GetUUID(), withdraw.GetRandId(), withdraw.GetCreatedAt(),
 		withdraw.GetUpdatedAt(), withdraw.Amount, withdraw.BalanceBeforePayment, withdraw.BalanceAfterPayment,
-		withdraw.BalanceUUID, withdraw.OrganizationUUID, withdraw.VendorRecordID, withdraw.Status, withdraw.Hash)
-	if err != nil {
-		return err
-	}
-
-	errSet := r.base.Set(withdraw)
-	if errSet != nil {
-		return errSet
-	}
-
-	r.timelineByBalance.AddItem(withdraw, []string{organization.GetRandId(), balance.GetRandId()})
-	return nil
+		withdraw.BalanceUUID, withdraw.OrganizationUUID, withdraw.VendorRecordID, withdraw.Status, withdraw.Hash,
+		withdraw.NextPollAt, withdraw.VendorCode, withdraw.Fees, withdraw.Channel, withdraw.FeeBreakdown)
+	return err
 }
 
 func (r *Repository) Update(tx *sql.Tx, withdraw *Withdraw) error {
-	query := `UPDATE withdraw SET updated_at = $1, status = $2, hash = $3 WHERE uuid = $4`
-	_, errExec := tx.Exec(query, withdraw.GetUpdatedAt(), withdraw.Status, withdraw.Hash, withdraw.GetUUID())
-	if errExec != nil {
-		return errExec
-	}
+	query := `UPDATE withdraw SET updated_at = $1, vendor_record_id = $2, status = $3, hash = $4, 
+                    failure_code = $5, poll_attempts = $6, next_poll_at = $7, vendor_code = $8, finalized_at = $9,
+                    balance_before_withdraw = $10, balance_after_withdraw = $11 WHERE uuid = $12`
+	_, errExec := tx.Exec(query, withdraw.GetUpdatedAt(), withdraw.VendorRecordID, withdraw.Status, withdraw.Hash,
+		withdraw.FailureCode, withdraw.PollAttempts, withdraw.NextPollAt, withdraw.VendorCode,
+		nullTime(withdraw.FinalizedAt), withdraw.BalanceBeforePayment, withdraw.BalanceAfterPayment, withdraw.GetUUID())
+	return errExec
+}
 
+// SetCache stores the withdraw in the cache. Create and Update only write the row, so callers
+// cache the withdraw once the transaction that wrote it has committed.
+func (r *Repository) SetCache(withdraw *Withdraw) error {
 	return r.base.Set(withdraw)
 }
 
+func (r *Repository) AddToTimeline(withdraw *Withdraw, organization *organization.Organization,
+	balance *balance.Balance) error {
+	return r.timelineByBalance.AddItem(withdraw, []string{organization.GetRandId(), balance.GetRandId()})
+}
+
+// SetVendorCache stores the vendor record in the cache once the transaction that upserted it
+// has committed.
+func (r *Repository) SetVendorCache(vendor *schema.Record) error {
+	return r.vendorRepository.SetCache(vendor)
+}
+
 func (r *Repository) FindByUUID(uuid string) (*Withdraw, error) {
 	withdraw, err := WithdrawRowScanner(r.findWithdrawByUUIDStmt.QueryRow(uuid))
 	if err != nil {
@@ -79,16 +103,139 @@ func (r *Repository) FindByUUID(uuid string) (*Withdraw, error) {
 	return withdraw, nil
 }
 
+func (r *Repository) FindByUUIDForUpdate(tx *sql.Tx, uuid string) (*Withdraw, error) {
+	withdraw, err := WithdrawRowScanner(tx.QueryRow(findWithdrawByUUIDForUpdateQuery, uuid))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, WithdrawNotFound
+		}
+		return nil, err
+	}
+
+	return withdraw, nil
+}
+
+func (r *Repository) UpsertVendor(tx *sql.Tx, vendor *schema.Record) error {
+	return r.vendorRepository.Upsert(tx, vendor)
+}
+
 func (r *Repository) SeedPartialByBalance(subtraction int64, lastRandId string, balance *balance.Balance) error {
-	joinedQuery := builder.JoinBuilder(firstPartSelectQuery, transaction.TypeWithdraw, r.AppConfig)
+	// The balance is $1 and the vendor codes follow it; the seeder appends the page cursor last.
+	joinedQuery, vendorArgs := builder.JoinBuilder(firstPartSelectQuery, transaction.TypeWithdraw, r.AppConfig, 2)
+	queryArgs := append([]interface{}{balance.GetUUID()}, vendorArgs...)
+	cursorPlaceholder := "$" + strconv.Itoa(len(queryArgs)+1)
 
-	rowQuery := joinedQuery + " WHERE w.randid = $1"
-	firstPageQuery := joinedQuery + " WHERE w.balance_uuid = $1 ORDER BY created_at DESC"
-	nextPageQuery := joinedQuery + " WHERE w.balance_uuid = $1 AND created_at < $2 ORDER BY created_at DESC"
+	rowQuery := firstPartSelectQuery + " FROM withdraw w WHERE w.randid = $1"
+	firstPageQuery := joinedQuery + " WHERE w.balance_uuid = $1 ORDER BY w.created_at DESC"
+	nextPageQuery := joinedQuery + " WHERE w.balance_uuid = $1 AND w.created_at < " + cursorPlaceholder + " ORDER BY w.created_at DESC"
 
 	return r.timelineSeederByBalance.SeedPartialWithRelation(
-		rowQuery, firstPageQuery, nextPageQuery, WithdrawRowScanner, WithdrawRowsScanner,
-		[]interface{}{balance.GetUUID()}, subtraction, lastRandId, []string{balance.GetRandId()})
+		rowQuery, firstPageQuery, nextPageQuery, WithdrawRowScanner, WithdrawRowsScanner(r.AppConfig.StoredWithdrawVendors()),
+		queryArgs, subtraction, lastRandId, []string{balance.GetRandId()})
+}
+
+func (r *Repository) Search(filter SearchFilter) ([]*Withdraw, string, error) {
+	errValidate := filter.Validate()
+	if errValidate != nil {
+		return nil, "", errValidate
+	}
+
+	limit := filter.Limit
+	if limit <= 0 || limit > r.AppConfig.ItemPerPage {
+		limit = r.AppConfig.ItemPerPage
+	}
+
+	filterBuilder := builder.NewFilterBuilder()
+	if filter.OrganizationUUID != "" {
+		filterBuilder.Where("w.organization_uuid", "=", filter.OrganizationUUID)
+	}
+	if filter.BalanceUUID != "" {
+		filterBuilder.Where("w.balance_uuid", "=", filter.BalanceUUID)
+	}
+	if len(filter.Statuses) > 0 {
+		var statuses []interface{}
+		for _, status := range filter.Statuses {
+			statuses = append(statuses, status)
+		}
+		filterBuilder.WhereIn("w.status", statuses)
+	}
+	if filter.MinAmount > 0 {
+		filterBuilder.Where("w.amount", ">=", filter.MinAmount)
+	}
+	if filter.MaxAmount > 0 {
+		filterBuilder.Where("w.amount", "<=", filter.MaxAmount)
+	}
+	if !filter.CreatedFrom.IsZero() {
+		filterBuilder.Where("w.created_at", ">=", filter.CreatedFrom)
+	}
+	if !filter.CreatedTo.IsZero() {
+		filterBuilder.Where("w.created_at", "<", filter.CreatedTo)
+	}
+	if filter.VendorRecordID != "" {
+		filterBuilder.Where("w.vendor_record_id", "=", filter.VendorRecordID)
+	}
+	if filter.Cursor != "" {
+		cursorTime, cursorUUID, errDecode := helper.DecodeCursor(filter.Cursor)
+		if errDecode != nil {
+			return nil, "", errDecode
+		}
+		filterBuilder.WhereBefore("w.created_at", "w.uuid", cursorTime, cursorUUID)
+	}
+
+	query := firstPartSelectQuery + ` FROM withdraw w` + filterBuilder.Clause() +
+		` ORDER BY w.created_at DESC, w.uuid DESC` + filterBuilder.Limit(limit+1)
+	rows, errQuery := r.readDB.Query(query, filterBuilder.Args()...)
+	if errQuery != nil {
+		return nil, "", errQuery
+	}
+	defer rows.Close()
+
+	var withdraws []*Withdraw
+	for rows.Next() {
+		withdraw := NewWithdraw()
+		errScan := rows.Scan(withdraw.ScanDestinations()...)
+		if errScan != nil {
+			return nil, "", errScan
+		}
+		withdraws = append(withdraws, withdraw)
+	}
+	if errRows := rows.Err(); errRows != nil {
+		return nil, "", errRows
+	}
+
+	var nextCursor string
+	if int64(len(withdraws)) > limit {
+		withdraws = withdraws[:limit]
+		lastWithdraw := withdraws[len(withdraws)-1]
+		nextCursor = helper.EncodeCursor(lastWithdraw.GetCreatedAt(), lastWithdraw.GetUUID())
+	}
+
+	return withdraws, nextCursor, nil
+}
+
+// FindStuck returns withdraws still pending since before createdBefore whose next status
+// poll is due, least recently polled first.
+func (r *Repository) FindStuck(createdBefore time.Time, now time.Time, limit int64) ([]*Withdraw, error) {
+	rows, errQuery := r.readDB.Query(findStuckQuery, StatusPending, createdBefore, now, limit)
+	if errQuery != nil {
+		return nil, errQuery
+	}
+	defer rows.Close()
+
+	var withdraws []*Withdraw
+	for rows.Next() {
+		withdraw := NewWithdraw()
+		errScan := rows.Scan(withdraw.ScanDestinations()...)
+		if errScan != nil {
+			return nil, errScan
+		}
+		withdraws = append(withdraws, withdraw)
+	}
+	if errRows := rows.Err(); errRows != nil {
+		return nil, errRows
+	}
+
+	return withdraws, nil
 }
 
 func WithdrawRowScanner(row *sql.Row) (*Withdraw, error) {
@@ -97,34 +244,57 @@ func WithdrawRowScanner(row *sql.Row) (*Withdraw, error) {
 	return withdraw, err
 }
 
-func WithdrawRowsScanner(rows *sql.Rows, relation map[string]redifu.Relation) (*Withdraw, error) {
-	withdraw := NewWithdraw()
-	withdrawVendor := vendorModel.NewWithdrawVendor()
+// WithdrawRowsScanner scans rows of the joined timeline query, which carries the columns of
+// every vendor in vendors, in order. Only the vendor that processed the withdraw joins a row.
+func WithdrawRowsScanner(vendors []*config.Vendor) func(rows *sql.Rows, relation map[string]redifu.Relation) (*Withdraw, error) {
+	return func(rows *sql.Rows, relation map[string]redifu.Relation) (*Withdraw, error) {
+		withdraw := NewWithdraw()
 
-	var scanDestinations []interface{}
-	scanDestinations = append(scanDestinations, withdraw.ScanDestinations()...)
-	scanDestinations = append(scanDestinations, withdrawVendor.ScanDestionations()...)
+		var scanDestinations []interface{}
+		scanDestinations = append(scanDestinations, withdraw.ScanDestinations()...)
+		var withdrawVendors []*schema.Record
+		for _, vendor := range vendors {
+			withdrawVendor := vendor.Schema.NewRecord()
+			withdrawVendor.UUID = ""
+			withdrawVendors = append(withdrawVendors, withdrawVendor)
+			scanDestinations = append(scanDestinations, helper.NullableDestinations(withdrawVendor.ScanDestinations())...)
+		}
 
-	err := rows.Scan(scanDestinations...)
-	if err != nil {
-		return nil, err
-	}
+		err := rows.Scan(scanDestinations...)
+		if err != nil {
+			return nil, err
+		}
 
-	if withdrawVendor.UUID != "" {
-		errSet := relation["vendor"].SetItem(withdrawVendor)
-		if errSet != nil {
-			return nil, errSet
+		for _, withdrawVendor := range withdrawVendors {
+			if withdrawVendor.UUID == "" {
+				continue
+			}
+			errSet := relation["vendor"].SetItem(withdrawVendor)
+			if errSet != nil {
+				return nil, errSet
+			}
+			withdraw.WithdrawVendorRandId = withdrawVendor.GetRandId()
+			break
 		}
+
+		return withdraw, nil
 	}
+}
 
-	return withdraw, nil
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
 }
 
 func NewRepository(readDB *sql.DB, redis redis.UniversalClient, config *config.App) *Repository {
+	vendorRepo := NewVendorRepository(redis, config)
+	vendorRelation := redifu.NewRelation[*schema.Record](vendorRepo.GetBase(),
+		"WithdrawVendor", "WithdrawVendorRandId")
+
 	base := redifu.NewBase[*Withdraw](redis, "withdraw:%s", config.RecordAge)
 	timelineByBalance := redifu.NewTimeline[*Withdraw](redis, base,
 		"withdraw:organization:%s:balance:%s", config.ItemPerPage, redifu.Descending, config.PaginationAge)
-	timelineSeederByBalance := redifu.NewTimelineSeeder[*Withdraw](nil, base, timelineByBalance)
+	timelineByBalance.AddRelation("vendor", vendorRelation)
+	timelineSeederByBalance := redifu.NewTimelineSeeder[*Withdraw](readDB, base, timelineByBalance)
 
 	findWithdrawByUUIDStmt, err := readDB.Prepare(findWithdrawByUUIDQuery)
 	if err != nil {
@@ -132,10 +302,75 @@ func NewRepository(readDB *sql.DB, redis redis.UniversalClient, config *config.A
 	}
 
 	return &Repository{
+		readDB:                  readDB,
 		base:                    base,
+		vendorRepository:        vendorRepo,
 		timelineByBalance:       timelineByBalance,
 		timelineSeederByBalance: timelineSeederByBalance,
 		findWithdrawByUUIDStmt:  findWithdrawByUUIDStmt,
 		AppConfig:               config,
 	}
 }
+
+type VendorRepository struct {
+	base          *redifu.Base[*schema.Record]
+	upsertQuery   map[string]string
+	defaultVendor string
+}
+
+func (r *VendorRepository) GetBase() *redifu.Base[*schema.Record] {
+	return r.base
+}
+
+// Upsert keys vendor rows on the vendor's own ID so repeated webhook deliveries update one row.
+// The row goes to the table of the vendor the record was decoded for.
+func (r *VendorRepository) Upsert(tx *sql.Tx, vendor *schema.Record) error {
+	vendorCode := vendor.VendorCode
+	if vendorCode == "" {
+		vendorCode = r.defaultVendor
+	}
+	upsertQuery, found := r.upsertQuery[vendorCode]
+	if !found {
+		return VendorRequired
+	}
+
+	_, errExec := tx.Exec(upsertQuery, vendor.QueryArgs()...)
+	return errExec
+}
+
+func (r *VendorRepository) SetCache(vendor *schema.Record) error {
+	return r.base.Set(vendor)
+}
+
+// CreateVendorTable creates a table for every stored withdraw vendor from its schema.
+func CreateVendorTable(writeDB *sql.DB, config *config.App) error {
+	for _, vendor := range config.StoredWithdrawVendors() {
+		_, errExec := writeDB.Exec(builder.CreateTableBuilder(vendor.TableName, vendor.ColumnDefinitions(),
+			vendor.IDColumn()))
+		if errExec != nil {
+			return errExec
+		}
+	}
+	return nil
+}
+
+func NewVendorRepository(redis redis.UniversalClient, config *config.App) *VendorRepository {
+	base := redifu.NewBase[*schema.Record](redis, "withdraw-vendor-item:%s", config.RecordAge)
+
+	upsertQuery := make(map[string]string)
+	for _, vendor := range config.StoredWithdrawVendors() {
+		upsertQuery[vendor.Code] = builder.UpsertBuilder(vendor.TableName, vendor.ColumnNames(),
+			vendor.IDColumn(), []string{"uuid", "randid", "created_at"})
+	}
+
+	var defaultVendor string
+	if len(config.WithdrawVendors) > 0 {
+		defaultVendor = config.WithdrawVendors[0].Code
+	}
+
+	return &VendorRepository{
+		base:          base,
+		upsertQuery:   upsertQuery,
+		defaultVendor: defaultVendor,
+	}
+}
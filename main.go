@@ -9,9 +9,19 @@ import (
 	"net"
 	"os"
 	"paystore/config"
+	"paystore/fetch"
 	"paystore/lib/helper"
+	"paystore/lib/payment"
+	"paystore/lib/provider"
+	"paystore/lib/schema"
+	"paystore/lib/webhook"
+	"paystore/lib/withdraw"
 	"paystore/operation"
 	pb "paystore/protos"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
 	_ "github.com/lib/pq"
 )
@@ -29,9 +39,92 @@ func main() {
 		os.Getenv("REDIS_PASS"), false)
 
 	config := config.DefaultConfig(os.Getenv("PAYMENT_VENDOR_TABLE_NAME"), os.Getenv("WITHDRAW_VENDOR_TABLE_NAME"))
+	if schemaFile := os.Getenv("VENDOR_SCHEMA_FILE"); schemaFile != "" {
+		vendorSchemas, errLoad := schema.Load(schemaFile)
+		if errLoad != nil {
+			log.Fatalf("Failed to load vendor schema: %v", errLoad)
+		}
+		config.SetVendorSchemas(vendorSchemas)
+	}
+	for _, vendor := range config.PaymentVendors {
+		vendor.WebhookSecret, vendor.WebhookToken = webhookCredentials("PAYMENT", vendor)
+	}
+	for _, vendor := range config.WithdrawVendors {
+		vendor.WebhookSecret, vendor.WebhookToken = webhookCredentials("WITHDRAW", vendor)
+	}
+
+	if errMigrate := payment.CreateVendorTable(writeDB, config); errMigrate != nil {
+		log.Fatalf("Failed to create payment vendor table: %v", errMigrate)
+	}
+	if errMigrate := withdraw.CreateVendorTable(writeDB, config); errMigrate != nil {
+		log.Fatalf("Failed to create withdraw vendor table: %v", errMigrate)
+	}
 
 	// GRPC Setup
 	paystoreClient := operation.New(writeDB, readDB, redis, config)
+	disbursementWired := false
+	if secretKey := os.Getenv("XENDIT_SECRET_KEY"); secretKey != "" {
+		xenditVendor, errVendor := config.PaymentVendor(os.Getenv("XENDIT_VENDOR_CODE"))
+		if errVendor != nil {
+			log.Fatalf("Failed to find Xendit payment vendor: %v", errVendor)
+		}
+		paymentProvider := provider.NewXenditInvoiceProvider(os.Getenv("XENDIT_BASE_URL"), secretKey, nil,
+			xenditVendor.Schema)
+		invoiceDuration := config.InvoiceDuration
+		if duration, errParse := time.ParseDuration(os.Getenv("XENDIT_INVOICE_DURATION")); errParse == nil && duration > 0 {
+			invoiceDuration = duration
+		}
+		paystoreClient.SetPaymentProvider(xenditVendor.Code, paymentProvider, invoiceDuration)
+
+		xenditWithdrawVendor, errVendor := config.WithdrawVendor(os.Getenv("XENDIT_WITHDRAW_VENDOR_CODE"))
+		if errVendor != nil {
+			log.Fatalf("Failed to find Xendit withdraw vendor: %v", errVendor)
+		}
+		disbursementProvider := provider.NewXenditDisbursementProvider(os.Getenv("XENDIT_BASE_URL"), secretKey, nil,
+			xenditWithdrawVendor.Schema)
+		paystoreClient.SetDisbursementProvider(xenditWithdrawVendor.Code, disbursementProvider)
+		disbursementWired = true
+	}
+	if interval, errParse := time.ParseDuration(os.Getenv("RECONCILIATION_INTERVAL")); errParse == nil && interval > 0 {
+		config.ReconciliationInterval = interval
+		reconciliationJob := operation.NewReconciliationJob(paystoreClient, config.ReconciliationInterval,
+			config.ReconciliationLag, os.Getenv("RECONCILIATION_AUTO_FINALIZE") == "true")
+		reconciliationJob.Start()
+		defer reconciliationJob.Stop()
+	}
+	if ttl, errParse := time.ParseDuration(os.Getenv("PAYMENT_TTL")); errParse == nil && ttl > 0 {
+		config.PaymentTTL = ttl
+	}
+	expiryJob := operation.NewExpiryJob(paystoreClient, redis, config.ExpirySweepInterval, config.PaymentTTL,
+		config.ExpirySweepBatchSize)
+	expiryJob.Start()
+	defer expiryJob.Stop()
+	// Stuck withdraws can only be polled through a disbursement provider, so polling is skipped
+	// without one; WITHDRAW_POLL_DISABLED turns it off even when a provider is wired.
+	if !disbursementWired {
+		log.Printf("No disbursement provider is wired; withdraw polling is not started")
+	} else if os.Getenv("WITHDRAW_POLL_DISABLED") != "true" {
+		withdrawPollJob := operation.NewWithdrawPollJob(paystoreClient, redis, config)
+		withdrawPollJob.Start()
+		defer withdrawPollJob.Stop()
+	}
+	if interval, errParse := time.ParseDuration(os.Getenv("SETTLEMENT_INTERVAL")); errParse == nil && interval > 0 {
+		config.SettlementInterval = interval
+	}
+	settlementJob := operation.NewSettlementJob(paystoreClient, redis, config)
+	settlementJob.Start()
+	defer settlementJob.Stop()
+	if maxLen, errParse := strconv.ParseInt(os.Getenv("OUTBOX_STREAM_MAXLEN"), 10, 64); errParse == nil && maxLen > 0 {
+		config.OutboxStreamMaxLen = maxLen
+	}
+	outboxRelay := operation.NewOutboxRelay(writeDB, redis, config)
+	outboxRelay.Start()
+	defer outboxRelay.Stop()
+	webhookDispatcher := operation.NewWebhookDispatcher(redis, webhook.NewRepository(writeDB, readDB, config), config)
+	if errStart := webhookDispatcher.Start(); errStart != nil {
+		log.Fatalf("Failed to start webhook dispatcher: %v", errStart)
+	}
+	defer webhookDispatcher.Stop()
 	grpcServer := grpc.NewServer()
 	grpcHandler := operation.NewGRPCHandler(paystoreClient)
 	pb.RegisterPaystoreServer(grpcServer, grpcHandler)
@@ -55,5 +148,28 @@ func main() {
 
 	app := fiber.New()
 
+	// HTTP Setup
+	paystoreFetcher := fetch.NewFetcher(readDB, redis, config)
+	httpFetcherHandler := fetch.NewHTTPFetcherHandler(paystoreFetcher)
+	httpFetcherHandler.RegisterRoutes(app)
+	httpWebhookHandler := operation.NewHTTPWebhookHandler(paystoreClient, config)
+	httpWebhookHandler.RegisterRoutes(app)
+
 	app.Listen(":" + os.Getenv("PORT"))
 }
+
+// webhookCredentials reads the vendor's webhook secret and token from <PREFIX>_WEBHOOK_SECRET_<CODE>
+// and <PREFIX>_WEBHOOK_TOKEN_<CODE>. The default vendor falls back to the unsuffixed variables
+// used before vendors had their own credentials.
+func webhookCredentials(prefix string, vendor *config.Vendor) (string, string) {
+	suffix := "_" + strings.ToUpper(nonAlphanumeric.ReplaceAllString(vendor.Code, "_"))
+	secret := os.Getenv(prefix + "_WEBHOOK_SECRET" + suffix)
+	token := os.Getenv(prefix + "_WEBHOOK_TOKEN" + suffix)
+	if vendor.Default && secret == "" && token == "" {
+		secret = os.Getenv(prefix + "_WEBHOOK_SECRET")
+		token = os.Getenv(prefix + "_WEBHOOK_TOKEN")
+	}
+	return secret, token
+}
+
+var nonAlphanumeric = regexp.MustCompile(`[^A-Za-z0-9]+`)
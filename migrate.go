@@ -14,12 +14,15 @@ var createTableBalance = `
 		currency VARCHAR(3) NOT NULL,
 		active BOOL NOT NULL DEFAULT true,
 		external_id VARCHAR(255),
-		organization_uuid UUID NOT NULL
+		organization_uuid UUID NOT NULL,
+		kind VARCHAR(20) NOT NULL DEFAULT 'merchant'
     );
 
     -- Indexes for better query performance
     CREATE INDEX idx_accounts_organization_uuid (organization_uuid);
     CREATE INDEX idx_accounts_external_id (external_id);
+    CREATE UNIQUE INDEX idx_accounts_revenue_organization_currency ON balance(organization_uuid, currency)
+        WHERE kind = 'revenue';
 `
 
 var createTableQuery = `
@@ -39,13 +42,25 @@ var createTableQuery = `
 			organization_uuid VARCHAR(255) NOT NULL,
 			vendor_record_id VARCHAR(255) NOT NULL,
 			status VARCHAR(20) NOT NULL,
-			hash VARCHAR(255) NOT NULL
+			hash VARCHAR(255) NOT NULL,
+			vendor_code VARCHAR(50) NOT NULL DEFAULT '',
+			channel VARCHAR(50) NOT NULL DEFAULT '',
+			fee_breakdown JSONB NOT NULL DEFAULT '{}',
+			fee_schedule_uuid VARCHAR(255) NOT NULL DEFAULT '',
+			parent_payment_uuid VARCHAR(255) NOT NULL DEFAULT '',
+			finalized_at TIMESTAMP
 		);
 		
 		-- Indexes for common queries
 		CREATE INDEX idx_payments_balance_uuid ON payment(balance_uuid);
 		CREATE INDEX idx_payments_created_at ON payment(created_at);
 		CREATE INDEX idx_payments_hash ON payment(hash);
+		CREATE INDEX idx_payments_organization_created_at ON payment(organization_uuid, created_at DESC, uuid DESC);
+		CREATE INDEX idx_payments_vendor_record_id ON payment(vendor_record_id);
+		CREATE INDEX idx_payments_organization_updated_at ON payment(organization_uuid, updated_at);
+		CREATE INDEX idx_payments_status_created_at ON payment(status, created_at);
+		CREATE INDEX idx_payments_parent_payment_uuid ON payment(parent_payment_uuid);
+		CREATE INDEX idx_payments_finalized_at ON payment(finalized_at);
 `
 
 var createTableOrganization = `
@@ -57,7 +72,81 @@ var createTableOrganization = `
 		name VARCHAR(255) NOT NULL,
 		slug VARCHAR(255) NOT NULL,
 		fees_constant BIGINT NOT NULL DEFAULT 0,
-		fees_type VARCHAR(20) NOT NULL
+		fees_type VARCHAR(20) NOT NULL,
+		fee_rounding VARCHAR(20) NOT NULL DEFAULT '',
+		parent_uuid VARCHAR(255) NOT NULL DEFAULT '',
+		status VARCHAR(20) NOT NULL DEFAULT 'active',
+		status_reason TEXT NOT NULL DEFAULT '',
+		status_changed_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		fees_override BOOLEAN NOT NULL DEFAULT FALSE
+	);
+
+	CREATE INDEX idx_organizations_parent_uuid ON organization(parent_uuid);
+
+	CREATE TABLE organization_status_change (
+		uuid VARCHAR(255) PRIMARY KEY,
+		randid VARCHAR(255) NOT NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		organization_uuid VARCHAR(255) NOT NULL,
+		from_status VARCHAR(20) NOT NULL,
+		to_status VARCHAR(20) NOT NULL,
+		reason TEXT NOT NULL DEFAULT '',
+		actor VARCHAR(255) NOT NULL DEFAULT ''
+	);
+
+	CREATE INDEX idx_organization_status_changes_organization ON organization_status_change(organization_uuid, created_at);
+`
+
+var createTableFeeSchedule = `
+	CREATE TABLE fee_schedule (
+		uuid VARCHAR(255) PRIMARY KEY,
+		randid VARCHAR(255) NOT NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		organization_uuid VARCHAR(255) NOT NULL,
+		kind VARCHAR(20) NOT NULL DEFAULT 'payment',
+		rules JSONB NOT NULL DEFAULT '[]',
+		effective_from TIMESTAMP NOT NULL,
+		effective_to TIMESTAMP,
+		UNIQUE (organization_uuid, kind, effective_from)
+	);
+`
+
+var createTableFeeRevenue = `
+	CREATE TABLE fee_revenue (
+		uuid VARCHAR(255) PRIMARY KEY,
+		randid VARCHAR(255) NOT NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		balance_uuid VARCHAR(255) NOT NULL,
+		organization_uuid VARCHAR(255) NOT NULL,
+		source VARCHAR(20) NOT NULL DEFAULT 'payment',
+		source_uuid VARCHAR(255) NOT NULL,
+		amount BIGINT NOT NULL,
+		balance_before BIGINT NOT NULL,
+		balance_after BIGINT NOT NULL,
+		UNIQUE (source, source_uuid)
+	);
+
+	CREATE INDEX idx_fee_revenues_balance_created_at ON fee_revenue(balance_uuid, created_at);`
+
+var createTableTransactionLimit = `
+	CREATE TABLE transaction_limit (
+		uuid VARCHAR(255) PRIMARY KEY,
+		randid VARCHAR(255) NOT NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		scope VARCHAR(20) NOT NULL,
+		scope_uuid VARCHAR(255) NOT NULL,
+		max_payment BIGINT NOT NULL DEFAULT 0,
+		max_withdraw BIGINT NOT NULL DEFAULT 0,
+		daily_payment BIGINT NOT NULL DEFAULT 0,
+		monthly_payment BIGINT NOT NULL DEFAULT 0,
+		daily_withdraw BIGINT NOT NULL DEFAULT 0,
+		monthly_withdraw BIGINT NOT NULL DEFAULT 0,
+		daily_withdraw_count BIGINT NOT NULL DEFAULT 0,
+		UNIQUE (scope, scope_uuid)
 	);
 `
 
@@ -70,7 +159,9 @@ var createTableTransaction = `
 		transaction_type VARCHAR(255) NOT NULL, 
 		record_uuid VARCHAR(255) NOT NULL, 
 		balance_uuid VARCHAR(255) NOT NULL
- 	);`
+ 	);
+
+	CREATE INDEX idx_transactions_balance_updated_at ON transaction(balance_uuid, updated_at);`
 
 var createTableWithdraw = `
 	CREATE TABLE withdraw (
@@ -85,5 +176,151 @@ var createTableWithdraw = `
 		organization_uuid VARCHAR(255) NOT NULL,
 		vendor_record_id VARCHAR(255) NOT NULL, 
 		status VARCHAR(20) NOT NULL, 
-		hash VARCHAR(255) NOT NULL
+		hash VARCHAR(255) NOT NULL,
+		failure_code VARCHAR(255) NOT NULL DEFAULT '',
+		poll_attempts BIGINT NOT NULL DEFAULT 0,
+		next_poll_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		vendor_code VARCHAR(50) NOT NULL DEFAULT '',
+		fees BIGINT NOT NULL DEFAULT 0,
+		channel VARCHAR(50) NOT NULL DEFAULT '',
+		fee_breakdown JSONB NOT NULL DEFAULT '{}',
+		finalized_at TIMESTAMP
+	);
+
+	CREATE INDEX idx_withdraws_balance_uuid ON withdraw(balance_uuid);
+	CREATE INDEX idx_withdraws_organization_created_at ON withdraw(organization_uuid, created_at DESC, uuid DESC);
+	CREATE INDEX idx_withdraws_vendor_record_id ON withdraw(vendor_record_id);
+	CREATE INDEX idx_withdraws_organization_updated_at ON withdraw(organization_uuid, updated_at);
+	CREATE INDEX idx_withdraws_status_next_poll_at ON withdraw(status, next_poll_at);
+	CREATE INDEX idx_withdraws_finalized_at ON withdraw(finalized_at);`
+
+var createTableReconciliationReport = `
+	CREATE TABLE reconciliation_report (
+		uuid VARCHAR(255) PRIMARY KEY,
+		randid VARCHAR(255) NOT NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		kind VARCHAR(20) NOT NULL,
+		period_start TIMESTAMP NOT NULL,
+		period_end TIMESTAMP NOT NULL,
+		matched_count BIGINT NOT NULL DEFAULT 0,
+		amount_mismatch_count BIGINT NOT NULL DEFAULT 0,
+		status_mismatch_count BIGINT NOT NULL DEFAULT 0,
+		missing_in_paystore_count BIGINT NOT NULL DEFAULT 0,
+		missing_at_vendor_count BIGINT NOT NULL DEFAULT 0,
+		auto_finalized_count BIGINT NOT NULL DEFAULT 0
+	);
+
+	CREATE INDEX idx_reconciliation_reports_kind_created_at ON reconciliation_report(kind, created_at DESC);`
+
+var createTableReconciliationItem = `
+	CREATE TABLE reconciliation_item (
+		uuid VARCHAR(255) PRIMARY KEY,
+		randid VARCHAR(255) NOT NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		report_uuid VARCHAR(255) NOT NULL,
+		result VARCHAR(30) NOT NULL,
+		record_uuid VARCHAR(255) NOT NULL,
+		balance_uuid VARCHAR(255) NOT NULL,
+		vendor_record_id VARCHAR(255) NOT NULL,
+		paystore_amount BIGINT NOT NULL,
+		vendor_amount BIGINT NOT NULL,
+		paystore_status VARCHAR(20) NOT NULL,
+		vendor_status VARCHAR(20) NOT NULL,
+		vendor_raw_status VARCHAR(255) NOT NULL,
+		paystore_currency VARCHAR(3) NOT NULL,
+		vendor_currency VARCHAR(3) NOT NULL,
+		vendor_failure_code VARCHAR(255) NOT NULL DEFAULT '',
+		auto_finalized BOOL NOT NULL DEFAULT false
+	);
+
+	CREATE INDEX idx_reconciliation_items_report_result ON reconciliation_item(report_uuid, result);`
+
+var createTableSettlementReport = `
+	CREATE TABLE settlement_report (
+		uuid VARCHAR(255) PRIMARY KEY,
+		randid VARCHAR(255) NOT NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		organization_uuid VARCHAR(255) NOT NULL,
+		currency VARCHAR(3) NOT NULL,
+		day DATE NOT NULL,
+		gross_collected BIGINT NOT NULL DEFAULT 0,
+		fees BIGINT NOT NULL DEFAULT 0,
+		net_credited BIGINT NOT NULL DEFAULT 0,
+		paid_count BIGINT NOT NULL DEFAULT 0,
+		failed_payment_count BIGINT NOT NULL DEFAULT 0,
+		withdrawn BIGINT NOT NULL DEFAULT 0,
+		withdraw_fees BIGINT NOT NULL DEFAULT 0,
+		withdraw_count BIGINT NOT NULL DEFAULT 0,
+		failed_withdraw_count BIGINT NOT NULL DEFAULT 0,
+		closing_liability BIGINT NOT NULL DEFAULT 0,
+		UNIQUE (organization_uuid, currency, day)
+	);
+
+	CREATE INDEX idx_settlement_reports_day ON settlement_report(day);`
+
+var createTableOutbox = `
+	CREATE TABLE outbox (
+		uuid VARCHAR(255) PRIMARY KEY,
+		randid VARCHAR(255) NOT NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		sequence BIGSERIAL NOT NULL UNIQUE,
+		event_type VARCHAR(50) NOT NULL,
+		aggregate_type VARCHAR(20) NOT NULL,
+		aggregate_uuid VARCHAR(255) NOT NULL,
+		organization_uuid VARCHAR(255) NOT NULL,
+		payload JSONB NOT NULL,
+		published_at TIMESTAMP
+	);
+
+	CREATE INDEX idx_outbox_pending_sequence ON outbox(sequence) WHERE published_at IS NULL;`
+
+var createTableWebhookSecret = `
+	CREATE TABLE webhook_secret (
+		organization_uuid VARCHAR(255) PRIMARY KEY,
+		secret VARCHAR(255) NOT NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		updated_at TIMESTAMP NOT NULL DEFAULT NOW()
 	);`
+
+var createTableWebhookEndpoint = `
+	CREATE TABLE webhook_endpoint (
+		uuid VARCHAR(255) PRIMARY KEY,
+		randid VARCHAR(255) NOT NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		organization_uuid VARCHAR(255) NOT NULL,
+		url TEXT NOT NULL,
+		event_types TEXT[] NOT NULL DEFAULT '{}',
+		active BOOL NOT NULL DEFAULT true
+	);
+
+	CREATE INDEX idx_webhook_endpoints_organization_active ON webhook_endpoint(organization_uuid) WHERE active;`
+
+var createTableWebhookDelivery = `
+	CREATE TABLE webhook_delivery (
+		uuid VARCHAR(255) PRIMARY KEY,
+		randid VARCHAR(255) NOT NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		endpoint_uuid VARCHAR(255) NOT NULL,
+		organization_uuid VARCHAR(255) NOT NULL,
+		event_uuid VARCHAR(255) NOT NULL,
+		event_type VARCHAR(50) NOT NULL,
+		url TEXT NOT NULL,
+		payload JSONB NOT NULL,
+		status VARCHAR(20) NOT NULL,
+		attempts BIGINT NOT NULL DEFAULT 0,
+		replay_count BIGINT NOT NULL DEFAULT 0,
+		next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		last_response_code INT NOT NULL DEFAULT 0,
+		last_error TEXT NOT NULL DEFAULT '',
+		delivered_at TIMESTAMP,
+		UNIQUE (endpoint_uuid, event_uuid)
+	);
+
+	CREATE INDEX idx_webhook_deliveries_status_next_attempt_at ON webhook_delivery(status, next_attempt_at);
+	CREATE INDEX idx_webhook_deliveries_organization_created_at ON webhook_delivery(organization_uuid, created_at DESC, uuid DESC);`
@@ -0,0 +1,77 @@
+package operation
+
+import (
+	"github.com/redis/go-redis/v9"
+	"paystore/lib/helper"
+	"paystore/lib/lock"
+	"time"
+)
+
+const expiryLockKey = "paystore:lock:payment-expiry"
+
+// ExpiryJob sweeps stale pending payments on a fixed interval. The sweep runs under a
+// Redis lock leased for one interval, so only one instance sweeps at a time.
+type ExpiryJob struct {
+	paystoreClient *PaystoreClient
+	redis          redis.UniversalClient
+	interval       time.Duration
+	ttl            time.Duration
+	batchSize      int64
+	stop           chan struct{}
+}
+
+func (j *ExpiryJob) Start() {
+	go func() {
+		ticker := time.NewTicker(j.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-j.stop:
+				return
+			case tick := <-ticker.C:
+				j.run(tick)
+			}
+		}
+	}()
+}
+
+func (j *ExpiryJob) Stop() {
+	close(j.stop)
+}
+
+func (j *ExpiryJob) run(now time.Time) {
+	sweepLock, errLock := lock.Acquire(j.redis, expiryLockKey, j.interval)
+	if errLock != nil {
+		helper.Logger.Error("expiry-lock-error", "component", "paystore", "source", "operation.ExpiryJob",
+			"error", errLock.Error())
+		return
+	}
+	if sweepLock == nil {
+		return
+	}
+	defer sweepLock.Release()
+
+	expired, errExpire := j.paystoreClient.ExpirePayments(now, j.ttl, j.batchSize)
+	if errExpire != nil {
+		helper.Logger.Error("expiry-sweep-error", "component", "paystore", "source", "operation.ExpiryJob",
+			"error", errExpire.Error())
+		return
+	}
+	if expired > 0 {
+		helper.Logger.Info("expiry-sweep", "component", "paystore", "source", "operation.ExpiryJob",
+			"expired", expired)
+	}
+}
+
+func NewExpiryJob(paystoreClient *PaystoreClient, redis redis.UniversalClient, interval time.Duration,
+	ttl time.Duration, batchSize int64) *ExpiryJob {
+	return &ExpiryJob{
+		paystoreClient: paystoreClient,
+		redis:          redis,
+		interval:       interval,
+		ttl:            ttl,
+		batchSize:      batchSize,
+		stop:           make(chan struct{}),
+	}
+}
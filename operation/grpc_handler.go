@@ -2,19 +2,56 @@ package operation
 
 import (
 	"context"
+	"paystore/lib/balance"
+	"paystore/lib/fee"
+	"paystore/lib/helper"
+	"paystore/lib/limit"
 	"paystore/lib/organization"
 	"paystore/lib/payment"
+	"paystore/lib/provider"
+	"paystore/lib/reconciliation"
+	"paystore/lib/schema"
+	"paystore/lib/settlement"
+	"paystore/lib/statement"
 	"paystore/lib/withdraw"
 	pb "paystore/protos"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // GRPCHandler
 /*
 	- CreateBalance
 	- CreatePayment
+	- CreateSplitPayment
 	- FinalizedPayment
 	- CreateWithdraw
 	- FinalizedWithdraw
+	- SearchPayments
+	- SearchWithdraws
+	- GenerateStatement
+	- Reconcile
+	- GetReconciliationReport
+	- RegisterWebhookEndpoint
+	- RemoveWebhookEndpoint
+	- RotateWebhookSecret
+	- ReplayWebhookDelivery
+	- SetFeeSchedule
+	- GetFeeSchedule
+	- ListFeeSchedules
+	- SetFeeRounding
+	- GetRevenueBalance
+	- SetLimit
+	- GetLimit
+	- SetOrganizationParent
+	- GetBalanceRollup
+	- RecomputeSettlement
+	- GetSettlementReports
+	- SetOrganizationStatus
+	- GetOrganizationStatusHistory
 */
 func pbToGoPaymentStatus(pbStatus pb.PaymentStatus) payment.PaymentStatus {
 	switch pbStatus {
@@ -24,6 +61,8 @@ func pbToGoPaymentStatus(pbStatus pb.PaymentStatus) payment.PaymentStatus {
 		return payment.PaymentStatusPaid
 	case pb.PaymentStatus_PAYMENT_STATUS_FAILED:
 		return payment.PaymentStatusFailed
+	case pb.PaymentStatus_PAYMENT_STATUS_EXPIRED:
+		return payment.PaymentStatusExpired
 	default:
 		return payment.PaymentStatusPending // or handle error
 	}
@@ -37,11 +76,399 @@ func pbToGoWithdrawStatus(pbStatus pb.PaymentStatus) withdraw.WithdrawStatus {
 		return withdraw.StatusSuccess
 	case pb.PaymentStatus_PAYMENT_STATUS_FAILED:
 		return withdraw.StatusFailed
+	case pb.PaymentStatus_PAYMENT_STATUS_REVIEW:
+		return withdraw.StatusReview
 	default:
 		return withdraw.StatusPending // or handle error
 	}
 }
 
+func goToPbPaymentStatus(status payment.PaymentStatus) pb.PaymentStatus {
+	switch status {
+	case payment.PaymentStatusPending:
+		return pb.PaymentStatus_PAYMENT_STATUS_PENDING
+	case payment.PaymentStatusPaid:
+		return pb.PaymentStatus_PAYMENT_STATUS_PAID
+	case payment.PaymentStatusFailed:
+		return pb.PaymentStatus_PAYMENT_STATUS_FAILED
+	case payment.PaymentStatusExpired:
+		return pb.PaymentStatus_PAYMENT_STATUS_EXPIRED
+	default:
+		return pb.PaymentStatus_PAYMENT_STATUS_UNSPECIFIED
+	}
+}
+
+func goToPbWithdrawStatus(status withdraw.WithdrawStatus) pb.PaymentStatus {
+	switch status {
+	case withdraw.StatusPending:
+		return pb.PaymentStatus_PAYMENT_STATUS_PENDING
+	case withdraw.StatusSuccess:
+		return pb.PaymentStatus_PAYMENT_STATUS_PAID
+	case withdraw.StatusFailed:
+		return pb.PaymentStatus_PAYMENT_STATUS_FAILED
+	case withdraw.StatusReview:
+		return pb.PaymentStatus_PAYMENT_STATUS_REVIEW
+	default:
+		return pb.PaymentStatus_PAYMENT_STATUS_UNSPECIFIED
+	}
+}
+
+func pbToGoStatementFormat(pbFormat pb.StatementFormat) statement.Format {
+	switch pbFormat {
+	case pb.StatementFormat_STATEMENT_FORMAT_CSV:
+		return statement.FormatCSV
+	default:
+		return statement.FormatJSON
+	}
+}
+
+// pbToGoFeeScheduleKind treats an unspecified kind as the payment schedule, which was the
+// only schedule before withdraws were priced.
+func pbToGoFeeScheduleKind(pbKind pb.FeeScheduleKind) fee.Kind {
+	switch pbKind {
+	case pb.FeeScheduleKind_FEE_SCHEDULE_KIND_WITHDRAW:
+		return fee.KindWithdraw
+	default:
+		return fee.KindPayment
+	}
+}
+
+func goToPbFeeScheduleKind(kind fee.Kind) pb.FeeScheduleKind {
+	switch kind {
+	case fee.KindPayment:
+		return pb.FeeScheduleKind_FEE_SCHEDULE_KIND_PAYMENT
+	case fee.KindWithdraw:
+		return pb.FeeScheduleKind_FEE_SCHEDULE_KIND_WITHDRAW
+	default:
+		return pb.FeeScheduleKind_FEE_SCHEDULE_KIND_UNSPECIFIED
+	}
+}
+
+// pbToGoFeeRounding treats an unspecified mode as inheriting the parent's rounding; top-level
+// organizations then round down, the rounding fees had before modes were configurable.
+func pbToGoFeeRounding(pbRounding pb.FeeRounding) fee.RoundingMode {
+	switch pbRounding {
+	case pb.FeeRounding_FEE_ROUNDING_DOWN:
+		return fee.RoundDown
+	case pb.FeeRounding_FEE_ROUNDING_UP:
+		return fee.RoundUp
+	case pb.FeeRounding_FEE_ROUNDING_HALF_UP:
+		return fee.RoundHalfUp
+	case pb.FeeRounding_FEE_ROUNDING_HALF_EVEN:
+		return fee.RoundHalfEven
+	default:
+		return ""
+	}
+}
+
+func goToPbFeeRounding(mode fee.RoundingMode) pb.FeeRounding {
+	switch mode {
+	case fee.RoundDown, "":
+		return pb.FeeRounding_FEE_ROUNDING_DOWN
+	case fee.RoundUp:
+		return pb.FeeRounding_FEE_ROUNDING_UP
+	case fee.RoundHalfUp:
+		return pb.FeeRounding_FEE_ROUNDING_HALF_UP
+	case fee.RoundHalfEven:
+		return pb.FeeRounding_FEE_ROUNDING_HALF_EVEN
+	default:
+		return pb.FeeRounding_FEE_ROUNDING_UNSPECIFIED
+	}
+}
+
+func pbToGoOrganizationStatus(pbStatus pb.OrganizationStatus) organization.Status {
+	switch pbStatus {
+	case pb.OrganizationStatus_ORGANIZATION_STATUS_ACTIVE:
+		return organization.StatusActive
+	case pb.OrganizationStatus_ORGANIZATION_STATUS_SUSPENDED:
+		return organization.StatusSuspended
+	case pb.OrganizationStatus_ORGANIZATION_STATUS_CLOSED:
+		return organization.StatusClosed
+	default:
+		return ""
+	}
+}
+
+func goToPbOrganizationStatus(status organization.Status) pb.OrganizationStatus {
+	switch status {
+	case organization.StatusActive:
+		return pb.OrganizationStatus_ORGANIZATION_STATUS_ACTIVE
+	case organization.StatusSuspended:
+		return pb.OrganizationStatus_ORGANIZATION_STATUS_SUSPENDED
+	case organization.StatusClosed:
+		return pb.OrganizationStatus_ORGANIZATION_STATUS_CLOSED
+	default:
+		return pb.OrganizationStatus_ORGANIZATION_STATUS_UNSPECIFIED
+	}
+}
+
+func goToPbOrganizationStatusChange(change *organization.StatusChange) *pb.OrganizationStatusChange {
+	return &pb.OrganizationStatusChange{
+		UUID:             change.GetUUID(),
+		OrganizationUUID: change.OrganizationUUID,
+		FromStatus:       goToPbOrganizationStatus(change.FromStatus),
+		ToStatus:         goToPbOrganizationStatus(change.ToStatus),
+		Reason:           change.Reason,
+		Actor:            change.Actor,
+		CreatedAt:        timestamppb.New(change.GetCreatedAt()),
+	}
+}
+
+func pbToGoLimitScope(pbScope pb.LimitScope) limit.Scope {
+	switch pbScope {
+	case pb.LimitScope_LIMIT_SCOPE_ORGANIZATION:
+		return limit.ScopeOrganization
+	case pb.LimitScope_LIMIT_SCOPE_BALANCE:
+		return limit.ScopeBalance
+	default:
+		return ""
+	}
+}
+
+func goToPbLimitScope(scope limit.Scope) pb.LimitScope {
+	switch scope {
+	case limit.ScopeOrganization:
+		return pb.LimitScope_LIMIT_SCOPE_ORGANIZATION
+	case limit.ScopeBalance:
+		return pb.LimitScope_LIMIT_SCOPE_BALANCE
+	default:
+		return pb.LimitScope_LIMIT_SCOPE_UNSPECIFIED
+	}
+}
+
+func goToPbLimit(transactionLimit *limit.Limit) *pb.Limit {
+	return &pb.Limit{
+		UUID:               transactionLimit.GetUUID(),
+		Scope:              goToPbLimitScope(transactionLimit.Scope),
+		ScopeUUID:          transactionLimit.ScopeUUID,
+		MaxPayment:         transactionLimit.MaxPayment,
+		MaxWithdraw:        transactionLimit.MaxWithdraw,
+		DailyPayment:       transactionLimit.DailyPayment,
+		MonthlyPayment:     transactionLimit.MonthlyPayment,
+		DailyWithdraw:      transactionLimit.DailyWithdraw,
+		MonthlyWithdraw:    transactionLimit.MonthlyWithdraw,
+		DailyWithdrawCount: transactionLimit.DailyWithdrawCount,
+	}
+}
+
+func goToPbBalanceRollup(rollup *balance.Rollup) *pb.BalanceRollup {
+	pbRollup := &pb.BalanceRollup{
+		OrganizationUUID:     rollup.OrganizationUUID,
+		Currency:             rollup.Currency,
+		BalanceCount:         rollup.BalanceCount,
+		Balance:              rollup.Balance,
+		IncomeAccumulation:   rollup.IncomeAccumulation,
+		WithdrawAccumulation: rollup.WithdrawAccumulation,
+	}
+	for _, subtotal := range rollup.Organizations {
+		pbRollup.Organizations = append(pbRollup.Organizations, goToPbBalanceRollup(subtotal))
+	}
+	return pbRollup
+}
+
+func pbToGoReconciliationKind(pbKind pb.ReconciliationKind) reconciliation.Kind {
+	switch pbKind {
+	case pb.ReconciliationKind_RECONCILIATION_KIND_PAYMENT:
+		return reconciliation.KindPayment
+	case pb.ReconciliationKind_RECONCILIATION_KIND_WITHDRAW:
+		return reconciliation.KindWithdraw
+	default:
+		return ""
+	}
+}
+
+func goToPbReconciliationKind(kind reconciliation.Kind) pb.ReconciliationKind {
+	switch kind {
+	case reconciliation.KindPayment:
+		return pb.ReconciliationKind_RECONCILIATION_KIND_PAYMENT
+	case reconciliation.KindWithdraw:
+		return pb.ReconciliationKind_RECONCILIATION_KIND_WITHDRAW
+	default:
+		return pb.ReconciliationKind_RECONCILIATION_KIND_UNSPECIFIED
+	}
+}
+
+var pbReconciliationResults = map[pb.ReconciliationResult]reconciliation.Result{
+	pb.ReconciliationResult_RECONCILIATION_RESULT_MATCHED:             reconciliation.ResultMatched,
+	pb.ReconciliationResult_RECONCILIATION_RESULT_AMOUNT_MISMATCH:     reconciliation.ResultAmountMismatch,
+	pb.ReconciliationResult_RECONCILIATION_RESULT_STATUS_MISMATCH:     reconciliation.ResultStatusMismatch,
+	pb.ReconciliationResult_RECONCILIATION_RESULT_MISSING_IN_PAYSTORE: reconciliation.ResultMissingInPaystore,
+	pb.ReconciliationResult_RECONCILIATION_RESULT_MISSING_AT_VENDOR:   reconciliation.ResultMissingAtVendor,
+}
+
+func goToPbReconciliationResult(result reconciliation.Result) pb.ReconciliationResult {
+	for pbResult, goResult := range pbReconciliationResults {
+		if goResult == result {
+			return pbResult
+		}
+	}
+	return pb.ReconciliationResult_RECONCILIATION_RESULT_UNSPECIFIED
+}
+
+func goToPbReconciliationReport(report *reconciliation.Report) *pb.ReconciliationReport {
+	pbReport := &pb.ReconciliationReport{
+		UUID:                   report.GetUUID(),
+		Kind:                   goToPbReconciliationKind(report.Kind),
+		PeriodStart:            timestamppb.New(report.PeriodStart),
+		PeriodEnd:              timestamppb.New(report.PeriodEnd),
+		MatchedCount:           report.MatchedCount,
+		AmountMismatchCount:    report.AmountMismatchCount,
+		StatusMismatchCount:    report.StatusMismatchCount,
+		MissingInPaystoreCount: report.MissingInPaystoreCount,
+		MissingAtVendorCount:   report.MissingAtVendorCount,
+		AutoFinalizedCount:     report.AutoFinalizedCount,
+	}
+	for _, item := range report.Items {
+		pbReport.Items = append(pbReport.Items, &pb.ReconciliationItem{
+			Result:            goToPbReconciliationResult(item.Result),
+			RecordUUID:        item.RecordUUID,
+			BalanceUUID:       item.BalanceUUID,
+			VendorRecordID:    item.VendorRecordID,
+			PaystoreAmount:    item.PaystoreAmount,
+			VendorAmount:      item.VendorAmount,
+			PaystoreStatus:    item.PaystoreStatus,
+			VendorStatus:      item.VendorStatus,
+			VendorRawStatus:   item.VendorRawStatus,
+			PaystoreCurrency:  item.PaystoreCurrency,
+			VendorCurrency:    item.VendorCurrency,
+			VendorFailureCode: item.VendorFailureCode,
+			AutoFinalized:     item.AutoFinalized,
+		})
+	}
+	return pbReport
+}
+
+func pbToGoTime(timestamp *timestamppb.Timestamp) time.Time {
+	if timestamp == nil {
+		return time.Time{}
+	}
+	return timestamp.AsTime()
+}
+
+// goToPbTime leaves a zero time unset.
+func goToPbTime(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// pbToGoDay parses a settlement day; an empty day is the zero time.
+func pbToGoDay(day string) (time.Time, error) {
+	if day == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(settlement.DayLayout, day)
+}
+
+func goToPbSettlementReports(reports []*settlement.Report) *pb.SettlementReports {
+	response := &pb.SettlementReports{}
+	for _, report := range reports {
+		response.Reports = append(response.Reports, &pb.SettlementReport{
+			UUID:                report.GetUUID(),
+			OrganizationUUID:    report.OrganizationUUID,
+			Currency:            report.Currency,
+			Day:                 report.Day.Format(settlement.DayLayout),
+			GrossCollected:      report.GrossCollected,
+			Fees:                report.Fees,
+			NetCredited:         report.NetCredited,
+			PaidCount:           report.PaidCount,
+			FailedPaymentCount:  report.FailedPaymentCount,
+			Withdrawn:           report.Withdrawn,
+			WithdrawFees:        report.WithdrawFees,
+			WithdrawCount:       report.WithdrawCount,
+			FailedWithdrawCount: report.FailedWithdrawCount,
+			ClosingLiability:    report.ClosingLiability,
+			UpdatedAt:           goToPbTime(report.GetUpdatedAt()),
+		})
+	}
+	return response
+}
+
+func goToPbPayment(payment *payment.Payment) *pb.Payment {
+	return &pb.Payment{
+		UUID:                 payment.GetUUID(),
+		RandId:               payment.GetRandId(),
+		CreatedAt:            timestamppb.New(payment.GetCreatedAt()),
+		UpdatedAt:            timestamppb.New(payment.GetUpdatedAt()),
+		Amount:               payment.Amount,
+		Fees:                 payment.Fees,
+		BalanceBeforePayment: payment.BalanceBeforePayment,
+		BalanceAfterPayment:  payment.BalanceAfterPayment,
+		BalanceUUID:          payment.BalanceUUID,
+		OrganizationUUID:     payment.OrganizationUUID,
+		VendorRecordID:       payment.VendorRecordID,
+		Status:               goToPbPaymentStatus(payment.Status),
+		Hash:                 payment.Hash,
+		VendorCode:           payment.VendorCode,
+		Channel:              payment.Channel,
+		FeeBreakdown:         goToPbFeeBreakdown(payment.FeeBreakdown),
+		FeeScheduleUUID:      payment.FeeScheduleUUID,
+		ParentPaymentUUID:    payment.ParentPaymentUUID,
+	}
+}
+
+func goToPbFeeBreakdown(breakdown fee.Breakdown) *pb.FeeBreakdown {
+	return &pb.FeeBreakdown{
+		ScheduleUUID:    breakdown.ScheduleUUID,
+		Channel:         breakdown.Channel,
+		PercentBps:      breakdown.PercentBps,
+		PercentFee:      breakdown.PercentFee,
+		FixedFee:        breakdown.FixedFee,
+		Adjustment:      breakdown.Adjustment,
+		Total:           breakdown.Total,
+		RateNumerator:   breakdown.RateNumerator,
+		RateDenominator: breakdown.RateDenominator,
+		Rounding:        goToPbFeeRounding(breakdown.Rounding),
+		Residue:         breakdown.Residue,
+	}
+}
+
+func goToPbFeeSchedule(schedule *fee.Schedule) *pb.FeeSchedule {
+	var rules []*pb.FeeRule
+	for _, rule := range schedule.Rules {
+		rules = append(rules, &pb.FeeRule{
+			Channel:         rule.Channel,
+			PercentBps:      rule.PercentBps,
+			RateNumerator:   rule.RateNumerator,
+			RateDenominator: rule.RateDenominator,
+			Fixed:           rule.Fixed,
+			Min:             rule.Min,
+			Max:             rule.Max,
+		})
+	}
+	return &pb.FeeSchedule{
+		ScheduleUUID:     schedule.GetUUID(),
+		OrganizationUUID: schedule.OrganizationUUID,
+		Rules:            rules,
+		Kind:             goToPbFeeScheduleKind(schedule.Kind),
+		Rounding:         goToPbFeeRounding(schedule.Rounding),
+		EffectiveFrom:    goToPbTime(schedule.EffectiveFrom),
+		EffectiveTo:      goToPbTime(schedule.EffectiveTo),
+	}
+}
+
+func goToPbWithdraw(withdraw *withdraw.Withdraw) *pb.Withdraw {
+	return &pb.Withdraw{
+		UUID:                  withdraw.GetUUID(),
+		RandId:                withdraw.GetRandId(),
+		CreatedAt:             timestamppb.New(withdraw.GetCreatedAt()),
+		UpdatedAt:             timestamppb.New(withdraw.GetUpdatedAt()),
+		Amount:                withdraw.Amount,
+		BalanceBeforeWithdraw: withdraw.BalanceBeforePayment,
+		BalanceAfterWithdraw:  withdraw.BalanceAfterPayment,
+		BalanceUUID:           withdraw.BalanceUUID,
+		OrganizationUUID:      withdraw.OrganizationUUID,
+		VendorRecordID:        withdraw.VendorRecordID,
+		Status:                goToPbWithdrawStatus(withdraw.Status),
+		Hash:                  withdraw.Hash,
+		VendorCode:            withdraw.VendorCode,
+		Fees:                  withdraw.Fees,
+		Channel:               withdraw.Channel,
+		FeeBreakdown:          goToPbFeeBreakdown(withdraw.FeeBreakdown),
+	}
+}
+
 type GRPCHandler struct {
 	pb.UnimplementedPaystoreServer
 	paystoreClient *PaystoreClient
@@ -60,16 +487,64 @@ func (grpc *GRPCHandler) CreateBalance(ctx context.Context, in *pb.CreateBalance
 }
 
 func (grpc *GRPCHandler) CreatePayment(ctx context.Context, in *pb.CreatePaymentRequest) (*pb.CreatedResponse, error) {
-	payment, errCreate := grpc.paystoreClient.CreatePayment(in.AccountUUID, in.Amount)
+	payment, errCreate := grpc.paystoreClient.CreatePayment(in.AccountUUID, in.Amount, in.VendorCode, in.Channel)
 	if errCreate != nil {
 		return nil, errCreate
 	}
 
-	return &pb.CreatedResponse{ID: payment.GetUUID()}, nil
+	return &pb.CreatedResponse{
+		ID:             payment.GetUUID(),
+		VendorRecordID: payment.VendorRecordID,
+		InvoiceURL:     payment.PaymentVendor.InvoiceURL(),
+	}, nil
+}
+
+func (grpc *GRPCHandler) CreateSplitPayment(ctx context.Context,
+	in *pb.CreateSplitPaymentRequest) (*pb.SplitPaymentResponse, error) {
+	var shares []payment.Share
+	for _, share := range in.Shares {
+		shares = append(shares, payment.Share{
+			BalanceUUID: share.BalanceUUID,
+			Fixed:       share.Fixed,
+			PercentBps:  share.PercentBps,
+		})
+	}
+
+	payments, errCreate := grpc.paystoreClient.CreateSplitPayment(in.Amount, shares, in.VendorCode, in.Channel)
+	if errCreate != nil {
+		return nil, errCreate
+	}
+
+	response := &pb.SplitPaymentResponse{
+		ID:             payments[0].GetUUID(),
+		VendorRecordID: payments[0].VendorRecordID,
+		InvoiceURL:     payments[0].PaymentVendor.InvoiceURL(),
+	}
+	for _, splitPayment := range payments {
+		response.Payments = append(response.Payments, goToPbPayment(splitPayment))
+	}
+	return response, nil
 }
 
 func (grpc *GRPCHandler) FinalizedPayment(ctx context.Context, in *pb.FinalizedPaymentRequest) (*pb.EmptyResponse, error) {
-	errFinalized := grpc.paystoreClient.FinalizedPayment(in.AccountUUID, in.PaymentUUID, pbToGoPaymentStatus(in.PaymentStatus), in.VendorRecordId)
+	var errFinalized error
+	if len(in.VendorPayload) > 0 {
+		vendor, errDecode := grpc.paystoreClient.DecodePaymentVendor(in.VendorCode, in.VendorPayload)
+		if errDecode != nil {
+			return nil, errDecode
+		}
+		if vendor.ID() == "" {
+			errSet := vendor.SetRole(schema.RoleID, in.VendorRecordId)
+			if errSet != nil {
+				return nil, errSet
+			}
+		}
+		errFinalized = grpc.paystoreClient.FinalizedPaymentWithVendor(in.AccountUUID, in.PaymentUUID,
+			pbToGoPaymentStatus(in.PaymentStatus), vendor)
+	} else {
+		errFinalized = grpc.paystoreClient.FinalizedPayment(in.AccountUUID, in.PaymentUUID,
+			pbToGoPaymentStatus(in.PaymentStatus), in.VendorRecordId, in.VendorCode)
+	}
 	if errFinalized != nil {
 		return nil, errFinalized
 	}
@@ -78,16 +553,42 @@ func (grpc *GRPCHandler) FinalizedPayment(ctx context.Context, in *pb.FinalizedP
 }
 
 func (grpc *GRPCHandler) CreateWithdraw(ctx context.Context, in *pb.CreateWithdrawRequest) (*pb.CreatedResponse, error) {
-	withdraw, errCreate := grpc.paystoreClient.CreateWithdraw(in.AccountUUID, in.Amount)
+	var destination *provider.Destination
+	if in.ChannelCode != "" {
+		destination = &provider.Destination{
+			ChannelCode:       in.ChannelCode,
+			AccountNumber:     in.AccountNumber,
+			AccountHolderName: in.AccountHolderName,
+		}
+	}
+
+	withdraw, errCreate := grpc.paystoreClient.CreateWithdraw(in.AccountUUID, in.Amount, destination, in.VendorCode)
 	if errCreate != nil {
 		return nil, errCreate
 	}
 
-	return &pb.CreatedResponse{ID: withdraw.GetUUID()}, nil
+	return &pb.CreatedResponse{ID: withdraw.GetUUID(), VendorRecordID: withdraw.VendorRecordID}, nil
 }
 
 func (grpc *GRPCHandler) FinalizedWithdraw(ctx context.Context, in *pb.FinalizedWithdrawRequest) (*pb.EmptyResponse, error) {
-	errFinalized := grpc.paystoreClient.FinalizedWithdraw(in.WithdrawUUID, in.WithdrawUUID, pbToGoWithdrawStatus(in.WithdrawStatus), in.VendorRecordId)
+	var errFinalized error
+	if len(in.VendorPayload) > 0 {
+		vendor, errDecode := grpc.paystoreClient.DecodeWithdrawVendor(in.VendorCode, in.VendorPayload)
+		if errDecode != nil {
+			return nil, errDecode
+		}
+		if vendor.ID() == "" {
+			errSet := vendor.SetRole(schema.RoleID, in.VendorRecordId)
+			if errSet != nil {
+				return nil, errSet
+			}
+		}
+		errFinalized = grpc.paystoreClient.FinalizedWithdrawWithVendor(in.AccountUUId, in.WithdrawUUID,
+			pbToGoWithdrawStatus(in.WithdrawStatus), vendor)
+	} else {
+		errFinalized = grpc.paystoreClient.FinalizedWithdraw(in.AccountUUId, in.WithdrawUUID,
+			pbToGoWithdrawStatus(in.WithdrawStatus), in.VendorRecordId, in.VendorCode)
+	}
 	if errFinalized != nil {
 		return nil, errFinalized
 	}
@@ -95,8 +596,350 @@ func (grpc *GRPCHandler) FinalizedWithdraw(ctx context.Context, in *pb.Finalized
 	return &pb.EmptyResponse{}, nil
 }
 
+// searchError reports a search the request itself got wrong, through its cursor or filter,
+// as an invalid argument.
+func searchError(errSearch error) error {
+	switch errSearch {
+	case helper.InvalidCursor, payment.InvalidAmountRange, payment.InvalidDateRange, withdraw.InvalidAmountRange,
+		withdraw.InvalidDateRange:
+		return status.Error(codes.InvalidArgument, errSearch.Error())
+	}
+	return errSearch
+}
+
+func (grpc *GRPCHandler) SearchPayments(ctx context.Context, in *pb.SearchPaymentsRequest) (*pb.SearchPaymentsResponse, error) {
+	filter := payment.SearchFilter{
+		OrganizationUUID: in.OrganizationUUID,
+		BalanceUUID:      in.BalanceUUID,
+		MinAmount:        in.MinAmount,
+		MaxAmount:        in.MaxAmount,
+		CreatedFrom:      pbToGoTime(in.CreatedFrom),
+		CreatedTo:        pbToGoTime(in.CreatedTo),
+		VendorRecordID:   in.VendorRecordID,
+		VendorCode:       in.VendorCode,
+		Cursor:           in.Cursor,
+		Limit:            in.Limit,
+	}
+	for _, status := range in.Statuses {
+		filter.Statuses = append(filter.Statuses, pbToGoPaymentStatus(status))
+	}
+
+	payments, nextCursor, errSearch := grpc.paystoreClient.SearchPayments(filter)
+	if errSearch != nil {
+		return nil, searchError(errSearch)
+	}
+
+	response := &pb.SearchPaymentsResponse{NextCursor: nextCursor}
+	for _, payment := range payments {
+		response.Payments = append(response.Payments, goToPbPayment(payment))
+	}
+
+	return response, nil
+}
+
+func (grpc *GRPCHandler) SearchWithdraws(ctx context.Context, in *pb.SearchWithdrawsRequest) (*pb.SearchWithdrawsResponse, error) {
+	filter := withdraw.SearchFilter{
+		OrganizationUUID: in.OrganizationUUID,
+		BalanceUUID:      in.BalanceUUID,
+		MinAmount:        in.MinAmount,
+		MaxAmount:        in.MaxAmount,
+		CreatedFrom:      pbToGoTime(in.CreatedFrom),
+		CreatedTo:        pbToGoTime(in.CreatedTo),
+		VendorRecordID:   in.VendorRecordID,
+		VendorCode:       in.VendorCode,
+		Cursor:           in.Cursor,
+		Limit:            in.Limit,
+	}
+	for _, status := range in.Statuses {
+		filter.Statuses = append(filter.Statuses, pbToGoWithdrawStatus(status))
+	}
+
+	withdraws, nextCursor, errSearch := grpc.paystoreClient.SearchWithdraws(filter)
+	if errSearch != nil {
+		return nil, searchError(errSearch)
+	}
+
+	response := &pb.SearchWithdrawsResponse{NextCursor: nextCursor}
+	for _, withdraw := range withdraws {
+		response.Withdraws = append(response.Withdraws, goToPbWithdraw(withdraw))
+	}
+
+	return response, nil
+}
+
+func (grpc *GRPCHandler) GenerateStatement(ctx context.Context, in *pb.GenerateStatementRequest) (*pb.StatementResponse, error) {
+	generated, errGenerate := grpc.paystoreClient.GenerateStatement(in.BalanceUUID,
+		pbToGoTime(in.PeriodStart), pbToGoTime(in.PeriodEnd))
+	if errGenerate != nil {
+		return nil, errGenerate
+	}
+
+	content, contentType, errRender := generated.Render(pbToGoStatementFormat(in.Format))
+	if errRender != nil {
+		return nil, errRender
+	}
+
+	return &pb.StatementResponse{
+		ContentType: contentType,
+		Content:     content,
+		Consistent:  generated.IsConsistent(),
+	}, nil
+}
+
 func NewGRPCHandler(paystoreClient *PaystoreClient) *GRPCHandler {
 	return &GRPCHandler{
 		paystoreClient: paystoreClient,
 	}
 }
+
+func (grpc *GRPCHandler) Reconcile(ctx context.Context, in *pb.ReconcileRequest) (*pb.ReconciliationReport, error) {
+	report, errReconcile := grpc.paystoreClient.Reconcile(pbToGoReconciliationKind(in.Kind),
+		pbToGoTime(in.PeriodStart), pbToGoTime(in.PeriodEnd), in.AutoFinalize)
+	if errReconcile != nil {
+		return nil, errReconcile
+	}
+
+	return goToPbReconciliationReport(report), nil
+}
+
+func (grpc *GRPCHandler) GetReconciliationReport(ctx context.Context,
+	in *pb.GetReconciliationReportRequest) (*pb.ReconciliationReport, error) {
+	report, errFind := grpc.paystoreClient.GetReconciliationReport(in.ReportUUID, pbReconciliationResults[in.Result])
+	if errFind != nil {
+		return nil, errFind
+	}
+
+	return goToPbReconciliationReport(report), nil
+}
+
+func (grpc *GRPCHandler) RegisterWebhookEndpoint(ctx context.Context,
+	in *pb.RegisterWebhookEndpointRequest) (*pb.WebhookEndpointResponse, error) {
+	endpoint, secret, errRegister := grpc.paystoreClient.RegisterWebhookEndpoint(in.OrganizationUUID, in.URL,
+		in.EventTypes)
+	if errRegister != nil {
+		return nil, errRegister
+	}
+
+	return &pb.WebhookEndpointResponse{
+		EndpointUUID: endpoint.GetUUID(),
+		Secret:       secret,
+	}, nil
+}
+
+func (grpc *GRPCHandler) RemoveWebhookEndpoint(ctx context.Context,
+	in *pb.RemoveWebhookEndpointRequest) (*pb.EmptyResponse, error) {
+	errRemove := grpc.paystoreClient.RemoveWebhookEndpoint(in.OrganizationUUID, in.EndpointUUID)
+	if errRemove != nil {
+		return nil, errRemove
+	}
+
+	return &pb.EmptyResponse{}, nil
+}
+
+func (grpc *GRPCHandler) RotateWebhookSecret(ctx context.Context,
+	in *pb.RotateWebhookSecretRequest) (*pb.WebhookSecretResponse, error) {
+	secret, errRotate := grpc.paystoreClient.RotateWebhookSecret(in.OrganizationUUID)
+	if errRotate != nil {
+		return nil, errRotate
+	}
+
+	return &pb.WebhookSecretResponse{Secret: secret}, nil
+}
+
+func (grpc *GRPCHandler) ReplayWebhookDelivery(ctx context.Context,
+	in *pb.ReplayWebhookDeliveryRequest) (*pb.EmptyResponse, error) {
+	_, errReplay := grpc.paystoreClient.ReplayWebhookDelivery(in.OrganizationUUID, in.DeliveryUUID)
+	if errReplay != nil {
+		return nil, errReplay
+	}
+
+	return &pb.EmptyResponse{}, nil
+}
+
+func (grpc *GRPCHandler) SetFeeSchedule(ctx context.Context, in *pb.SetFeeScheduleRequest) (*pb.FeeSchedule, error) {
+	var rules []fee.Rule
+	for _, rule := range in.Rules {
+		rules = append(rules, fee.Rule{
+			Channel:         rule.Channel,
+			PercentBps:      rule.PercentBps,
+			RateNumerator:   rule.RateNumerator,
+			RateDenominator: rule.RateDenominator,
+			Fixed:           rule.Fixed,
+			Min:             rule.Min,
+			Max:             rule.Max,
+		})
+	}
+
+	schedule, errSet := grpc.paystoreClient.SetFeeSchedule(in.OrganizationUUID, pbToGoFeeScheduleKind(in.Kind), rules,
+		pbToGoTime(in.EffectiveFrom))
+	if errSet != nil {
+		return nil, errSet
+	}
+
+	return goToPbFeeSchedule(schedule), nil
+}
+
+func (grpc *GRPCHandler) GetFeeSchedule(ctx context.Context, in *pb.GetFeeScheduleRequest) (*pb.FeeSchedule, error) {
+	schedule, errFind := grpc.paystoreClient.GetFeeSchedule(in.OrganizationUUID, pbToGoFeeScheduleKind(in.Kind),
+		pbToGoTime(in.At))
+	if errFind != nil {
+		return nil, errFind
+	}
+
+	return goToPbFeeSchedule(schedule), nil
+}
+
+func (grpc *GRPCHandler) ListFeeSchedules(ctx context.Context,
+	in *pb.ListFeeSchedulesRequest) (*pb.FeeSchedules, error) {
+	versions, errFind := grpc.paystoreClient.ListFeeSchedules(in.OrganizationUUID, pbToGoFeeScheduleKind(in.Kind))
+	if errFind != nil {
+		return nil, errFind
+	}
+
+	response := &pb.FeeSchedules{}
+	for _, version := range versions {
+		response.Schedules = append(response.Schedules, goToPbFeeSchedule(version))
+	}
+	return response, nil
+}
+
+func (grpc *GRPCHandler) SetFeeRounding(ctx context.Context, in *pb.SetFeeRoundingRequest) (*pb.EmptyResponse, error) {
+	errSet := grpc.paystoreClient.SetFeeRounding(in.OrganizationUUID, pbToGoFeeRounding(in.Rounding))
+	if errSet != nil {
+		return nil, errSet
+	}
+
+	return &pb.EmptyResponse{}, nil
+}
+
+func (grpc *GRPCHandler) GetRevenueBalance(ctx context.Context,
+	in *pb.GetRevenueBalanceRequest) (*pb.RevenueBalance, error) {
+	revenueBalance, errFind := grpc.paystoreClient.GetRevenueBalance(in.OrganizationUUID, in.Currency)
+	if errFind != nil {
+		return nil, errFind
+	}
+
+	return &pb.RevenueBalance{
+		BalanceUUID:          revenueBalance.GetUUID(),
+		OrganizationUUID:     revenueBalance.OrganizationUUID,
+		Currency:             revenueBalance.Currency,
+		Balance:              revenueBalance.Balance,
+		IncomeAccumulation:   revenueBalance.IncomeAccumulation,
+		WithdrawAccumulation: revenueBalance.WithdrawAccumulation,
+	}, nil
+}
+
+func (grpc *GRPCHandler) SetLimit(ctx context.Context, in *pb.SetLimitRequest) (*pb.Limit, error) {
+	newLimit, errLimit := limit.NewLimit(pbToGoLimitScope(in.Scope), in.ScopeUUID)
+	if errLimit != nil {
+		return nil, errLimit
+	}
+	newLimit.MaxPayment = in.MaxPayment
+	newLimit.MaxWithdraw = in.MaxWithdraw
+	newLimit.DailyPayment = in.DailyPayment
+	newLimit.MonthlyPayment = in.MonthlyPayment
+	newLimit.DailyWithdraw = in.DailyWithdraw
+	newLimit.MonthlyWithdraw = in.MonthlyWithdraw
+	newLimit.DailyWithdrawCount = in.DailyWithdrawCount
+
+	transactionLimit, errSet := grpc.paystoreClient.SetLimit(newLimit)
+	if errSet != nil {
+		return nil, errSet
+	}
+
+	return goToPbLimit(transactionLimit), nil
+}
+
+func (grpc *GRPCHandler) GetLimit(ctx context.Context, in *pb.GetLimitRequest) (*pb.Limit, error) {
+	transactionLimit, errFind := grpc.paystoreClient.GetLimit(pbToGoLimitScope(in.Scope), in.ScopeUUID)
+	if errFind != nil {
+		return nil, errFind
+	}
+
+	return goToPbLimit(transactionLimit), nil
+}
+
+func (grpc *GRPCHandler) SetOrganizationParent(ctx context.Context,
+	in *pb.SetOrganizationParentRequest) (*pb.EmptyResponse, error) {
+	_, errSet := grpc.paystoreClient.SetOrganizationParent(in.OrganizationUUID, in.ParentUUID)
+	if errSet != nil {
+		return nil, errSet
+	}
+
+	return &pb.EmptyResponse{}, nil
+}
+
+func (grpc *GRPCHandler) GetBalanceRollup(ctx context.Context,
+	in *pb.GetBalanceRollupRequest) (*pb.BalanceRollupResponse, error) {
+	rollups, errRollup := grpc.paystoreClient.GetBalanceRollup(in.OrganizationUUID)
+	if errRollup != nil {
+		return nil, errRollup
+	}
+
+	response := &pb.BalanceRollupResponse{}
+	for _, rollup := range rollups {
+		response.Rollups = append(response.Rollups, goToPbBalanceRollup(rollup))
+	}
+	return response, nil
+}
+
+func (grpc *GRPCHandler) RecomputeSettlement(ctx context.Context,
+	in *pb.RecomputeSettlementRequest) (*pb.SettlementReports, error) {
+	day, errParse := time.Parse(settlement.DayLayout, in.Day)
+	if errParse != nil {
+		return nil, errParse
+	}
+
+	reports, errCompute := grpc.paystoreClient.ComputeSettlement(day, time.Now())
+	if errCompute != nil {
+		return nil, errCompute
+	}
+	return goToPbSettlementReports(reports), nil
+}
+
+func (grpc *GRPCHandler) GetSettlementReports(ctx context.Context,
+	in *pb.GetSettlementReportsRequest) (*pb.SettlementReports, error) {
+	from, errParse := pbToGoDay(in.From)
+	if errParse != nil {
+		return nil, errParse
+	}
+	to, errParse := pbToGoDay(in.To)
+	if errParse != nil {
+		return nil, errParse
+	}
+
+	reports, errFind := grpc.paystoreClient.GetSettlementReports(settlement.Filter{
+		OrganizationUUID: in.OrganizationUUID,
+		Currency:         in.Currency,
+		From:             from,
+		To:               to,
+	})
+	if errFind != nil {
+		return nil, errFind
+	}
+	return goToPbSettlementReports(reports), nil
+}
+
+func (grpc *GRPCHandler) SetOrganizationStatus(ctx context.Context,
+	in *pb.SetOrganizationStatusRequest) (*pb.OrganizationStatusChange, error) {
+	change, errStatus := grpc.paystoreClient.SetOrganizationStatus(in.OrganizationUUID,
+		pbToGoOrganizationStatus(in.Status), in.Reason, in.Actor)
+	if errStatus != nil {
+		return nil, errStatus
+	}
+	return goToPbOrganizationStatusChange(change), nil
+}
+
+func (grpc *GRPCHandler) GetOrganizationStatusHistory(ctx context.Context,
+	in *pb.GetOrganizationStatusHistoryRequest) (*pb.OrganizationStatusHistory, error) {
+	changes, errFind := grpc.paystoreClient.GetOrganizationStatusHistory(in.OrganizationUUID)
+	if errFind != nil {
+		return nil, errFind
+	}
+
+	response := &pb.OrganizationStatusHistory{}
+	for _, change := range changes {
+		response.Changes = append(response.Changes, goToPbOrganizationStatusChange(change))
+	}
+	return response, nil
+}
@@ -0,0 +1,111 @@
+package operation
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"paystore/config"
+	"paystore/lib/balance"
+	"paystore/lib/helper"
+	"paystore/lib/payment"
+	"paystore/lib/signature"
+	"paystore/lib/withdraw"
+)
+
+// HTTPWebhookHandler
+/*
+	- ReceivePaymentWebhook
+	- ReceiveWithdrawWebhook
+*/
+type HTTPWebhookHandler struct {
+	paystoreClient *PaystoreClient
+	config         *config.App
+}
+
+// verifyWebhook accepts a request signed with the vendor's secret, or carrying its static
+// callback token when the vendor only supports tokens. Vendors without credentials reject everything.
+func verifyWebhook(c *fiber.Ctx, vendor *config.Vendor) bool {
+	if vendor.WebhookSecret != "" {
+		return signature.Verify(vendor.WebhookSecret, c.Body(), c.Get("X-Signature"))
+	}
+	if vendor.WebhookToken != "" {
+		return signature.VerifyToken(vendor.WebhookToken, c.Get("X-Callback-Token"))
+	}
+	return false
+}
+
+func (h *HTTPWebhookHandler) ReceivePaymentWebhook(c *fiber.Ctx) error {
+	vendorConfig, errVendor := h.config.PaymentVendor(c.Params("vendorCode"))
+	if errVendor != nil {
+		return helper.ReturnErrorResponse(c, fiber.StatusNotFound, errVendor, "unknown-vendor", "operation", "ReceivePaymentWebhook")
+	}
+	if !verifyWebhook(c, vendorConfig) {
+		return helper.ReturnErrorResponse(c, fiber.StatusUnauthorized, nil, "invalid-signature", "operation", "ReceivePaymentWebhook")
+	}
+
+	vendor, errDecode := h.paystoreClient.DecodePaymentVendor(c.Params("vendorCode"), c.Body())
+	if errDecode == config.UnknownVendor {
+		return helper.ReturnErrorResponse(c, fiber.StatusNotFound, errDecode, "unknown-vendor", "operation", "ReceivePaymentWebhook")
+	}
+	if errDecode != nil {
+		return helper.ReturnErrorResponse(c, fiber.StatusBadRequest, errDecode, "invalid-payload", "operation", "ReceivePaymentWebhook")
+	}
+
+	errReceive := h.paystoreClient.ReceivePaymentVendor(vendor)
+	if errReceive != nil {
+		switch errReceive {
+		case payment.PaymentNotFound:
+			return helper.ReturnErrorResponse(c, fiber.StatusNotFound, errReceive, "payment-not-found", "operation", "ReceivePaymentWebhook")
+		case payment.UnknownVendorStatus:
+			return helper.ReturnErrorResponse(c, fiber.StatusUnprocessableEntity, errReceive, "unknown-status", "operation", "ReceivePaymentWebhook")
+		case payment.AlreadyFinalized, payment.UnmatchBalance, payment.UnmatchVendor, payment.UnmatchPaidAmount:
+			return helper.ReturnErrorResponse(c, fiber.StatusConflict, errReceive, "payment-conflict", "operation", "ReceivePaymentWebhook")
+		}
+		return helper.ReturnErrorResponse(c, fiber.StatusInternalServerError, errReceive, "webhook-failed", "operation", "ReceivePaymentWebhook")
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
+
+func (h *HTTPWebhookHandler) ReceiveWithdrawWebhook(c *fiber.Ctx) error {
+	vendorConfig, errVendor := h.config.WithdrawVendor(c.Params("vendorCode"))
+	if errVendor != nil {
+		return helper.ReturnErrorResponse(c, fiber.StatusNotFound, errVendor, "unknown-vendor", "operation", "ReceiveWithdrawWebhook")
+	}
+	if !verifyWebhook(c, vendorConfig) {
+		return helper.ReturnErrorResponse(c, fiber.StatusUnauthorized, nil, "invalid-signature", "operation", "ReceiveWithdrawWebhook")
+	}
+
+	vendor, errDecode := h.paystoreClient.DecodeWithdrawVendor(c.Params("vendorCode"), c.Body())
+	if errDecode == config.UnknownVendor {
+		return helper.ReturnErrorResponse(c, fiber.StatusNotFound, errDecode, "unknown-vendor", "operation", "ReceiveWithdrawWebhook")
+	}
+	if errDecode != nil {
+		return helper.ReturnErrorResponse(c, fiber.StatusBadRequest, errDecode, "invalid-payload", "operation", "ReceiveWithdrawWebhook")
+	}
+
+	errReceive := h.paystoreClient.ReceiveWithdrawVendor(vendor)
+	if errReceive != nil {
+		switch errReceive {
+		case withdraw.WithdrawNotFound:
+			return helper.ReturnErrorResponse(c, fiber.StatusNotFound, errReceive, "withdraw-not-found", "operation", "ReceiveWithdrawWebhook")
+		case withdraw.UnknownVendorStatus:
+			return helper.ReturnErrorResponse(c, fiber.StatusUnprocessableEntity, errReceive, "unknown-status", "operation", "ReceiveWithdrawWebhook")
+		case withdraw.AlreadyFinalized, withdraw.UnmatchBalance, withdraw.UnmatchVendor, balance.InsufficientFunds:
+			return helper.ReturnErrorResponse(c, fiber.StatusConflict, errReceive, "withdraw-conflict", "operation", "ReceiveWithdrawWebhook")
+		}
+		return helper.ReturnErrorResponse(c, fiber.StatusInternalServerError, errReceive, "webhook-failed", "operation", "ReceiveWithdrawWebhook")
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
+
+func (h *HTTPWebhookHandler) RegisterRoutes(app *fiber.App) {
+	app.Post("/webhooks/payment/:vendorCode?", h.ReceivePaymentWebhook)
+	app.Post("/webhooks/withdraw/:vendorCode?", h.ReceiveWithdrawWebhook)
+}
+
+func NewHTTPWebhookHandler(paystoreClient *PaystoreClient, config *config.App) *HTTPWebhookHandler {
+	return &HTTPWebhookHandler{
+		paystoreClient: paystoreClient,
+		config:         config,
+	}
+}
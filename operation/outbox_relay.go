@@ -0,0 +1,108 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"github.com/redis/go-redis/v9"
+	"paystore/config"
+	"paystore/lib/helper"
+	"paystore/lib/outbox"
+	"time"
+)
+
+// OutboxRelay publishes committed outbox events to a Redis Stream. Events are marked
+// published only after XADD succeeds, so a crash between the two re-publishes them:
+// delivery is at-least-once and consumers should deduplicate on the event uuid.
+type OutboxRelay struct {
+	writeDB          *sql.DB
+	redis            redis.UniversalClient
+	outboxRepository outbox.RepositoryClient
+	config           *config.App
+	stop             chan struct{}
+}
+
+func (r *OutboxRelay) Start() {
+	go func() {
+		ticker := time.NewTicker(r.config.OutboxRelayInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-r.stop:
+				return
+			case <-ticker.C:
+				r.drain()
+			}
+		}
+	}()
+}
+
+func (r *OutboxRelay) Stop() {
+	close(r.stop)
+}
+
+func (r *OutboxRelay) drain() {
+	for {
+		published, errRelay := r.relay()
+		if errRelay != nil {
+			helper.Logger.Error("outbox-relay-error", "component", "paystore", "source", "operation.OutboxRelay",
+				"error", errRelay.Error())
+			return
+		}
+		if int64(published) < r.config.OutboxBatchSize {
+			return
+		}
+	}
+}
+
+func (r *OutboxRelay) relay() (int, error) {
+	tx, errInitTx := r.writeDB.Begin()
+	if errInitTx != nil {
+		return 0, errInitTx
+	}
+	defer tx.Rollback()
+
+	events, errFind := r.outboxRepository.FindPending(tx, r.config.OutboxBatchSize)
+	if errFind != nil {
+		return 0, errFind
+	}
+	if len(events) == 0 {
+		return 0, nil
+	}
+
+	pipeline := r.redis.Pipeline()
+	for _, event := range events {
+		pipeline.XAdd(context.Background(), &redis.XAddArgs{
+			Stream: r.config.OutboxStream,
+			MaxLen: r.config.OutboxStreamMaxLen,
+			Approx: true,
+			Values: event.StreamValues(),
+		})
+	}
+	_, errPublish := pipeline.Exec(context.Background())
+	if errPublish != nil {
+		return 0, errPublish
+	}
+
+	errMark := r.outboxRepository.MarkPublished(tx, events)
+	if errMark != nil {
+		return 0, errMark
+	}
+
+	errCommit := tx.Commit()
+	if errCommit != nil {
+		return 0, errCommit
+	}
+
+	return len(events), nil
+}
+
+func NewOutboxRelay(writeDB *sql.DB, redis redis.UniversalClient, config *config.App) *OutboxRelay {
+	return &OutboxRelay{
+		writeDB:          writeDB,
+		redis:            redis,
+		outboxRepository: outbox.NewRepository(),
+		config:           config,
+		stop:             make(chan struct{}),
+	}
+}
@@ -0,0 +1,71 @@
+package operation
+
+import (
+	"paystore/lib/helper"
+	"paystore/lib/reconciliation"
+	"time"
+)
+
+// ReconciliationJob reconciles consecutive periods on a fixed interval. Each period ends lag
+// before the tick so vendor callbacks still in flight are not reported as missing.
+type ReconciliationJob struct {
+	paystoreClient *PaystoreClient
+	interval       time.Duration
+	lag            time.Duration
+	autoFinalize   bool
+	stop           chan struct{}
+}
+
+func (j *ReconciliationJob) Start() {
+	go func() {
+		periodEnd := time.Now().Add(-j.lag)
+		ticker := time.NewTicker(j.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-j.stop:
+				return
+			case tick := <-ticker.C:
+				periodStart := periodEnd
+				periodEnd = tick.Add(-j.lag)
+				j.run(periodStart, periodEnd)
+			}
+		}
+	}()
+}
+
+func (j *ReconciliationJob) Stop() {
+	close(j.stop)
+}
+
+func (j *ReconciliationJob) run(periodStart time.Time, periodEnd time.Time) {
+	for _, kind := range []reconciliation.Kind{reconciliation.KindPayment, reconciliation.KindWithdraw} {
+		report, errReconcile := j.paystoreClient.Reconcile(kind, periodStart, periodEnd, j.autoFinalize)
+		if errReconcile == reconciliation.VendorTableRequired {
+			continue
+		}
+		if errReconcile != nil {
+			helper.Logger.Error("reconciliation-error", "component", "paystore", "source", "operation.ReconciliationJob",
+				"kind", string(kind), "error", errReconcile.Error())
+			continue
+		}
+
+		helper.Logger.Info("reconciliation-report", "component", "paystore", "source", "operation.ReconciliationJob",
+			"kind", string(kind), "reportUUID", report.GetUUID(), "matched", report.MatchedCount,
+			"amountMismatch", report.AmountMismatchCount, "statusMismatch", report.StatusMismatchCount,
+			"missingInPaystore", report.MissingInPaystoreCount, "missingAtVendor", report.MissingAtVendorCount,
+			"autoFinalized", report.AutoFinalizedCount)
+	}
+}
+
+func NewReconciliationJob(paystoreClient *PaystoreClient, interval time.Duration, lag time.Duration,
+	autoFinalize bool) *ReconciliationJob {
+	return &ReconciliationJob{
+		paystoreClient: paystoreClient,
+		interval:       interval,
+		lag:            lag,
+		autoFinalize:   autoFinalize,
+		stop:           make(chan struct{}),
+	}
+}
@@ -0,0 +1,76 @@
+package operation
+
+import (
+	"github.com/redis/go-redis/v9"
+	"paystore/config"
+	"paystore/lib/helper"
+	"paystore/lib/lock"
+	"paystore/lib/settlement"
+	"time"
+)
+
+const settlementLockKey = "paystore:lock:settlement"
+
+// SettlementJob settles the last day that ended at least the settlement lag ago, on a fixed
+// interval. The day is recomputed on every tick until the next one ends, so late finalizations
+// within the lag are picked up. It runs under a Redis lock leased for one interval.
+type SettlementJob struct {
+	paystoreClient *PaystoreClient
+	redis          redis.UniversalClient
+	config         *config.App
+	stop           chan struct{}
+}
+
+func (j *SettlementJob) Start() {
+	go func() {
+		ticker := time.NewTicker(j.config.SettlementInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-j.stop:
+				return
+			case tick := <-ticker.C:
+				j.run(tick)
+			}
+		}
+	}()
+}
+
+func (j *SettlementJob) Stop() {
+	close(j.stop)
+}
+
+func (j *SettlementJob) run(now time.Time) {
+	settlementLock, errLock := lock.Acquire(j.redis, settlementLockKey, j.config.SettlementInterval)
+	if errLock != nil {
+		helper.Logger.Error("settlement-lock-error", "component", "paystore", "source", "operation.SettlementJob",
+			"error", errLock.Error())
+		return
+	}
+	if settlementLock == nil {
+		return
+	}
+	defer settlementLock.Release()
+
+	day := settlement.Day(now.Add(-j.config.SettlementLag)).AddDate(0, 0, -1)
+	reports, errCompute := j.paystoreClient.ComputeSettlement(day, now)
+	if errCompute != nil {
+		helper.Logger.Error("settlement-error", "component", "paystore", "source", "operation.SettlementJob",
+			"day", day.Format(settlement.DayLayout), "error", errCompute.Error())
+		return
+	}
+
+	helper.Logger.Info("settlement", "component", "paystore", "source", "operation.SettlementJob",
+		"day", day.Format(settlement.DayLayout), "reports", len(reports))
+}
+
+func NewSettlementJob(paystoreClient *PaystoreClient, redis redis.UniversalClient,
+	config *config.App) *SettlementJob {
+	return &SettlementJob{
+		paystoreClient: paystoreClient,
+		redis:          redis,
+		config:         config,
+		stop:           make(chan struct{}),
+	}
+}
@@ -0,0 +1,267 @@
+package operation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"net"
+	"net/http"
+	"os"
+	"paystore/config"
+	"paystore/lib/balance"
+	"paystore/lib/helper"
+	"paystore/lib/outbox"
+	"paystore/lib/webhook"
+	"strings"
+	"time"
+)
+
+// webhookClaimIdle is how long a stream entry may stay unacknowledged before another
+// consumer claims it, covering dispatchers that crashed mid fan-out.
+const webhookClaimIdle = time.Minute
+
+// WebhookDispatcher turns outbox events into tenant webhook deliveries and sends them.
+// Fan-out reads the outbox stream through a consumer group and acknowledges an entry only
+// after its deliveries are stored; sending claims due deliveries from the delivery log.
+type WebhookDispatcher struct {
+	redis             redis.UniversalClient
+	webhookRepository webhook.RepositoryClient
+	httpClient        *http.Client
+	config            *config.App
+	consumer          string
+	stop              chan struct{}
+}
+
+func (d *WebhookDispatcher) Start() error {
+	errGroup := d.redis.XGroupCreateMkStream(context.Background(), d.config.OutboxStream,
+		d.config.WebhookConsumerGroup, "0").Err()
+	if errGroup != nil && !strings.HasPrefix(errGroup.Error(), "BUSYGROUP") {
+		return errGroup
+	}
+
+	go d.fanOutLoop()
+	go d.sendLoop()
+	return nil
+}
+
+func (d *WebhookDispatcher) Stop() {
+	close(d.stop)
+}
+
+func (d *WebhookDispatcher) fanOutLoop() {
+	for {
+		select {
+		case <-d.stop:
+			return
+		default:
+		}
+
+		errFanOut := d.fanOut()
+		if errFanOut != nil && errFanOut != redis.Nil {
+			helper.Logger.Error("webhook-fan-out-error", "component", "paystore", "source", "operation.WebhookDispatcher",
+				"error", errFanOut.Error())
+			time.Sleep(d.config.WebhookInterval)
+		}
+	}
+}
+
+func (d *WebhookDispatcher) fanOut() error {
+	claimed, _, errClaim := d.redis.XAutoClaim(context.Background(), &redis.XAutoClaimArgs{
+		Stream:   d.config.OutboxStream,
+		Group:    d.config.WebhookConsumerGroup,
+		Consumer: d.consumer,
+		MinIdle:  webhookClaimIdle,
+		Start:    "0-0",
+		Count:    d.config.WebhookBatchSize,
+	}).Result()
+	if errClaim != nil && errClaim != redis.Nil {
+		return errClaim
+	}
+	messages := claimed
+
+	if len(messages) == 0 {
+		streams, errRead := d.redis.XReadGroup(context.Background(), &redis.XReadGroupArgs{
+			Group:    d.config.WebhookConsumerGroup,
+			Consumer: d.consumer,
+			Streams:  []string{d.config.OutboxStream, ">"},
+			Count:    d.config.WebhookBatchSize,
+			Block:    d.config.WebhookInterval,
+		}).Result()
+		if errRead != nil {
+			return errRead
+		}
+		for _, stream := range streams {
+			messages = append(messages, stream.Messages...)
+		}
+	}
+
+	for _, message := range messages {
+		errQueue := d.queue(message)
+		if errQueue != nil {
+			return errQueue
+		}
+
+		errAck := d.redis.XAck(context.Background(), d.config.OutboxStream, d.config.WebhookConsumerGroup,
+			message.ID).Err()
+		if errAck != nil {
+			return errAck
+		}
+	}
+
+	return nil
+}
+
+func (d *WebhookDispatcher) queue(message redis.XMessage) error {
+	body := webhook.EventBody{
+		ID:               fmt.Sprint(message.Values["uuid"]),
+		Type:             fmt.Sprint(message.Values["eventType"]),
+		OrganizationUUID: fmt.Sprint(message.Values["organizationUUID"]),
+		CreatedAt:        fmt.Sprint(message.Values["createdAt"]),
+		Data:             json.RawMessage(fmt.Sprint(message.Values["payload"])),
+	}
+	if body.OrganizationUUID == "" {
+		return nil
+	}
+	// Revenue balances hold the platform's fees, not the tenant's money, so their changes are
+	// not sent to tenant endpoints.
+	if fmt.Sprint(message.Values["aggregateType"]) == string(outbox.AggregateBalance) {
+		var aggregate struct{ Kind balance.Kind }
+		errDecode := json.Unmarshal(body.Data, &aggregate)
+		if errDecode != nil {
+			return errDecode
+		}
+		if aggregate.Kind == balance.KindRevenue {
+			return nil
+		}
+	}
+
+	endpoints, errFind := d.webhookRepository.FindActiveEndpoints(body.OrganizationUUID)
+	if errFind != nil {
+		return errFind
+	}
+
+	var payload []byte
+	for _, endpoint := range endpoints {
+		if !endpoint.Matches(body.Type) {
+			continue
+		}
+		if payload == nil {
+			var errMarshal error
+			payload, errMarshal = json.Marshal(body)
+			if errMarshal != nil {
+				return errMarshal
+			}
+		}
+
+		errCreate := d.webhookRepository.CreateDelivery(webhook.NewDelivery(endpoint, body.ID, body.Type, payload))
+		if errCreate != nil {
+			return errCreate
+		}
+	}
+
+	return nil
+}
+
+func (d *WebhookDispatcher) sendLoop() {
+	ticker := time.NewTicker(d.config.WebhookInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-d.stop:
+			return
+		case tick := <-ticker.C:
+			errSend := d.sendDue(tick)
+			if errSend != nil {
+				helper.Logger.Error("webhook-send-error", "component", "paystore", "source", "operation.WebhookDispatcher",
+					"error", errSend.Error())
+			}
+		}
+	}
+}
+
+func (d *WebhookDispatcher) sendDue(now time.Time) error {
+	deliveries, errClaim := d.webhookRepository.ClaimDue(now, d.config.WebhookTimeout*2, d.config.WebhookBatchSize)
+	if errClaim != nil {
+		return errClaim
+	}
+
+	secrets := make(map[string]string)
+	for _, delivery := range deliveries {
+		secret, cached := secrets[delivery.OrganizationUUID]
+		if !cached {
+			var errSecret error
+			secret, errSecret = d.webhookRepository.FindOrCreateSecret(delivery.OrganizationUUID)
+			if errSecret != nil {
+				return errSecret
+			}
+			secrets[delivery.OrganizationUUID] = secret
+		}
+
+		responseCode, errPost := d.post(delivery, secret)
+		delivery.RecordAttempt(responseCode, errPost, time.Now(), d.config.WebhookBackoff, d.config.WebhookMaxAttempts)
+
+		errUpdate := d.webhookRepository.UpdateDelivery(delivery)
+		if errUpdate != nil {
+			return errUpdate
+		}
+	}
+
+	return nil
+}
+
+func (d *WebhookDispatcher) post(delivery *webhook.Delivery, secret string) (int, error) {
+	request, errRequest := http.NewRequest(http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if errRequest != nil {
+		return 0, errRequest
+	}
+
+	timestamp, signature := delivery.Sign(secret, time.Now())
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(webhook.SignatureHeader, signature)
+	request.Header.Set(webhook.TimestampHeader, timestamp)
+	request.Header.Set(webhook.EventHeader, delivery.EventType)
+	request.Header.Set(webhook.DeliveryHeader, delivery.GetUUID())
+
+	response, errPost := d.httpClient.Do(request)
+	if errPost != nil {
+		return 0, errPost
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return response.StatusCode, fmt.Errorf("endpoint responded %s", response.Status)
+	}
+	return response.StatusCode, nil
+}
+
+// newWebhookClient connects only to public addresses and never through a proxy, so tenant
+// endpoints cannot reach paystore's own network, whatever their host resolves to.
+func newWebhookClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout, Control: webhook.DialControl}
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+			MaxIdleConns:        100,
+			IdleConnTimeout:     90 * time.Second,
+		},
+	}
+}
+
+func NewWebhookDispatcher(redis redis.UniversalClient, webhookRepository webhook.RepositoryClient,
+	config *config.App) *WebhookDispatcher {
+	hostname, _ := os.Hostname()
+
+	return &WebhookDispatcher{
+		redis:             redis,
+		webhookRepository: webhookRepository,
+		httpClient:        newWebhookClient(config.WebhookTimeout),
+		config:            config,
+		consumer:          fmt.Sprintf("%s-%d", hostname, os.Getpid()),
+		stop:              make(chan struct{}),
+	}
+}
@@ -0,0 +1,75 @@
+package operation
+
+import (
+	"github.com/redis/go-redis/v9"
+	"paystore/config"
+	"paystore/lib/helper"
+	"paystore/lib/lock"
+	"time"
+)
+
+const withdrawPollLockKey = "paystore:lock:withdraw-poll"
+
+// WithdrawPollJob polls the disbursement provider for stuck withdraws on a fixed interval,
+// under a Redis lock leased for one interval so only one instance polls at a time.
+type WithdrawPollJob struct {
+	paystoreClient *PaystoreClient
+	redis          redis.UniversalClient
+	config         *config.App
+	stop           chan struct{}
+}
+
+func (j *WithdrawPollJob) Start() {
+	go func() {
+		ticker := time.NewTicker(j.config.WithdrawPollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-j.stop:
+				return
+			case tick := <-ticker.C:
+				j.run(tick)
+			}
+		}
+	}()
+}
+
+func (j *WithdrawPollJob) Stop() {
+	close(j.stop)
+}
+
+func (j *WithdrawPollJob) run(now time.Time) {
+	pollLock, errLock := lock.Acquire(j.redis, withdrawPollLockKey, j.config.WithdrawPollInterval)
+	if errLock != nil {
+		helper.Logger.Error("withdraw-poll-lock-error", "component", "paystore", "source", "operation.WithdrawPollJob",
+			"error", errLock.Error())
+		return
+	}
+	if pollLock == nil {
+		return
+	}
+	defer pollLock.Release()
+
+	settled, errPoll := j.paystoreClient.PollWithdraws(now, j.config.WithdrawPollThreshold,
+		j.config.WithdrawPollBatchSize, j.config.WithdrawPollBackoff, j.config.WithdrawPollMaxAttempts)
+	if errPoll != nil {
+		helper.Logger.Error("withdraw-poll-error", "component", "paystore", "source", "operation.WithdrawPollJob",
+			"error", errPoll.Error())
+		return
+	}
+	if settled > 0 {
+		helper.Logger.Info("withdraw-poll", "component", "paystore", "source", "operation.WithdrawPollJob",
+			"settled", settled)
+	}
+}
+
+func NewWithdrawPollJob(paystoreClient *PaystoreClient, redis redis.UniversalClient,
+	config *config.App) *WithdrawPollJob {
+	return &WithdrawPollJob{
+		paystoreClient: paystoreClient,
+		redis:          redis,
+		config:         config,
+		stop:           make(chan struct{}),
+	}
+}
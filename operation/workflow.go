@@ -5,23 +5,219 @@ import (
 	"github.com/redis/go-redis/v9"
 	"paystore/config"
 	"paystore/lib/balance"
+	"paystore/lib/fee"
+	"paystore/lib/helper"
+	"paystore/lib/limit"
 	"paystore/lib/organization"
+	"paystore/lib/outbox"
 	"paystore/lib/payment"
+	"paystore/lib/provider"
+	"paystore/lib/reconciliation"
+	"paystore/lib/revenue"
+	"paystore/lib/schema"
+	"paystore/lib/settlement"
+	"paystore/lib/statement"
 	"paystore/lib/transaction"
+	"paystore/lib/webhook"
 	"paystore/lib/withdraw"
+	"sort"
+	"time"
 )
 
+const DispatchRejectedFailureCode = "DISBURSEMENT_REJECTED"
+
+var paymentEvents = map[payment.PaymentStatus]outbox.EventType{
+	payment.PaymentStatusPaid:    outbox.EventPaymentPaid,
+	payment.PaymentStatusFailed:  outbox.EventPaymentFailed,
+	payment.PaymentStatusExpired: outbox.EventPaymentExpired,
+}
+
+var withdrawEvents = map[withdraw.WithdrawStatus]outbox.EventType{
+	withdraw.StatusSuccess: outbox.EventWithdrawSucceeded,
+	withdraw.StatusFailed:  outbox.EventWithdrawFailed,
+	withdraw.StatusReview:  outbox.EventWithdrawReview,
+}
+
+var organizationEvents = map[organization.Status]outbox.EventType{
+	organization.StatusActive:    outbox.EventOrganizationActivated,
+	organization.StatusSuspended: outbox.EventOrganizationSuspended,
+	organization.StatusClosed:    outbox.EventOrganizationClosed,
+}
+
+// maxPollBackoffShift caps the exponential poll backoff at 2^16 times the base interval.
+const maxPollBackoffShift = 16
+
 type OrganizationClient struct {
 	organizationRepository organization.RepositoryClient
 }
 
 type PaystoreClient struct {
-	writeDB                *sql.DB
-	balanceRepository      balance.RepositoryClient
-	paymentRepository      payment.RepositoryClient
-	transactionRepository  transaction.RepositoryClient
-	organizationRepository organization.RepositoryClient
-	withdrawRepository     withdraw.RepositoryClient
+	writeDB                  *sql.DB
+	balanceRepository        balance.RepositoryClient
+	paymentRepository        payment.RepositoryClient
+	transactionRepository    transaction.RepositoryClient
+	organizationRepository   organization.RepositoryClient
+	withdrawRepository       withdraw.RepositoryClient
+	statementRepository      statement.RepositoryClient
+	paymentProviders         map[string]provider.PaymentProvider
+	invoiceDurations         map[string]time.Duration
+	disbursementProviders    map[string]provider.DisbursementProvider
+	reconciliationRepository reconciliation.RepositoryClient
+	outboxRepository         outbox.RepositoryClient
+	webhookRepository        webhook.RepositoryClient
+	paymentVendors           []*config.Vendor
+	withdrawVendors          []*config.Vendor
+	feeRepository            fee.RepositoryClient
+	revenueRepository        revenue.RepositoryClient
+	limitRepository          limit.RepositoryClient
+	limitCounter             limit.CounterClient
+	settlementRepository     settlement.RepositoryClient
+}
+
+// SetPaymentProvider makes CreatePayment open an invoice with the provider for every new
+// payment processed by the vendor. Invoices the provider opens stay payable for invoiceDuration.
+func (ps *PaystoreClient) SetPaymentProvider(vendorCode string, paymentProvider provider.PaymentProvider,
+	invoiceDuration time.Duration) {
+	if ps.paymentProviders == nil {
+		ps.paymentProviders = make(map[string]provider.PaymentProvider)
+		ps.invoiceDurations = make(map[string]time.Duration)
+	}
+	ps.paymentProviders[vendorCode] = paymentProvider
+	ps.invoiceDurations[vendorCode] = invoiceDuration
+}
+
+// SetDisbursementProvider makes CreateWithdraw submit the payout of every withdraw processed
+// by the vendor once the withdraw is committed.
+func (ps *PaystoreClient) SetDisbursementProvider(vendorCode string, disbursementProvider provider.DisbursementProvider) {
+	if ps.disbursementProviders == nil {
+		ps.disbursementProviders = make(map[string]provider.DisbursementProvider)
+	}
+	ps.disbursementProviders[vendorCode] = disbursementProvider
+}
+
+// SetVendors sets the vendor registries vendor codes are resolved and callbacks decoded with.
+func (ps *PaystoreClient) SetVendors(paymentVendors []*config.Vendor, withdrawVendors []*config.Vendor) {
+	ps.paymentVendors = paymentVendors
+	ps.withdrawVendors = withdrawVendors
+}
+
+// paymentVendorCode resolves a requested vendor code to a registered one. Without a
+// registry, payments are not attributed to any vendor.
+func (ps *PaystoreClient) paymentVendorCode(vendorCode string) (string, error) {
+	if vendorCode == "" && len(ps.paymentVendors) == 0 {
+		return "", nil
+	}
+	vendor, errFind := config.FindVendor(ps.paymentVendors, vendorCode)
+	if errFind != nil {
+		return "", errFind
+	}
+	return vendor.Code, nil
+}
+
+// withdrawVendorCode resolves a requested vendor code to a registered one. Without a
+// registry, withdraws are not attributed to any vendor.
+func (ps *PaystoreClient) withdrawVendorCode(vendorCode string) (string, error) {
+	if vendorCode == "" && len(ps.withdrawVendors) == 0 {
+		return "", nil
+	}
+	vendor, errFind := config.FindVendor(ps.withdrawVendors, vendorCode)
+	if errFind != nil {
+		return "", errFind
+	}
+	return vendor.Code, nil
+}
+
+// defaultVendorCode is the code of the registry's default vendor, or an empty string without
+// a registry.
+func defaultVendorCode(vendors []*config.Vendor) string {
+	if len(vendors) == 0 {
+		return ""
+	}
+	return vendors[0].Code
+}
+
+// paymentProvider returns the provider of the vendor that processed a payment, or nil.
+// Payments recorded before vendors were tracked belong to the default vendor.
+func (ps *PaystoreClient) paymentProvider(vendorCode string) provider.PaymentProvider {
+	if vendorCode == "" && len(ps.paymentVendors) > 0 {
+		vendorCode = ps.paymentVendors[0].Code
+	}
+	return ps.paymentProviders[vendorCode]
+}
+
+// invoiceDuration returns how long invoices opened by the vendor's provider stay payable.
+func (ps *PaystoreClient) invoiceDuration(vendorCode string) time.Duration {
+	if vendorCode == "" && len(ps.paymentVendors) > 0 {
+		vendorCode = ps.paymentVendors[0].Code
+	}
+	return ps.invoiceDurations[vendorCode]
+}
+
+// disbursementProvider returns the provider of the vendor that processed a withdraw, or nil.
+// Withdraws recorded before vendors were tracked belong to the default vendor.
+func (ps *PaystoreClient) disbursementProvider(vendorCode string) provider.DisbursementProvider {
+	if vendorCode == "" && len(ps.withdrawVendors) > 0 {
+		vendorCode = ps.withdrawVendors[0].Code
+	}
+	return ps.disbursementProviders[vendorCode]
+}
+
+// DecodePaymentVendor reads a payment vendor payload through the schema of the vendor with
+// the given code; an empty code is the default vendor.
+func (ps *PaystoreClient) DecodePaymentVendor(vendorCode string, body []byte) (*schema.Record, error) {
+	if len(ps.paymentVendors) == 0 {
+		return nil, schema.SchemaRequired
+	}
+	vendor, errFind := config.FindVendor(ps.paymentVendors, vendorCode)
+	if errFind != nil {
+		return nil, errFind
+	}
+	return vendor.Schema.Decode(body)
+}
+
+// DecodeWithdrawVendor reads a withdraw vendor payload through the schema of the vendor with
+// the given code; an empty code is the default vendor.
+func (ps *PaystoreClient) DecodeWithdrawVendor(vendorCode string, body []byte) (*schema.Record, error) {
+	if len(ps.withdrawVendors) == 0 {
+		return nil, schema.SchemaRequired
+	}
+	vendor, errFind := config.FindVendor(ps.withdrawVendors, vendorCode)
+	if errFind != nil {
+		return nil, errFind
+	}
+	return vendor.Schema.Decode(body)
+}
+
+// recordEvent writes a domain event to the outbox in the caller's transaction, so the event
+// is published if and only if the change it describes is committed.
+func (ps *PaystoreClient) recordEvent(tx *sql.Tx, eventType outbox.EventType, aggregateType outbox.AggregateType,
+	aggregateUUID string, organizationUUID string, aggregate interface{}) error {
+	event, errEvent := outbox.NewEvent(eventType, aggregateType, aggregateUUID, organizationUUID, aggregate)
+	if errEvent != nil {
+		return errEvent
+	}
+
+	return ps.outboxRepository.Create(tx, event)
+}
+
+// commitHooks collects the work a transaction defers until it has committed, such as cache
+// writes, which must not become visible for a transaction that is rolled back.
+type commitHooks []func() error
+
+func (h *commitHooks) add(hook func() error) {
+	*h = append(*h, hook)
+}
+
+// run runs the hooks in order once the transaction has committed. The changes are durable by
+// then, so a failing hook is logged rather than returned.
+func (h commitHooks) run(source string) {
+	for _, hook := range h {
+		errHook := hook()
+		if errHook != nil {
+			helper.Logger.Error("commit-hook-error", "component", "paystore", "source", source,
+				"error", errHook.Error())
+		}
+	}
 }
 
 func (ps *PaystoreClient) CreateBalance(externalID string,
@@ -30,6 +226,10 @@ func (ps *PaystoreClient) CreateBalance(externalID string,
 	if errFind != nil {
 		return nil, errFind
 	}
+	errActive := ps.checkActive(organizationFromDB)
+	if errActive != nil {
+		return nil, errActive
+	}
 
 	newBalance := balance.NewBalance()
 	newBalance.OrganizationUUID = organizationFromDB.GetUUID()
@@ -37,24 +237,65 @@ func (ps *PaystoreClient) CreateBalance(externalID string,
 	newBalance.ExternalID = externalID
 	newBalance.Active = true
 
-	errCreate := ps.balanceRepository.Create(newBalance)
+	tx, errInitTx := ps.writeDB.Begin()
+	if errInitTx != nil {
+		return nil, errInitTx
+	}
+	defer tx.Rollback()
+
+	errCreate := ps.balanceRepository.Create(tx, newBalance)
 	if errCreate != nil {
 		return nil, errCreate
 	}
 
+	errEvent := ps.recordEvent(tx, outbox.EventBalanceCreated, outbox.AggregateBalance, newBalance.GetUUID(),
+		newBalance.OrganizationUUID, newBalance)
+	if errEvent != nil {
+		return nil, errEvent
+	}
+
+	errCommit := tx.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	errSet := ps.balanceRepository.SetCache(newBalance)
+	if errSet == nil {
+		errSet = ps.balanceRepository.AddToTimeline(newBalance)
+	}
+	if errSet != nil {
+		helper.Logger.Error("commit-hook-error", "component", "paystore", "source", "operation.CreateBalance",
+			"error", errSet.Error())
+	}
+
 	return newBalance, nil
 }
 
-func (ps *PaystoreClient) CreatePayment(accountUUID string, amount int64) (*payment.Payment, error) {
+// CreatePayment opens a payment processed by the vendor with the given code; an empty code
+// is the default vendor. The fee is priced by the organization's fee schedule for channel.
+func (ps *PaystoreClient) CreatePayment(accountUUID string, amount int64, vendorCode string,
+	channel string) (*payment.Payment, error) {
+	vendorCode, errVendor := ps.paymentVendorCode(vendorCode)
+	if errVendor != nil {
+		return nil, errVendor
+	}
+
 	balanceFromDB, errFind := ps.balanceRepository.FindByUUID(accountUUID)
 	if errFind != nil {
 		return nil, errFind
 	}
+	if balanceFromDB.Kind == balance.KindRevenue {
+		return nil, balance.RevenueNotPayable
+	}
 
 	organizationFromDB, errFind := ps.organizationRepository.FindByUUID(balanceFromDB.OrganizationUUID)
 	if errFind != nil {
 		return nil, errFind
 	}
+	errActive := ps.checkActive(organizationFromDB)
+	if errActive != nil {
+		return nil, errActive
+	}
 
 	previousPayment, errFind := ps.paymentRepository.FindLatestPayment(balanceFromDB)
 	if errFind != nil {
@@ -62,11 +303,55 @@ func (ps *PaystoreClient) CreatePayment(accountUUID string, amount int64) (*paym
 	}
 
 	newPayment := payment.NewPayment()
-	newPayment.SetBalance(balanceFromDB)
-	newPayment.SetAmount(amount, balanceFromDB.Balance, organizationFromDB)
-	newPayment.GenerateHash(previousPayment)
+	feeSchedule, errFind := ps.feeSchedule(organizationFromDB, fee.KindPayment, newPayment.GetCreatedAt())
+	if errFind != nil {
+		return nil, errFind
+	}
+	feeBreakdown, errFee := feeSchedule.Evaluate(amount, channel)
+	if errFee != nil {
+		return nil, errFee
+	}
 
+	newPayment.SetBalance(balanceFromDB)
+	newPayment.Channel = channel
+	errAmount := newPayment.SetAmount(amount, balanceFromDB.Balance, feeBreakdown)
+	if errAmount != nil {
+		return nil, errAmount
+	}
 	newPayment.OrganizationUUID = organizationFromDB.GetUUID()
+	newPayment.VendorCode = vendorCode
+
+	usage, errLimit := ps.reserveLimits(limit.KindPayment, newPayment.GetUUID(), balanceFromDB, organizationFromDB, amount,
+		newPayment.GetCreatedAt())
+	if errLimit != nil {
+		return nil, errLimit
+	}
+	created := false
+	defer func() {
+		if !created {
+			ps.releaseLimits(usage)
+		}
+	}()
+
+	paymentProvider := ps.paymentProvider(vendorCode)
+	var invoice *schema.Record
+	if paymentProvider != nil {
+		var errInvoice error
+		invoice, errInvoice = paymentProvider.CreateInvoice(provider.InvoiceRequest{
+			ExternalID: newPayment.GetUUID(),
+			Amount:     amount,
+			Currency:   balanceFromDB.Currency,
+			Duration:   ps.invoiceDuration(vendorCode),
+		})
+		if errInvoice != nil {
+			return nil, errInvoice
+		}
+		newPayment.SetVendorRecord(vendorCode, invoice.ID())
+		newPayment.PaymentVendorRandId = invoice.GetRandId()
+		newPayment.PaymentVendor = invoice
+	}
+
+	newPayment.GenerateHash(previousPayment)
 
 	newTranscation := transaction.NewTransaction()
 	newTranscation.SetType(transaction.TypePayment)
@@ -79,187 +364,1819 @@ func (ps *PaystoreClient) CreatePayment(accountUUID string, amount int64) (*paym
 	}
 	defer tx.Rollback()
 
-	errCreatePayment := ps.paymentRepository.Create(tx, newPayment, balanceFromDB, organizationFromDB)
-	if errCreatePayment != nil {
-		return nil, errCreatePayment
-	}
-
-	errCreateTransaction := ps.transactionRepository.Create(tx, newTranscation)
-	if errCreateTransaction != nil {
-		return nil, errCreateTransaction
+	var hooks commitHooks
+	errCreate := ps.createPayment(tx, &hooks, newPayment, newTranscation, balanceFromDB, organizationFromDB, invoice)
+	if errCreate == nil {
+		errCreate = tx.Commit()
 	}
-
-	errCommit := tx.Commit()
-	if errCommit != nil {
-		return nil, errCommit
+	if errCreate != nil {
+		if invoice != nil {
+			_, errCancel := paymentProvider.CancelInvoice(invoice.ID())
+			if errCancel != nil {
+				helper.Logger.Error("cancel-invoice-error", "component", "paystore", "source", "operation.CreatePayment",
+					"vendorRecordID", invoice.ID(), "error", errCancel.Error())
+			}
+		}
+		return nil, errCreate
 	}
 
+	created = true
+	hooks.run("operation.CreatePayment")
 	return newPayment, nil
 }
 
-func (ps *PaystoreClient) FinalizedPayment(accountUUID string,
-	paymentUUID string, paymentStatus payment.PaymentStatus, vendorRecordID string) error {
-	balanceFromDB, errFind := ps.balanceRepository.FindByUUID(accountUUID)
-	if errFind != nil {
-		return errFind
+// CreateSplitPayment opens one inbound payment of amount divided among shares. The first share
+// is the parent payment, invoiced by the vendor for the whole amount; the other shares are
+// linked to it and settled when it is finalized. Each share is priced and limited by its own
+// organization, and all of them are created in one transaction.
+func (ps *PaystoreClient) CreateSplitPayment(amount int64, shares []payment.Share, vendorCode string,
+	channel string) ([]*payment.Payment, error) {
+	vendorCode, errVendor := ps.paymentVendorCode(vendorCode)
+	if errVendor != nil {
+		return nil, errVendor
 	}
 
-	paymentFromDB, errFind := ps.paymentRepository.FindByUUID(paymentUUID)
-	if errFind != nil {
-		return errFind
+	allocations, errAllocate := payment.AllocateShares(amount, shares)
+	if errAllocate != nil {
+		return nil, errAllocate
 	}
 
-	updateBalance := false
-	if paymentStatus == payment.PaymentStatusFailed {
-		paymentFromDB.SetFailed()
-	} else if paymentStatus == payment.PaymentStatusPaid {
-		paymentFromDB.SetPaid()
-		paymentFromDB.SetVendorRecord(vendorRecordID)
-		balanceFromDB.LastReceive = paymentFromDB.GetCreatedAt()
-		balanceFromDB.Collect(paymentFromDB.Amount)
-		updateBalance = true
+	var usages []*limit.Usage
+	created := false
+	defer func() {
+		if !created {
+			for _, usage := range usages {
+				ps.releaseLimits(usage)
+			}
+		}
+	}()
+
+	newPayments := make([]*payment.Payment, len(shares))
+	balances := make([]*balance.Balance, len(shares))
+	organizations := make([]*organization.Organization, len(shares))
+	previousPayments := make([]*payment.Payment, len(shares))
+	for i, share := range shares {
+		balanceFromDB, errFind := ps.balanceRepository.FindByUUID(share.BalanceUUID)
+		if errFind != nil {
+			return nil, errFind
+		}
+		if balanceFromDB.Kind == balance.KindRevenue {
+			return nil, balance.RevenueNotPayable
+		}
+		if i > 0 && balanceFromDB.Currency != balances[0].Currency {
+			return nil, payment.ShareCurrencyMismatch
+		}
+
+		organizationFromDB, errFind := ps.organizationRepository.FindByUUID(balanceFromDB.OrganizationUUID)
+		if errFind != nil {
+			return nil, errFind
+		}
+		errActive := ps.checkActive(organizationFromDB)
+		if errActive != nil {
+			return nil, errActive
+		}
+
+		previousPayment, errFind := ps.paymentRepository.FindLatestPayment(balanceFromDB)
+		if errFind != nil {
+			return nil, errFind
+		}
+
+		newPayment := payment.NewPayment()
+		feeSchedule, errFind := ps.feeSchedule(organizationFromDB, fee.KindPayment, newPayment.GetCreatedAt())
+		if errFind != nil {
+			return nil, errFind
+		}
+		feeBreakdown, errFee := feeSchedule.Evaluate(allocations[i], channel)
+		if errFee != nil {
+			return nil, errFee
+		}
+
+		newPayment.SetBalance(balanceFromDB)
+		newPayment.Channel = channel
+		errAmount := newPayment.SetAmount(allocations[i], balanceFromDB.Balance, feeBreakdown)
+		if errAmount != nil {
+			return nil, errAmount
+		}
+		newPayment.OrganizationUUID = organizationFromDB.GetUUID()
+		newPayment.VendorCode = vendorCode
+		if i > 0 {
+			newPayment.SetParent(newPayments[0])
+		}
+
+		usage, errLimit := ps.reserveLimits(limit.KindPayment, newPayment.GetUUID(), balanceFromDB, organizationFromDB,
+			allocations[i], newPayment.GetCreatedAt())
+		if errLimit != nil {
+			return nil, errLimit
+		}
+		usages = append(usages, usage)
+
+		newPayments[i] = newPayment
+		balances[i] = balanceFromDB
+		organizations[i] = organizationFromDB
+		previousPayments[i] = previousPayment
+	}
+
+	paymentProvider := ps.paymentProvider(vendorCode)
+	var invoice *schema.Record
+	if paymentProvider != nil {
+		var errInvoice error
+		invoice, errInvoice = paymentProvider.CreateInvoice(provider.InvoiceRequest{
+			ExternalID: newPayments[0].GetUUID(),
+			Amount:     amount,
+			Currency:   balances[0].Currency,
+			Duration:   ps.invoiceDuration(vendorCode),
+		})
+		if errInvoice != nil {
+			return nil, errInvoice
+		}
+		for _, newPayment := range newPayments {
+			newPayment.SetVendorRecord(vendorCode, invoice.ID())
+			newPayment.PaymentVendorRandId = invoice.GetRandId()
+			newPayment.PaymentVendor = invoice
+		}
 	}
 
 	tx, errInitTx := ps.writeDB.Begin()
 	if errInitTx != nil {
-		return errInitTx
+		return nil, errInitTx
 	}
 	defer tx.Rollback()
 
-	errUpdatePayment := ps.paymentRepository.Update(tx, paymentFromDB)
-	if errUpdatePayment != nil {
-		return errUpdatePayment
-	}
+	var hooks commitHooks
+	var errCreate error
+	for i, newPayment := range newPayments {
+		newPayment.GenerateHash(previousPayments[i])
 
-	if updateBalance {
-		errUpdateBalance := ps.balanceRepository.Update(tx, balanceFromDB)
-		if errUpdateBalance != nil {
-			return errUpdateBalance
+		newTransaction := transaction.NewTransaction()
+		newTransaction.SetType(transaction.TypePayment)
+		newTransaction.SetRecord(newPayment)
+		newTransaction.SetBalance(balances[i])
+
+		shareInvoice := invoice
+		if i > 0 {
+			shareInvoice = nil
+		}
+		errCreate = ps.createPayment(tx, &hooks, newPayment, newTransaction, balances[i], organizations[i], shareInvoice)
+		if errCreate != nil {
+			break
 		}
 	}
-
-	errCommit := tx.Commit()
-	if errCommit != nil {
-		return errCommit
+	if errCreate == nil {
+		errCreate = tx.Commit()
+	}
+	if errCreate != nil {
+		if invoice != nil {
+			_, errCancel := paymentProvider.CancelInvoice(invoice.ID())
+			if errCancel != nil {
+				helper.Logger.Error("cancel-invoice-error", "component", "paystore", "source",
+					"operation.CreateSplitPayment", "vendorRecordID", invoice.ID(), "error", errCancel.Error())
+			}
+		}
+		return nil, errCreate
 	}
 
-	return nil
+	created = true
+	hooks.run("operation.CreateSplitPayment")
+	return newPayments, nil
 }
 
-func (ps *PaystoreClient) CreateWithdraw(accountUUID string, amount int64) (*withdraw.Withdraw, error) {
-	balanceFromDB, errFind := ps.balanceRepository.FindByUUID(accountUUID)
+// reserveLimits counts a new payment or withdraw against the limits of its balance and
+// organization, rejecting it when it would exceed one. Without a counter nothing is limited.
+func (ps *PaystoreClient) reserveLimits(kind limit.Kind, movementUUID string, balanceFromDB *balance.Balance,
+	organizationFromDB *organization.Organization, amount int64, at time.Time) (*limit.Usage, error) {
+	if ps.limitCounter == nil {
+		return nil, nil
+	}
+
+	organizationLimit, errFind := ps.findOrganizationLimit(organizationFromDB)
 	if errFind != nil {
 		return nil, errFind
 	}
-
-	organizationFromDB, errFind := ps.organizationRepository.FindByUUID(balanceFromDB.OrganizationUUID)
+	balanceLimit, errFind := ps.findLimit(limit.ScopeBalance, balanceFromDB.GetUUID())
 	if errFind != nil {
 		return nil, errFind
 	}
 
-	if balanceFromDB.Balance < amount {
-		return nil, balance.InsufficientFunds
+	usage := &limit.Usage{
+		Kind:             kind,
+		MovementUUID:     movementUUID,
+		OrganizationUUID: balanceFromDB.OrganizationUUID,
+		BalanceUUID:      balanceFromDB.GetUUID(),
+		Amount:           amount,
+		At:               at,
 	}
+	errReserve := ps.limitCounter.Reserve(*usage, organizationLimit, balanceLimit)
+	if errReserve != nil {
+		return nil, errReserve
+	}
+	return usage, nil
+}
 
-	newWithdraw := withdraw.NewWithdraw()
-	newWithdraw.SetBalance(balanceFromDB)
-	newWithdraw.SetAmount(amount, balanceFromDB.Balance)
-	newWithdraw.SetOrganization(organizationFromDB)
-
-	newTransaction := transaction.NewTransaction()
-	newTransaction.SetType(transaction.TypeWithdraw)
-	newTransaction.SetRecord(newWithdraw)
-	newTransaction.SetBalance(balanceFromDB)
-
-	tx, errInitTx := ps.writeDB.Begin()
-	if errInitTx != nil {
-		return nil, errInitTx
+// releaseLimits uncounts a payment or withdraw that was not created, or did not go through.
+func (ps *PaystoreClient) releaseLimits(usage *limit.Usage) {
+	if usage == nil || ps.limitCounter == nil {
+		return
 	}
-	defer tx.Rollback()
+	errRelease := ps.limitCounter.Release(*usage)
+	if errRelease != nil {
+		helper.Logger.Error("release-limits-error", "component", "paystore", "source", "operation.releaseLimits",
+			"movementUUID", usage.MovementUUID, "error", errRelease.Error())
+	}
+}
 
-	errCreate := ps.withdrawRepository.Create(tx, newWithdraw, balanceFromDB, organizationFromDB)
-	if errCreate != nil {
-		return nil, errCreate
+// paymentUsage is the usage a payment was reserved with: its amount before fees, counted when
+// it was created.
+func paymentUsage(countedPayment *payment.Payment) *limit.Usage {
+	return &limit.Usage{
+		Kind:             limit.KindPayment,
+		MovementUUID:     countedPayment.GetUUID(),
+		OrganizationUUID: countedPayment.OrganizationUUID,
+		BalanceUUID:      countedPayment.BalanceUUID,
+		Amount:           countedPayment.Amount + countedPayment.Fees,
+		At:               countedPayment.GetCreatedAt(),
 	}
+}
 
-	errCreate = ps.transactionRepository.Create(tx, newTransaction)
-	if errCreate != nil {
-		return nil, errCreate
+// withdrawUsage is the usage a withdraw was reserved with: the debit, counted when it was created.
+func withdrawUsage(countedWithdraw *withdraw.Withdraw) *limit.Usage {
+	return &limit.Usage{
+		Kind:             limit.KindWithdraw,
+		MovementUUID:     countedWithdraw.GetUUID(),
+		OrganizationUUID: countedWithdraw.OrganizationUUID,
+		BalanceUUID:      countedWithdraw.BalanceUUID,
+		Amount:           countedWithdraw.Debit(),
+		At:               countedWithdraw.GetCreatedAt(),
 	}
+}
 
-	errCommit := tx.Commit()
-	if errCommit != nil {
-		return nil, errCommit
+// countLimits counts a payment or withdraw without checking it against any limit.
+func (ps *PaystoreClient) countLimits(usage *limit.Usage) error {
+	if ps.limitCounter == nil {
+		return nil
 	}
+	return ps.limitCounter.Reserve(*usage, nil, nil)
+}
 
-	return newWithdraw, nil
+// findLimit returns the limit of the scope, or nil when none is set.
+func (ps *PaystoreClient) findLimit(scope limit.Scope, scopeUUID string) (*limit.Limit, error) {
+	if ps.limitRepository == nil {
+		return nil, nil
+	}
+	limitFromDB, errFind := ps.limitRepository.Find(scope, scopeUUID)
+	if errFind == limit.LimitNotFound {
+		return nil, nil
+	}
+	return limitFromDB, errFind
 }
 
-func (ps *PaystoreClient) FinalizedWithdraw(accountUUID string,
-	withdrawUUID string, withdrawStatus withdraw.WithdrawStatus, vendorRecordID string) error {
-	balanceFromDB, errFind := ps.balanceRepository.FindByUUID(accountUUID)
-	if errFind != nil {
-		return errFind
+// findOrganizationLimit returns the limit of the organization, inherited from the nearest
+// parent that sets one when the organization sets none itself.
+func (ps *PaystoreClient) findOrganizationLimit(organizationFromDB *organization.Organization) (*limit.Limit, error) {
+	chain, errChain := ps.organizationChain(organizationFromDB)
+	if errChain != nil {
+		return nil, errChain
+	}
+	for _, member := range chain {
+		limitFromDB, errFind := ps.findLimit(limit.ScopeOrganization, member.GetUUID())
+		if errFind != nil || limitFromDB != nil {
+			return limitFromDB, errFind
+		}
 	}
+	return nil, nil
+}
 
-	withdrawFromDB, errFind := ps.withdrawRepository.FindByUUID(withdrawUUID)
-	if errFind != nil {
-		return errFind
+// SetLimit replaces the limits of an organization or a balance. Zero fields leave a limit off.
+func (ps *PaystoreClient) SetLimit(newLimit *limit.Limit) (*limit.Limit, error) {
+	errValidate := newLimit.Validate()
+	if errValidate != nil {
+		return nil, errValidate
 	}
 
-	updateBalance := false
-	if withdrawStatus == withdraw.StatusFailed {
-		withdrawFromDB.SetFailed()
+	var errFind error
+	if newLimit.Scope == limit.ScopeOrganization {
+		_, errFind = ps.organizationRepository.FindByUUID(newLimit.ScopeUUID)
 	} else {
-		withdrawFromDB.SetSuccess()
-		withdrawFromDB.SetVendorRecord(vendorRecordID)
-		balanceFromDB.LastWithdraw = withdrawFromDB.GetCreatedAt()
-		balanceFromDB.Withdraw(withdrawFromDB.Amount)
-		updateBalance = true
+		_, errFind = ps.balanceRepository.FindByUUID(newLimit.ScopeUUID)
+	}
+	if errFind != nil {
+		return nil, errFind
 	}
 
-	tx, errInitTx := ps.writeDB.Begin()
-	if errInitTx != nil {
-		return errInitTx
+	errUpsert := ps.limitRepository.Upsert(newLimit)
+	if errUpsert != nil {
+		return nil, errUpsert
 	}
-	defer tx.Rollback()
+	return newLimit, nil
+}
 
-	errUpdateWithdraw := ps.withdrawRepository.Update(tx, withdrawFromDB)
-	if errUpdateWithdraw != nil {
-		return errUpdateWithdraw
+// GetLimit returns the limits of an organization or a balance.
+func (ps *PaystoreClient) GetLimit(scope limit.Scope, scopeUUID string) (*limit.Limit, error) {
+	if scope != limit.ScopeOrganization && scope != limit.ScopeBalance {
+		return nil, limit.UnknownScope
 	}
+	return ps.limitRepository.Find(scope, scopeUUID)
+}
 
-	if updateBalance {
-		errUpdateBalance := ps.balanceRepository.Update(tx, balanceFromDB)
-		if errUpdateBalance != nil {
-			return errUpdateBalance
+// organizationChain returns the organization followed by its parent, its parent's parent and
+// so on up to its top-level organization.
+func (ps *PaystoreClient) organizationChain(organizationFromDB *organization.Organization) ([]*organization.Organization, error) {
+	chain := []*organization.Organization{organizationFromDB}
+	visited := map[string]bool{organizationFromDB.GetUUID(): true}
+	for member := organizationFromDB; member.ParentUUID != ""; {
+		if visited[member.ParentUUID] {
+			return nil, organization.CyclicParent
 		}
+		parent, errFind := ps.organizationRepository.FindByUUID(member.ParentUUID)
+		if errFind != nil {
+			return nil, errFind
+		}
+		visited[parent.GetUUID()] = true
+		chain = append(chain, parent)
+		member = parent
 	}
+	return chain, nil
+}
 
-	errCommit := tx.Commit()
-	if errCommit != nil {
-		return errCommit
+// feeSchedule returns the fee schedule of the kind in force at the time for the organization.
+// It is the first one found up the organization's chain, where an organization's own version
+// in force or, for payments, its own flat fee overrides its parent's. Without any, payments
+// are priced by the top-level organization's flat fee and withdraws are free. The rounding
+// mode is likewise the organization's own or the nearest parent's.
+func (ps *PaystoreClient) feeSchedule(organizationFromDB *organization.Organization, kind fee.Kind,
+	at time.Time) (*fee.Schedule, error) {
+	chain, errChain := ps.organizationChain(organizationFromDB)
+	if errChain != nil {
+		return nil, errChain
 	}
 
-	return nil
+	var schedule *fee.Schedule
+	for _, member := range chain {
+		if ps.feeRepository != nil {
+			scheduleFromDB, errFind := ps.feeRepository.FindInForce(member.GetUUID(), kind, at)
+			if errFind == nil {
+				schedule = scheduleFromDB
+				break
+			}
+			if errFind != fee.ScheduleNotFound {
+				return nil, errFind
+			}
+		}
+		if kind == fee.KindPayment && member.HasFlatFee() {
+			schedule = member.FlatFeeSchedule()
+			break
+		}
+	}
+	if schedule == nil {
+		schedule = defaultFeeSchedule(chain[len(chain)-1], kind)
+	}
+
+	schedule.Rounding = feeRounding(chain)
+	return schedule, nil
 }
 
-type PaymentSeeder struct {
-	ps *PaystoreClient
+// feeRounding is the rounding mode of the first organization of chain that sets one.
+func feeRounding(chain []*organization.Organization) fee.RoundingMode {
+	for _, member := range chain {
+		if member.FeeRounding != "" {
+			return member.FeeRounding
+		}
+	}
+	return fee.RoundDown
 }
 
-func (psr *PaymentSeeder) ByBalance(subtraction int64, lastRandId string, balanceUUID string) error {
-	balanceFromDB, errFind := psr.ps.balanceRepository.FindByUUID(balanceUUID)
-	if errFind != nil {
-		return errFind
+func defaultFeeSchedule(organizationFromDB *organization.Organization, kind fee.Kind) *fee.Schedule {
+	if kind == fee.KindWithdraw {
+		return fee.FlatSchedule(organizationFromDB.GetUUID(), fee.KindWithdraw, 0, 0)
 	}
-	if balanceFromDB == nil {
+	return organizationFromDB.FlatFeeSchedule()
+}
+
+// SetOrganizationParent makes the organization a sub-merchant of the parent, or a top-level
+// organization when parentUUID is empty. An organization cannot be placed under itself or
+// under one of its own sub-merchants.
+func (ps *PaystoreClient) SetOrganizationParent(organizationUUID string, parentUUID string) (*organization.Organization, error) {
+	tx, errInitTx := ps.writeDB.Begin()
+	if errInitTx != nil {
+		return nil, errInitTx
+	}
+	defer tx.Rollback()
+
+	organizationFromDB, errFind := ps.organizationRepository.FindByUUIDForUpdate(tx, organizationUUID)
+	if errFind != nil {
+		return nil, errFind
+	}
+
+	// The new parent's chain is locked up to its top-level organization, so no concurrent move
+	// can place one of them under the organization between the check and the update. Two moves
+	// that would together form a cycle lock each other's organizations and one of them aborts.
+	visited := map[string]bool{organizationUUID: true}
+	for ancestorUUID := parentUUID; ancestorUUID != ""; {
+		if visited[ancestorUUID] {
+			return nil, organization.CyclicParent
+		}
+		visited[ancestorUUID] = true
+		ancestor, errFind := ps.organizationRepository.FindByUUIDForUpdate(tx, ancestorUUID)
+		if errFind != nil {
+			return nil, errFind
+		}
+		ancestorUUID = ancestor.ParentUUID
+	}
+
+	errSet := organizationFromDB.SetParent(parentUUID)
+	if errSet != nil {
+		return nil, errSet
+	}
+	errUpdate := ps.organizationRepository.UpdateParent(tx, organizationFromDB)
+	if errUpdate != nil {
+		return nil, errUpdate
+	}
+	hooks := commitHooks{func() error {
+		return ps.organizationRepository.SetCache(organizationFromDB)
+	}}
+
+	errCommit := tx.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	hooks.run("operation.SetOrganizationParent")
+	return organizationFromDB, nil
+}
+
+// checkActive refuses new balances, payments and withdraws unless the organization and every
+// organization above it are active, so suspending a merchant also freezes its sub-merchants.
+func (ps *PaystoreClient) checkActive(organizationFromDB *organization.Organization) error {
+	chain, errChain := ps.organizationChain(organizationFromDB)
+	if errChain != nil {
+		return errChain
+	}
+	for _, member := range chain {
+		errActive := member.CheckActive()
+		if errActive != nil {
+			return errActive
+		}
+	}
+	return nil
+}
+
+// SetOrganizationStatus activates, suspends or closes an organization on behalf of actor and
+// returns the audit record of the change, which is stored and published in one transaction.
+func (ps *PaystoreClient) SetOrganizationStatus(organizationUUID string, status organization.Status, reason string,
+	actor string) (*organization.StatusChange, error) {
+	tx, errInitTx := ps.writeDB.Begin()
+	if errInitTx != nil {
+		return nil, errInitTx
+	}
+	defer tx.Rollback()
+
+	organizationFromDB, errFind := ps.organizationRepository.FindByUUIDForUpdate(tx, organizationUUID)
+	if errFind != nil {
+		return nil, errFind
+	}
+
+	change, errStatus := organizationFromDB.SetStatus(status, reason, actor)
+	if errStatus != nil {
+		return nil, errStatus
+	}
+
+	errUpdate := ps.organizationRepository.UpdateStatus(tx, organizationFromDB, change)
+	if errUpdate != nil {
+		return nil, errUpdate
+	}
+	hooks := commitHooks{func() error {
+		return ps.organizationRepository.SetCache(organizationFromDB)
+	}}
+
+	errEvent := ps.recordEvent(tx, organizationEvents[status], outbox.AggregateOrganization, organizationUUID,
+		organizationUUID, change)
+	if errEvent != nil {
+		return nil, errEvent
+	}
+
+	errCommit := tx.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	hooks.run("operation.SetOrganizationStatus")
+	return change, nil
+}
+
+// GetOrganizationStatusHistory returns the audited status changes of an organization, oldest first.
+func (ps *PaystoreClient) GetOrganizationStatusHistory(organizationUUID string) ([]*organization.StatusChange, error) {
+	_, errFind := ps.organizationRepository.FindByUUID(organizationUUID)
+	if errFind != nil {
+		return nil, errFind
+	}
+	return ps.organizationRepository.FindStatusChanges(organizationUUID)
+}
+
+// GetBalanceRollup totals the merchant balances of the organization and all of its
+// sub-merchants, one rollup per currency.
+func (ps *PaystoreClient) GetBalanceRollup(organizationUUID string) ([]*balance.Rollup, error) {
+	_, errFind := ps.organizationRepository.FindByUUID(organizationUUID)
+	if errFind != nil {
+		return nil, errFind
+	}
+	return ps.balanceRepository.Rollup(organizationUUID)
+}
+
+// SetFeeSchedule schedules a new version of the organization's fee schedule of the kind, taking
+// effect at effectiveFrom or right away when it is zero. The version in force at that time
+// ends there; payments and withdraws already created keep the version they were priced with.
+func (ps *PaystoreClient) SetFeeSchedule(organizationUUID string, kind fee.Kind, rules []fee.Rule,
+	effectiveFrom time.Time) (*fee.Schedule, error) {
+	organizationFromDB, errFind := ps.organizationRepository.FindByUUID(organizationUUID)
+	if errFind != nil {
+		return nil, errFind
+	}
+
+	schedule, errSchedule := fee.NewSchedule(organizationUUID, kind)
+	if errSchedule != nil {
+		return nil, errSchedule
+	}
+	errRules := schedule.SetRules(rules)
+	if errRules != nil {
+		return nil, errRules
+	}
+	errEffective := schedule.SetEffectiveFrom(effectiveFrom, schedule.GetCreatedAt())
+	if errEffective != nil {
+		return nil, errEffective
+	}
+
+	tx, errInitTx := ps.writeDB.Begin()
+	if errInitTx != nil {
+		return nil, errInitTx
+	}
+	defer tx.Rollback()
+
+	versions, errFind := ps.feeRepository.FindVersionsForUpdate(tx, organizationUUID, kind)
+	if errFind != nil {
+		return nil, errFind
+	}
+	endedVersions, errSupersede := schedule.Supersede(versions)
+	if errSupersede != nil {
+		return nil, errSupersede
+	}
+	for _, endedVersion := range endedVersions {
+		errEnd := ps.feeRepository.End(tx, endedVersion)
+		if errEnd != nil {
+			return nil, errEnd
+		}
+	}
+
+	errCreate := ps.feeRepository.Create(tx, schedule)
+	if errCreate != nil {
+		return nil, errCreate
+	}
+
+	errCommit := tx.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	chain, errChain := ps.organizationChain(organizationFromDB)
+	if errChain != nil {
+		return nil, errChain
+	}
+	schedule.Rounding = feeRounding(chain)
+	return schedule, nil
+}
+
+// SetFeeRounding changes how the organization's percentage fees are rounded. Payments and
+// withdraws already created keep the rounding recorded in their fee breakdown.
+func (ps *PaystoreClient) SetFeeRounding(organizationUUID string, mode fee.RoundingMode) error {
+	organizationFromDB, errFind := ps.organizationRepository.FindByUUID(organizationUUID)
+	if errFind != nil {
+		return errFind
+	}
+
+	errSet := organizationFromDB.SetFeeRounding(mode)
+	if errSet != nil {
+		return errSet
+	}
+	return ps.organizationRepository.UpdateFeeRounding(organizationFromDB)
+}
+
+// GetFeeSchedule returns the version payments or withdraws of the organization are priced with
+// at the time, or now when it is zero.
+func (ps *PaystoreClient) GetFeeSchedule(organizationUUID string, kind fee.Kind, at time.Time) (*fee.Schedule, error) {
+	if kind != fee.KindPayment && kind != fee.KindWithdraw {
+		return nil, fee.UnknownKind
+	}
+
+	organizationFromDB, errFind := ps.organizationRepository.FindByUUID(organizationUUID)
+	if errFind != nil {
+		return nil, errFind
+	}
+	if at.IsZero() {
+		at = time.Now()
+	}
+	return ps.feeSchedule(organizationFromDB, kind, at)
+}
+
+// ListFeeSchedules returns every version of the organization's fee schedule of the kind, past
+// and scheduled, in order of taking effect.
+func (ps *PaystoreClient) ListFeeSchedules(organizationUUID string, kind fee.Kind) ([]*fee.Schedule, error) {
+	if kind != fee.KindPayment && kind != fee.KindWithdraw {
+		return nil, fee.UnknownKind
+	}
+
+	organizationFromDB, errFind := ps.organizationRepository.FindByUUID(organizationUUID)
+	if errFind != nil {
+		return nil, errFind
+	}
+
+	versions, errFind := ps.feeRepository.FindVersions(organizationUUID, kind)
+	if errFind != nil {
+		return nil, errFind
+	}
+	chain, errChain := ps.organizationChain(organizationFromDB)
+	if errChain != nil {
+		return nil, errChain
+	}
+	for _, version := range versions {
+		version.Rounding = feeRounding(chain)
+	}
+	return versions, nil
+}
+
+func (ps *PaystoreClient) createPayment(tx *sql.Tx, hooks *commitHooks, newPayment *payment.Payment,
+	newTransaction *transaction.Transaction, balanceFromDB *balance.Balance, organizationFromDB *organization.Organization,
+	invoice *schema.Record) error {
+	errCreatePayment := ps.paymentRepository.Create(tx, newPayment, balanceFromDB, organizationFromDB)
+	if errCreatePayment != nil {
+		return errCreatePayment
+	}
+	hooks.add(func() error {
+		errSet := ps.paymentRepository.SetCache(newPayment)
+		if errSet != nil {
+			return errSet
+		}
+		return ps.paymentRepository.AddToTimeline(newPayment, organizationFromDB, balanceFromDB)
+	})
+
+	errCreateTransaction := ps.transactionRepository.Create(tx, newTransaction)
+	if errCreateTransaction != nil {
+		return errCreateTransaction
+	}
+
+	errEvent := ps.recordEvent(tx, outbox.EventPaymentCreated, outbox.AggregatePayment, newPayment.GetUUID(),
+		newPayment.OrganizationUUID, newPayment)
+	if errEvent != nil {
+		return errEvent
+	}
+
+	if invoice != nil {
+		errUpsert := ps.paymentRepository.UpsertVendor(tx, invoice)
+		if errUpsert != nil {
+			return errUpsert
+		}
+		hooks.add(func() error {
+			return ps.paymentRepository.SetVendorCache(invoice)
+		})
+	}
+
+	return nil
+}
+
+// FinalizedPayment settles a payment by vendor record ID. When the vendor has a payment
+// provider the full invoice is fetched from it and stored alongside the payment. An empty
+// vendor code is the vendor the payment was created with.
+func (ps *PaystoreClient) FinalizedPayment(accountUUID string, paymentUUID string,
+	paymentStatus payment.PaymentStatus, vendorRecordID string, vendorCode string) error {
+	if vendorCode == "" && len(ps.paymentProviders) > 0 && vendorRecordID != "" {
+		paymentFromDB, errFind := ps.paymentRepository.FindByUUID(paymentUUID)
+		if errFind != nil {
+			return errFind
+		}
+		vendorCode = paymentFromDB.VendorCode
+	} else if vendorCode != "" {
+		var errVendor error
+		vendorCode, errVendor = ps.paymentVendorCode(vendorCode)
+		if errVendor != nil {
+			return errVendor
+		}
+	}
+
+	if paymentProvider := ps.paymentProvider(vendorCode); paymentProvider != nil && vendorRecordID != "" {
+		invoice, errInvoice := paymentProvider.GetInvoice(vendorRecordID)
+		if errInvoice != nil {
+			return errInvoice
+		}
+		return ps.FinalizedPaymentWithVendor(accountUUID, paymentUUID, paymentStatus, invoice)
+	}
+
+	tx, errInitTx := ps.writeDB.Begin()
+	if errInitTx != nil {
+		return errInitTx
+	}
+	defer tx.Rollback()
+
+	var hooks commitHooks
+	errFinalize := ps.finalizePayment(tx, &hooks, accountUUID, paymentUUID, paymentStatus, vendorRecordID, vendorCode)
+	if errFinalize != nil {
+		return errFinalize
+	}
+
+	errCommit := tx.Commit()
+	if errCommit != nil {
+		return errCommit
+	}
+
+	hooks.run("operation.FinalizedPayment")
+	return nil
+}
+
+// FinalizedPaymentWithVendor settles a payment and upserts the vendor payload in the same transaction.
+func (ps *PaystoreClient) FinalizedPaymentWithVendor(accountUUID string,
+	paymentUUID string, paymentStatus payment.PaymentStatus, vendor *schema.Record) error {
+	tx, errInitTx := ps.writeDB.Begin()
+	if errInitTx != nil {
+		return errInitTx
+	}
+	defer tx.Rollback()
+
+	if paymentStatus == payment.PaymentStatusPaid {
+		errAmount := ps.checkCollected(tx, paymentUUID, vendor.Collected())
+		if errAmount != nil {
+			return errAmount
+		}
+	}
+
+	errUpsert := ps.paymentRepository.UpsertVendor(tx, vendor)
+	if errUpsert != nil {
+		return errUpsert
+	}
+	hooks := commitHooks{func() error {
+		return ps.paymentRepository.SetVendorCache(vendor)
+	}}
+
+	errFinalize := ps.finalizePayment(tx, &hooks, accountUUID, paymentUUID, paymentStatus, vendor.ID(),
+		vendor.VendorCode)
+	if errFinalize != nil {
+		return errFinalize
+	}
+
+	errCommit := tx.Commit()
+	if errCommit != nil {
+		return errCommit
+	}
+
+	hooks.run("operation.FinalizedPaymentWithVendor")
+	return nil
+}
+
+// checkCollected compares the amount a vendor collected with the gross amount of the payment
+// and its shares. Amounts are fixed when the payment is created; the rows are locked in the
+// order finalizePayment locks them again.
+func (ps *PaystoreClient) checkCollected(tx *sql.Tx, paymentUUID string, collected int64) error {
+	paymentFromDB, errFind := ps.paymentRepository.FindByUUIDForUpdate(tx, paymentUUID)
+	if errFind != nil {
+		return errFind
+	}
+	if paymentFromDB.IsShare() {
+		return payment.ShareFinalizedWithParent
+	}
+	shares, errFind := ps.paymentRepository.FindSharesForUpdate(tx, paymentUUID)
+	if errFind != nil {
+		return errFind
+	}
+	return payment.CheckPaidAmount(collected, append([]*payment.Payment{paymentFromDB}, shares...))
+}
+
+// finalizePayment locks the payment row so concurrent or repeated finalization of the
+// same payment settles the balance at most once. The shares of a split payment are locked
+// with their parent and settled with the same status.
+func (ps *PaystoreClient) finalizePayment(tx *sql.Tx, hooks *commitHooks, accountUUID string, paymentUUID string,
+	paymentStatus payment.PaymentStatus, vendorRecordID string, vendorCode string) error {
+	paymentFromDB, errFind := ps.paymentRepository.FindByUUIDForUpdate(tx, paymentUUID)
+	if errFind != nil {
+		return errFind
+	}
+	if paymentFromDB.BalanceUUID != accountUUID {
+		return payment.UnmatchBalance
+	}
+	errVendor := paymentFromDB.CheckVendor(vendorCode, defaultVendorCode(ps.paymentVendors))
+	if errVendor != nil {
+		return errVendor
+	}
+	if paymentFromDB.IsShare() {
+		return payment.ShareFinalizedWithParent
+	}
+	// An expired invoice can still be paid if the vendor accepted the payment before it was
+	// cancelled; the money has arrived, so the late payment is collected rather than rejected.
+	latePayment := paymentFromDB.Status == payment.PaymentStatusExpired && paymentStatus == payment.PaymentStatusPaid
+	if paymentFromDB.Status != payment.PaymentStatusPending && !latePayment {
+		if paymentFromDB.Status == paymentStatus {
+			return nil
+		}
+		return payment.AlreadyFinalized
+	}
+
+	shares, errFind := ps.paymentRepository.FindSharesForUpdate(tx, paymentUUID)
+	if errFind != nil {
+		return errFind
+	}
+	settling := append([]*payment.Payment{paymentFromDB}, shares...)
+
+	// Balances are locked in UUID order, so split payments sharing balances cannot deadlock.
+	balanceUUIDs := make([]string, 0, len(settling))
+	for _, settlingPayment := range settling {
+		balanceUUIDs = append(balanceUUIDs, settlingPayment.BalanceUUID)
+	}
+	sort.Strings(balanceUUIDs)
+	balances := make(map[string]*balance.Balance)
+	for _, balanceUUID := range balanceUUIDs {
+		balanceFromDB, errFind := ps.balanceRepository.FindByUUIDForUpdate(tx, balanceUUID)
+		if errFind != nil {
+			return errFind
+		}
+		balances[balanceUUID] = balanceFromDB
+	}
+
+	for _, settlingPayment := range settling {
+		errSettle := ps.settlePayment(tx, hooks, settlingPayment, balances[settlingPayment.BalanceUUID], paymentStatus,
+			vendorRecordID, vendorCode, latePayment)
+		if errSettle != nil {
+			return errSettle
+		}
+	}
+
+	return nil
+}
+
+// settlePayment moves a locked payment to paymentStatus, collecting it into its locked balance
+// when it is paid.
+func (ps *PaystoreClient) settlePayment(tx *sql.Tx, hooks *commitHooks, paymentFromDB *payment.Payment,
+	balanceFromDB *balance.Balance, paymentStatus payment.PaymentStatus, vendorRecordID string, vendorCode string,
+	latePayment bool) error {
+	updateBalance := false
+	if paymentStatus == payment.PaymentStatusFailed {
+		paymentFromDB.SetFailed()
+	} else if paymentStatus == payment.PaymentStatusExpired {
+		paymentFromDB.SetExpired()
+	} else if paymentStatus == payment.PaymentStatusPaid {
+		paymentFromDB.SetPaid()
+		paymentFromDB.SetVendorRecord(vendorCode, vendorRecordID)
+		paymentFromDB.SetBalanceSnapshot(balanceFromDB.Balance)
+		balanceFromDB.LastReceive = paymentFromDB.GetCreatedAt()
+		balanceFromDB.Collect(paymentFromDB.Amount)
+		updateBalance = true
+	} else {
+		return nil
+	}
+
+	// A payment that did not go through no longer counts against the limits. One paid after
+	// it expired counts again without being checked, since money that arrived cannot be refused.
+	if !updateBalance {
+		hooks.add(func() error {
+			ps.releaseLimits(paymentUsage(paymentFromDB))
+			return nil
+		})
+	} else if latePayment {
+		hooks.add(func() error {
+			return ps.countLimits(paymentUsage(paymentFromDB))
+		})
+	}
+
+	errUpdatePayment := ps.paymentRepository.Update(tx, paymentFromDB)
+	if errUpdatePayment != nil {
+		return errUpdatePayment
+	}
+	hooks.add(func() error {
+		return ps.paymentRepository.SetCache(paymentFromDB)
+	})
+
+	errEvent := ps.recordEvent(tx, paymentEvents[paymentStatus], outbox.AggregatePayment, paymentFromDB.GetUUID(),
+		paymentFromDB.OrganizationUUID, paymentFromDB)
+	if errEvent != nil {
+		return errEvent
+	}
+
+	if updateBalance {
+		errUpdateBalance := ps.balanceRepository.Update(tx, balanceFromDB)
+		if errUpdateBalance != nil {
+			return errUpdateBalance
+		}
+		hooks.add(func() error {
+			return ps.balanceRepository.SetCache(balanceFromDB)
+		})
+
+		errEvent = ps.recordEvent(tx, outbox.EventBalanceUpdated, outbox.AggregateBalance, balanceFromDB.GetUUID(),
+			balanceFromDB.OrganizationUUID, balanceFromDB)
+		if errEvent != nil {
+			return errEvent
+		}
+
+		errRevenue := ps.creditRevenue(tx, hooks, revenue.NewEntry(revenue.SourcePayment, paymentFromDB.GetUUID()),
+			paymentFromDB.OrganizationUUID, balanceFromDB.Currency, paymentFromDB.Fees)
+		if errRevenue != nil {
+			return errRevenue
+		}
+	}
+
+	if latePayment {
+		organizationFromDB, errFind := ps.organizationRepository.FindByUUID(paymentFromDB.OrganizationUUID)
+		if errFind != nil {
+			return errFind
+		}
+		hooks.add(func() error {
+			return ps.paymentRepository.AddToTimeline(paymentFromDB, organizationFromDB, balanceFromDB)
+		})
+	}
+
+	return nil
+}
+
+// creditRevenue credits the fees charged on a paid payment or a successful withdraw, recorded
+// as entry, to the organization's revenue balance in currency. Merchant balances are always
+// locked before revenue balances.
+func (ps *PaystoreClient) creditRevenue(tx *sql.Tx, hooks *commitHooks, entry *revenue.Entry,
+	organizationUUID string, currency string, fees int64) error {
+	if fees <= 0 {
+		return nil
+	}
+
+	revenueBalance, revenueCreated, errFind := ps.balanceRepository.FindOrCreateRevenueForUpdate(tx,
+		organizationUUID, currency)
+	if errFind != nil {
+		return errFind
+	}
+
+	entry.Credit(revenueBalance, fees)
+	revenueBalance.LastReceive = entry.GetCreatedAt()
+
+	errUpdate := ps.balanceRepository.Update(tx, revenueBalance)
+	if errUpdate != nil {
+		return errUpdate
+	}
+	hooks.add(func() error {
+		errSet := ps.balanceRepository.SetCache(revenueBalance)
+		if errSet != nil || !revenueCreated {
+			return errSet
+		}
+		return ps.balanceRepository.AddToTimeline(revenueBalance)
+	})
+
+	errCreate := ps.revenueRepository.Create(tx, entry)
+	if errCreate != nil {
+		return errCreate
+	}
+
+	revenueTransaction := transaction.NewTransaction()
+	revenueTransaction.SetType(transaction.TypeRevenue)
+	revenueTransaction.SetRecord(entry)
+	revenueTransaction.SetBalance(revenueBalance)
+	errCreate = ps.transactionRepository.Create(tx, revenueTransaction)
+	if errCreate != nil {
+		return errCreate
+	}
+
+	return ps.recordEvent(tx, outbox.EventBalanceUpdated, outbox.AggregateBalance, revenueBalance.GetUUID(),
+		revenueBalance.OrganizationUUID, revenueBalance)
+}
+
+// GetRevenueBalance returns the balance the organization's fees in currency are credited to.
+// It is withdrawn from and reported on like any other balance.
+func (ps *PaystoreClient) GetRevenueBalance(organizationUUID string, currency string) (*balance.Balance, error) {
+	return ps.balanceRepository.FindRevenue(organizationUUID, currency)
+}
+
+// ReceivePaymentVendor stores a vendor callback and finalizes the payment it refers to.
+// The vendor's ExternalID carries the paystore payment UUID given when the invoice was created.
+// A callback from another vendor than the payment's, or one paying other than its gross
+// amount, is refused with payment.UnmatchVendor or payment.UnmatchPaidAmount.
+func (ps *PaystoreClient) ReceivePaymentVendor(vendor *schema.Record) error {
+	paymentStatus, known := payment.VendorStatuses[vendor.Status()]
+	if !known {
+		return payment.UnknownVendorStatus
+	}
+
+	paymentFromDB, errFind := ps.paymentRepository.FindByUUID(vendor.Reference())
+	if errFind != nil {
+		return errFind
+	}
+
+	return ps.FinalizedPaymentWithVendor(paymentFromDB.BalanceUUID, paymentFromDB.GetUUID(), paymentStatus, vendor)
+}
+
+// CreateWithdraw opens a withdraw processed by the vendor with the given code; an empty code
+// is the default vendor.
+func (ps *PaystoreClient) CreateWithdraw(accountUUID string, amount int64,
+	destination *provider.Destination, vendorCode string) (*withdraw.Withdraw, error) {
+	vendorCode, errVendor := ps.withdrawVendorCode(vendorCode)
+	if errVendor != nil {
+		return nil, errVendor
+	}
+
+	balanceFromDB, errFind := ps.balanceRepository.FindByUUID(accountUUID)
+	if errFind != nil {
+		return nil, errFind
+	}
+
+	organizationFromDB, errFind := ps.organizationRepository.FindByUUID(balanceFromDB.OrganizationUUID)
+	if errFind != nil {
+		return nil, errFind
+	}
+	errActive := ps.checkActive(organizationFromDB)
+	if errActive != nil {
+		return nil, errActive
+	}
+
+	var channel string
+	if destination != nil {
+		channel = destination.ChannelCode
+	}
+	newWithdraw := withdraw.NewWithdraw()
+	schedule, errSchedule := ps.feeSchedule(organizationFromDB, fee.KindWithdraw, newWithdraw.GetCreatedAt())
+	if errSchedule != nil {
+		return nil, errSchedule
+	}
+	breakdown, errFee := schedule.Evaluate(amount, channel)
+	if errFee != nil {
+		return nil, errFee
+	}
+
+	newWithdraw.SetBalance(balanceFromDB)
+	newWithdraw.SetAmount(amount, balanceFromDB.Balance, breakdown)
+	newWithdraw.SetOrganization(organizationFromDB)
+	newWithdraw.VendorCode = vendorCode
+	newWithdraw.Channel = channel
+
+	if balanceFromDB.Balance < newWithdraw.Debit() {
+		return nil, balance.InsufficientFunds
+	}
+
+	usage, errLimit := ps.reserveLimits(limit.KindWithdraw, newWithdraw.GetUUID(), balanceFromDB, organizationFromDB,
+		newWithdraw.Debit(), newWithdraw.GetCreatedAt())
+	if errLimit != nil {
+		return nil, errLimit
+	}
+	created := false
+	defer func() {
+		if !created {
+			ps.releaseLimits(usage)
+		}
+	}()
+
+	newTransaction := transaction.NewTransaction()
+	newTransaction.SetType(transaction.TypeWithdraw)
+	newTransaction.SetRecord(newWithdraw)
+	newTransaction.SetBalance(balanceFromDB)
+
+	tx, errInitTx := ps.writeDB.Begin()
+	if errInitTx != nil {
+		return nil, errInitTx
+	}
+	defer tx.Rollback()
+
+	errCreate := ps.withdrawRepository.Create(tx, newWithdraw, balanceFromDB, organizationFromDB)
+	if errCreate != nil {
+		return nil, errCreate
+	}
+
+	errCreate = ps.transactionRepository.Create(tx, newTransaction)
+	if errCreate != nil {
+		return nil, errCreate
+	}
+
+	errCreate = ps.recordEvent(tx, outbox.EventWithdrawCreated, outbox.AggregateWithdraw, newWithdraw.GetUUID(),
+		newWithdraw.OrganizationUUID, newWithdraw)
+	if errCreate != nil {
+		return nil, errCreate
+	}
+
+	errCommit := tx.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+	created = true
+
+	errSet := ps.withdrawRepository.SetCache(newWithdraw)
+	if errSet == nil {
+		errSet = ps.withdrawRepository.AddToTimeline(newWithdraw, organizationFromDB, balanceFromDB)
+	}
+	if errSet != nil {
+		helper.Logger.Error("commit-hook-error", "component", "paystore", "source", "operation.CreateWithdraw",
+			"error", errSet.Error())
+	}
+
+	if disbursementProvider := ps.disbursementProvider(vendorCode); disbursementProvider != nil && destination != nil {
+		errDispatch := ps.dispatchWithdraw(disbursementProvider, newWithdraw, balanceFromDB.Currency, *destination)
+		if errDispatch != nil {
+			helper.Logger.Error("dispatch-withdraw-error", "component", "paystore", "source", "operation.CreateWithdraw",
+				"withdrawUUID", newWithdraw.GetUUID(), "error", errDispatch.Error())
+		}
+	}
+
+	return newWithdraw, nil
+}
+
+// dispatchWithdraw submits a committed withdraw to the disbursement provider. A definitive
+// rejection fails the withdraw right away; any other error leaves it pending so the
+// vendor callback or status polling can settle it.
+func (ps *PaystoreClient) dispatchWithdraw(disbursementProvider provider.DisbursementProvider,
+	newWithdraw *withdraw.Withdraw, currency string, destination provider.Destination) error {
+	disbursement, errDispatch := disbursementProvider.CreateDisbursement(provider.DisbursementRequest{
+		ReferenceID: newWithdraw.GetUUID(),
+		Amount:      newWithdraw.Amount,
+		Currency:    currency,
+		Destination: destination,
+	})
+	if errDispatch == provider.DisbursementRejected {
+		tx, errInitTx := ps.writeDB.Begin()
+		if errInitTx != nil {
+			return errInitTx
+		}
+		defer tx.Rollback()
+
+		var hooks commitHooks
+		errFinalize := ps.finalizeWithdraw(tx, &hooks, newWithdraw.BalanceUUID, newWithdraw.GetUUID(),
+			withdraw.StatusFailed, "", "", DispatchRejectedFailureCode)
+		if errFinalize != nil {
+			return errFinalize
+		}
+
+		errCommit := tx.Commit()
+		if errCommit != nil {
+			return errCommit
+		}
+		hooks.run("operation.CreateWithdraw")
+
+		newWithdraw.SetFailed()
+		newWithdraw.SetFailureCode(DispatchRejectedFailureCode)
+		return nil
+	}
+	if errDispatch != nil {
+		return errDispatch
+	}
+
+	tx, errInitTx := ps.writeDB.Begin()
+	if errInitTx != nil {
+		return errInitTx
+	}
+	defer tx.Rollback()
+
+	errUpsert := ps.withdrawRepository.UpsertVendor(tx, disbursement)
+	if errUpsert != nil {
+		return errUpsert
+	}
+	hooks := commitHooks{func() error {
+		return ps.withdrawRepository.SetVendorCache(disbursement)
+	}}
+
+	withdrawFromDB, errFind := ps.withdrawRepository.FindByUUIDForUpdate(tx, newWithdraw.GetUUID())
+	if errFind != nil {
+		return errFind
+	}
+	if withdrawFromDB.VendorRecordID == "" {
+		withdrawFromDB.SetVendorRecord(newWithdraw.VendorCode, disbursement.ID())
+		errUpdate := ps.withdrawRepository.Update(tx, withdrawFromDB)
+		if errUpdate != nil {
+			return errUpdate
+		}
+		hooks.add(func() error {
+			return ps.withdrawRepository.SetCache(withdrawFromDB)
+		})
+	}
+
+	errCommit := tx.Commit()
+	if errCommit != nil {
+		return errCommit
+	}
+	hooks.run("operation.CreateWithdraw")
+
+	newWithdraw.SetVendorRecord(newWithdraw.VendorCode, disbursement.ID())
+	newWithdraw.WithdrawVendorRandId = disbursement.GetRandId()
+	newWithdraw.WithdrawVendor = disbursement
+	return nil
+}
+
+// FinalizedWithdraw settles a withdraw by vendor record ID. When the vendor has a
+// disbursement provider the full disbursement is fetched from it and stored alongside the
+// withdraw. An empty vendor code is the vendor the withdraw was created with.
+func (ps *PaystoreClient) FinalizedWithdraw(accountUUID string, withdrawUUID string,
+	withdrawStatus withdraw.WithdrawStatus, vendorRecordID string, vendorCode string) error {
+	if vendorCode == "" && len(ps.disbursementProviders) > 0 && vendorRecordID != "" {
+		withdrawFromDB, errFind := ps.withdrawRepository.FindByUUID(withdrawUUID)
+		if errFind != nil {
+			return errFind
+		}
+		vendorCode = withdrawFromDB.VendorCode
+	} else if vendorCode != "" {
+		var errVendor error
+		vendorCode, errVendor = ps.withdrawVendorCode(vendorCode)
+		if errVendor != nil {
+			return errVendor
+		}
+	}
+
+	if disbursementProvider := ps.disbursementProvider(vendorCode); disbursementProvider != nil && vendorRecordID != "" {
+		disbursement, errDisbursement := disbursementProvider.GetDisbursement(vendorRecordID)
+		if errDisbursement != nil {
+			return errDisbursement
+		}
+		return ps.FinalizedWithdrawWithVendor(accountUUID, withdrawUUID, withdrawStatus, disbursement)
+	}
+
+	tx, errInitTx := ps.writeDB.Begin()
+	if errInitTx != nil {
+		return errInitTx
+	}
+	defer tx.Rollback()
+
+	var hooks commitHooks
+	errFinalize := ps.finalizeWithdraw(tx, &hooks, accountUUID, withdrawUUID, withdrawStatus, vendorRecordID,
+		vendorCode, "")
+	if errFinalize != nil {
+		return errFinalize
+	}
+
+	errCommit := tx.Commit()
+	if errCommit != nil {
+		return errCommit
+	}
+
+	hooks.run("operation.FinalizedWithdraw")
+	return nil
+}
+
+// FinalizedWithdrawWithVendor settles a withdraw and upserts the vendor payload in the same transaction.
+func (ps *PaystoreClient) FinalizedWithdrawWithVendor(accountUUID string,
+	withdrawUUID string, withdrawStatus withdraw.WithdrawStatus, vendor *schema.Record) error {
+	tx, errInitTx := ps.writeDB.Begin()
+	if errInitTx != nil {
+		return errInitTx
+	}
+	defer tx.Rollback()
+
+	errUpsert := ps.withdrawRepository.UpsertVendor(tx, vendor)
+	if errUpsert != nil {
+		return errUpsert
+	}
+	hooks := commitHooks{func() error {
+		return ps.withdrawRepository.SetVendorCache(vendor)
+	}}
+
+	errFinalize := ps.finalizeWithdraw(tx, &hooks, accountUUID, withdrawUUID, withdrawStatus, vendor.ID(),
+		vendor.VendorCode, vendor.FailureCode())
+	if errFinalize != nil {
+		return errFinalize
+	}
+
+	errCommit := tx.Commit()
+	if errCommit != nil {
+		return errCommit
+	}
+
+	hooks.run("operation.FinalizedWithdrawWithVendor")
+	return nil
+}
+
+// finalizeWithdraw locks the withdraw row so concurrent or repeated finalization of the
+// same withdraw debits the balance at most once.
+func (ps *PaystoreClient) finalizeWithdraw(tx *sql.Tx, hooks *commitHooks, accountUUID string, withdrawUUID string,
+	withdrawStatus withdraw.WithdrawStatus, vendorRecordID string, vendorCode string, failureCode string) error {
+	withdrawFromDB, errFind := ps.withdrawRepository.FindByUUIDForUpdate(tx, withdrawUUID)
+	if errFind != nil {
+		return errFind
+	}
+	if withdrawFromDB.BalanceUUID != accountUUID {
+		return withdraw.UnmatchBalance
+	}
+	errVendor := withdrawFromDB.CheckVendor(vendorCode, defaultVendorCode(ps.withdrawVendors))
+	if errVendor != nil {
+		return errVendor
+	}
+	// A withdraw held for review is still unsettled, so an operator can finalize it either way.
+	if withdrawFromDB.Status != withdraw.StatusPending && withdrawFromDB.Status != withdraw.StatusReview {
+		if withdrawFromDB.Status == withdrawStatus {
+			return nil
+		}
+		return withdraw.AlreadyFinalized
+	}
+
+	balanceFromDB, errFind := ps.balanceRepository.FindByUUIDForUpdate(tx, accountUUID)
+	if errFind != nil {
+		return errFind
+	}
+
+	updateBalance := false
+	if withdrawStatus == withdraw.StatusFailed {
+		withdrawFromDB.SetFailed()
+		withdrawFromDB.SetFailureCode(failureCode)
+		withdrawFromDB.SetVendorRecord(vendorCode, vendorRecordID)
+		hooks.add(func() error {
+			ps.releaseLimits(withdrawUsage(withdrawFromDB))
+			return nil
+		})
+	} else if withdrawStatus == withdraw.StatusSuccess {
+		withdrawFromDB.SetSuccess()
+		withdrawFromDB.SetVendorRecord(vendorCode, vendorRecordID)
+		withdrawFromDB.SetBalanceSnapshot(balanceFromDB.Balance)
+		balanceFromDB.LastWithdraw = withdrawFromDB.GetCreatedAt()
+		errWithdraw := balanceFromDB.Withdraw(withdrawFromDB.Debit())
+		if errWithdraw != nil {
+			return errWithdraw
+		}
+		updateBalance = true
+	} else {
+		return nil
+	}
+
+	errUpdateWithdraw := ps.withdrawRepository.Update(tx, withdrawFromDB)
+	if errUpdateWithdraw != nil {
+		return errUpdateWithdraw
+	}
+	hooks.add(func() error {
+		return ps.withdrawRepository.SetCache(withdrawFromDB)
+	})
+
+	errEvent := ps.recordEvent(tx, withdrawEvents[withdrawStatus], outbox.AggregateWithdraw, withdrawUUID,
+		withdrawFromDB.OrganizationUUID, withdrawFromDB)
+	if errEvent != nil {
+		return errEvent
+	}
+
+	if updateBalance {
+		errUpdateBalance := ps.balanceRepository.Update(tx, balanceFromDB)
+		if errUpdateBalance != nil {
+			return errUpdateBalance
+		}
+		hooks.add(func() error {
+			return ps.balanceRepository.SetCache(balanceFromDB)
+		})
+
+		errEvent = ps.recordEvent(tx, outbox.EventBalanceUpdated, outbox.AggregateBalance, accountUUID,
+			balanceFromDB.OrganizationUUID, balanceFromDB)
+		if errEvent != nil {
+			return errEvent
+		}
+
+		// The balance is written first, so a withdraw from the revenue balance itself credits
+		// its fee back onto the debited row rather than onto a stale copy.
+		errRevenue := ps.creditRevenue(tx, hooks, revenue.NewEntry(revenue.SourceWithdraw, withdrawUUID),
+			withdrawFromDB.OrganizationUUID, balanceFromDB.Currency, withdrawFromDB.Fees)
+		if errRevenue != nil {
+			return errRevenue
+		}
+	}
+
+	return nil
+}
+
+// ReceiveWithdrawVendor stores a disbursement callback and finalizes the withdraw it refers to.
+// The vendor's ReferenceID carries the paystore withdraw UUID given when the payout was submitted.
+// A callback from another vendor than the withdraw's is refused with withdraw.UnmatchVendor.
+func (ps *PaystoreClient) ReceiveWithdrawVendor(vendor *schema.Record) error {
+	withdrawStatus, known := withdraw.VendorStatuses[vendor.Status()]
+	if !known {
+		return withdraw.UnknownVendorStatus
+	}
+
+	withdrawFromDB, errFind := ps.withdrawRepository.FindByUUID(vendor.Reference())
+	if errFind != nil {
+		return errFind
+	}
+
+	return ps.FinalizedWithdrawWithVendor(withdrawFromDB.BalanceUUID, withdrawFromDB.GetUUID(), withdrawStatus, vendor)
+}
+
+func (ps *PaystoreClient) SearchPayments(filter payment.SearchFilter) ([]*payment.Payment, string, error) {
+	return ps.paymentRepository.Search(filter)
+}
+
+func (ps *PaystoreClient) SearchWithdraws(filter withdraw.SearchFilter) ([]*withdraw.Withdraw, string, error) {
+	return ps.withdrawRepository.Search(filter)
+}
+
+func (ps *PaystoreClient) GenerateStatement(balanceUUID string,
+	periodStart time.Time, periodEnd time.Time) (*statement.Statement, error) {
+	return ps.statementRepository.Generate(balanceUUID, periodStart, periodEnd)
+}
+
+type PaymentSeeder struct {
+	ps *PaystoreClient
+}
+
+func (psr *PaymentSeeder) ByBalance(subtraction int64, lastRandId string, balanceUUID string) error {
+	balanceFromDB, errFind := psr.ps.balanceRepository.FindByUUID(balanceUUID)
+	if errFind != nil {
+		return errFind
+	}
+	if balanceFromDB == nil {
 		return balance.BalanceNotFound
 	}
 
 	return psr.ps.paymentRepository.SeedPartialByBalance(subtraction, lastRandId, balanceFromDB)
 }
 
+// PollWithdraws asks the disbursement provider about withdraws pending longer than threshold,
+// in case their callback was lost. Settled ones are finalized through FinalizedWithdraw; the
+// rest are polled again after an exponential backoff and, once maxAttempts polls have not
+// settled them, held for manual review.
+func (ps *PaystoreClient) PollWithdraws(now time.Time, threshold time.Duration, limit int64,
+	backoff time.Duration, maxAttempts int64) (int, error) {
+	if len(ps.disbursementProviders) == 0 {
+		return 0, nil
+	}
+
+	stuckWithdraws, errFind := ps.withdrawRepository.FindStuck(now.Add(-threshold), now, limit)
+	if errFind != nil {
+		return 0, errFind
+	}
+
+	settled := 0
+	for _, stuckWithdraw := range stuckWithdraws {
+		withdrawStatus, errPoll := ps.pollWithdraw(stuckWithdraw)
+		if errPoll == nil && withdrawStatus != withdraw.StatusPending {
+			errPoll = ps.FinalizedWithdraw(stuckWithdraw.BalanceUUID, stuckWithdraw.GetUUID(), withdrawStatus,
+				stuckWithdraw.VendorRecordID, stuckWithdraw.VendorCode)
+			if errPoll == nil {
+				settled++
+				continue
+			}
+		}
+		if errPoll != nil {
+			helper.Logger.Error("poll-withdraw-error", "component", "paystore", "source", "operation.PollWithdraws",
+				"withdrawUUID", stuckWithdraw.GetUUID(), "error", errPoll.Error())
+		}
+
+		errReschedule := ps.reschedulePoll(stuckWithdraw.GetUUID(), now, backoff, maxAttempts)
+		if errReschedule != nil {
+			helper.Logger.Error("reschedule-poll-error", "component", "paystore", "source", "operation.PollWithdraws",
+				"withdrawUUID", stuckWithdraw.GetUUID(), "error", errReschedule.Error())
+		}
+	}
+
+	return settled, nil
+}
+
+func (ps *PaystoreClient) pollWithdraw(stuckWithdraw *withdraw.Withdraw) (withdraw.WithdrawStatus, error) {
+	disbursementProvider := ps.disbursementProvider(stuckWithdraw.VendorCode)
+	if stuckWithdraw.VendorRecordID == "" || disbursementProvider == nil {
+		return withdraw.StatusPending, nil
+	}
+
+	disbursement, errDisbursement := disbursementProvider.GetDisbursement(stuckWithdraw.VendorRecordID)
+	if errDisbursement != nil {
+		return withdraw.StatusPending, errDisbursement
+	}
+
+	withdrawStatus, known := withdraw.VendorStatuses[disbursement.Status()]
+	if !known {
+		return withdraw.StatusPending, withdraw.UnknownVendorStatus
+	}
+
+	return withdrawStatus, nil
+}
+
+// reschedulePoll re-reads the withdraw under lock so a callback that settled it meanwhile
+// is not overwritten.
+func (ps *PaystoreClient) reschedulePoll(withdrawUUID string, now time.Time, backoff time.Duration,
+	maxAttempts int64) error {
+	tx, errInitTx := ps.writeDB.Begin()
+	if errInitTx != nil {
+		return errInitTx
+	}
+	defer tx.Rollback()
+
+	withdrawFromDB, errFind := ps.withdrawRepository.FindByUUIDForUpdate(tx, withdrawUUID)
+	if errFind != nil {
+		return errFind
+	}
+	if withdrawFromDB.Status != withdraw.StatusPending {
+		return nil
+	}
+
+	withdrawFromDB.SchedulePoll(now.Add(backoff << min(withdrawFromDB.PollAttempts, maxPollBackoffShift)))
+	if withdrawFromDB.PollAttempts >= maxAttempts {
+		withdrawFromDB.SetReview()
+	}
+
+	errUpdate := ps.withdrawRepository.Update(tx, withdrawFromDB)
+	if errUpdate != nil {
+		return errUpdate
+	}
+
+	if withdrawFromDB.Status == withdraw.StatusReview {
+		errEvent := ps.recordEvent(tx, outbox.EventWithdrawReview, outbox.AggregateWithdraw, withdrawUUID,
+			withdrawFromDB.OrganizationUUID, withdrawFromDB)
+		if errEvent != nil {
+			return errEvent
+		}
+	}
+
+	errCommit := tx.Commit()
+	if errCommit != nil {
+		return errCommit
+	}
+
+	return ps.withdrawRepository.SetCache(withdrawFromDB)
+}
+
+// ExpirePayments moves pending payments past the payment TTL, or past their invoice expiry
+// date, to expired and drops them from the balance timelines. Invoices that the provider
+// refuses to cancel are left pending, since the customer may already have paid them.
+func (ps *PaystoreClient) ExpirePayments(now time.Time, ttl time.Duration, limit int64) (int, error) {
+	candidates, errFind := ps.paymentRepository.FindExpirable(now.Add(-ttl), now, limit)
+	if errFind != nil {
+		return 0, errFind
+	}
+
+	expired := 0
+	for _, candidate := range candidates {
+		errExpire := ps.expirePayment(candidate)
+		if errExpire == payment.AlreadyFinalized || errExpire == provider.InvoiceNotCancellable {
+			continue
+		}
+		if errExpire != nil {
+			helper.Logger.Error("expire-payment-error", "component", "paystore", "source", "operation.ExpirePayments",
+				"paymentUUID", candidate.GetUUID(), "error", errExpire.Error())
+			continue
+		}
+		expired++
+	}
+
+	return expired, nil
+}
+
+func (ps *PaystoreClient) expirePayment(candidate *payment.Payment) error {
+	if paymentProvider := ps.paymentProvider(candidate.VendorCode); paymentProvider != nil && candidate.VendorRecordID != "" {
+		_, errCancel := paymentProvider.CancelInvoice(candidate.VendorRecordID)
+		if errCancel != nil && errCancel != provider.InvoiceNotFound {
+			return errCancel
+		}
+	}
+
+	balanceFromDB, errFind := ps.balanceRepository.FindByUUID(candidate.BalanceUUID)
+	if errFind != nil {
+		return errFind
+	}
+	organizationFromDB, errFind := ps.organizationRepository.FindByUUID(candidate.OrganizationUUID)
+	if errFind != nil {
+		return errFind
+	}
+
+	tx, errInitTx := ps.writeDB.Begin()
+	if errInitTx != nil {
+		return errInitTx
+	}
+	defer tx.Rollback()
+
+	var hooks commitHooks
+	errFinalize := ps.finalizePayment(tx, &hooks, candidate.BalanceUUID, candidate.GetUUID(),
+		payment.PaymentStatusExpired, candidate.VendorRecordID, "")
+	if errFinalize != nil {
+		return errFinalize
+	}
+
+	errCommit := tx.Commit()
+	if errCommit != nil {
+		return errCommit
+	}
+	hooks.run("operation.ExpirePayments")
+
+	errTimeline := ps.paymentRepository.RemoveFromTimeline(candidate, organizationFromDB, balanceFromDB)
+	if errTimeline != nil {
+		return errTimeline
+	}
+
+	shares, errFind := ps.paymentRepository.FindShares(candidate.GetUUID())
+	if errFind != nil {
+		return errFind
+	}
+	for _, share := range shares {
+		shareBalance, errFind := ps.balanceRepository.FindByUUID(share.BalanceUUID)
+		if errFind != nil {
+			return errFind
+		}
+		shareOrganization, errFind := ps.organizationRepository.FindByUUID(share.OrganizationUUID)
+		if errFind != nil {
+			return errFind
+		}
+		errTimeline = ps.paymentRepository.RemoveFromTimeline(share, shareOrganization, shareBalance)
+		if errTimeline != nil {
+			return errTimeline
+		}
+	}
+	return nil
+}
+
+// Reconcile compares paystore rows created within the period against the stored vendor records
+// and persists the resulting report. With autoFinalize, rows that are still pending while the
+// vendor already reports a final status for the same amount are finalized to match the vendor.
+func (ps *PaystoreClient) Reconcile(kind reconciliation.Kind, periodStart time.Time, periodEnd time.Time,
+	autoFinalize bool) (*reconciliation.Report, error) {
+	report, errCompare := ps.reconciliationRepository.Compare(kind, periodStart, periodEnd)
+	if errCompare != nil {
+		return nil, errCompare
+	}
+
+	if autoFinalize {
+		for _, item := range report.Items {
+			errFinalize := ps.autoFinalize(kind, item)
+			if errFinalize != nil {
+				helper.Logger.Error("auto-finalize-error", "component", "paystore", "source", "operation.Reconcile",
+					"kind", string(kind), "recordUUID", item.RecordUUID, "error", errFinalize.Error())
+			}
+		}
+		report.Tally()
+	}
+
+	tx, errInitTx := ps.writeDB.Begin()
+	if errInitTx != nil {
+		return nil, errInitTx
+	}
+	defer tx.Rollback()
+
+	errCreate := ps.reconciliationRepository.Create(tx, report)
+	if errCreate != nil {
+		return nil, errCreate
+	}
+
+	errCommit := tx.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return report, nil
+}
+
+// autoFinalize finalizes a row to the status the vendor reports. The vendor's raw status is
+// mapped to a paystore status the same way vendor callbacks are; a status the vendor mapping
+// does not know is never finalized to.
+func (ps *PaystoreClient) autoFinalize(kind reconciliation.Kind, item *reconciliation.Item) error {
+	pendingStatus := string(withdraw.StatusPending)
+	if kind == reconciliation.KindPayment {
+		pendingStatus = string(payment.PaymentStatusPending)
+	}
+	if !item.IsSafeToFinalize(pendingStatus) {
+		return nil
+	}
+
+	var vendorStatus string
+	if kind == reconciliation.KindPayment {
+		paymentStatus, known := payment.VendorStatuses[item.VendorRawStatus]
+		if !known {
+			return payment.UnknownVendorStatus
+		}
+		vendorStatus = string(paymentStatus)
+	} else {
+		withdrawStatus, known := withdraw.VendorStatuses[item.VendorRawStatus]
+		if !known {
+			return withdraw.UnknownVendorStatus
+		}
+		vendorStatus = string(withdrawStatus)
+	}
+	if vendorStatus == pendingStatus {
+		return nil
+	}
+
+	tx, errInitTx := ps.writeDB.Begin()
+	if errInitTx != nil {
+		return errInitTx
+	}
+	defer tx.Rollback()
+
+	var hooks commitHooks
+	var errFinalize error
+	if kind == reconciliation.KindPayment {
+		errFinalize = ps.finalizePayment(tx, &hooks, item.BalanceUUID, item.RecordUUID,
+			payment.PaymentStatus(vendorStatus), item.VendorRecordID, "")
+	} else {
+		errFinalize = ps.finalizeWithdraw(tx, &hooks, item.BalanceUUID, item.RecordUUID,
+			withdraw.WithdrawStatus(vendorStatus), item.VendorRecordID, "", item.VendorFailureCode)
+	}
+	if errFinalize != nil {
+		return errFinalize
+	}
+
+	errCommit := tx.Commit()
+	if errCommit != nil {
+		return errCommit
+	}
+
+	hooks.run("operation.Reconcile")
+	item.SetAutoFinalized()
+	return nil
+}
+
+func (ps *PaystoreClient) GetReconciliationReport(reportUUID string,
+	result reconciliation.Result) (*reconciliation.Report, error) {
+	report, errFind := ps.reconciliationRepository.FindByUUID(reportUUID)
+	if errFind != nil {
+		return nil, errFind
+	}
+
+	items, errFind := ps.reconciliationRepository.FindItems(reportUUID, result)
+	if errFind != nil {
+		return nil, errFind
+	}
+	report.Items = items
+
+	return report, nil
+}
+
+// ComputeSettlement settles the UTC day day falls in for every organization and currency and
+// stores the reports, replacing any computed before. Only days that have ended by now are
+// settled, so a day can be recomputed any time after it ends.
+func (ps *PaystoreClient) ComputeSettlement(day time.Time, now time.Time) ([]*settlement.Report, error) {
+	dayStart := settlement.Day(day)
+	if dayStart.AddDate(0, 0, 1).After(now) {
+		return nil, settlement.DayNotEnded
+	}
+
+	tx, errInitTx := ps.writeDB.Begin()
+	if errInitTx != nil {
+		return nil, errInitTx
+	}
+	defer tx.Rollback()
+
+	reports, errCompute := ps.settlementRepository.Compute(tx, dayStart)
+	if errCompute != nil {
+		return nil, errCompute
+	}
+
+	errSave := ps.settlementRepository.Save(tx, dayStart, reports, now)
+	if errSave != nil {
+		return nil, errSave
+	}
+
+	errCommit := tx.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return reports, nil
+}
+
+func (ps *PaystoreClient) GetSettlementReports(filter settlement.Filter) ([]*settlement.Report, error) {
+	return ps.settlementRepository.Find(filter)
+}
+
+// RegisterWebhookEndpoint subscribes a callback URL to the organization's events and returns
+// the organization's signing secret, created on first registration.
+func (ps *PaystoreClient) RegisterWebhookEndpoint(organizationUUID string, endpointURL string,
+	eventTypes []string) (*webhook.Endpoint, string, error) {
+	_, errFind := ps.organizationRepository.FindByUUID(organizationUUID)
+	if errFind != nil {
+		return nil, "", errFind
+	}
+
+	endpoint := webhook.NewEndpoint(organizationUUID, eventTypes)
+	errURL := endpoint.SetURL(endpointURL)
+	if errURL != nil {
+		return nil, "", errURL
+	}
+
+	secret, errSecret := ps.webhookRepository.FindOrCreateSecret(organizationUUID)
+	if errSecret != nil {
+		return nil, "", errSecret
+	}
+
+	errCreate := ps.webhookRepository.CreateEndpoint(endpoint)
+	if errCreate != nil {
+		return nil, "", errCreate
+	}
+
+	return endpoint, secret, nil
+}
+
+func (ps *PaystoreClient) RemoveWebhookEndpoint(organizationUUID string, endpointUUID string) error {
+	return ps.webhookRepository.DeactivateEndpoint(organizationUUID, endpointUUID)
+}
+
+// RotateWebhookSecret replaces the organization's signing secret; deliveries sent from now
+// on, including retries of earlier events, are signed with the new one.
+func (ps *PaystoreClient) RotateWebhookSecret(organizationUUID string) (string, error) {
+	_, errFind := ps.organizationRepository.FindByUUID(organizationUUID)
+	if errFind != nil {
+		return "", errFind
+	}
+
+	return ps.webhookRepository.RotateSecret(organizationUUID)
+}
+
+func (ps *PaystoreClient) ReplayWebhookDelivery(organizationUUID string, deliveryUUID string) (*webhook.Delivery, error) {
+	delivery, errFind := ps.webhookRepository.FindDeliveryByUUID(deliveryUUID)
+	if errFind != nil {
+		return nil, errFind
+	}
+	if delivery.OrganizationUUID != organizationUUID {
+		return nil, webhook.DeliveryNotFound
+	}
+
+	delivery.Replay(time.Now())
+	errUpdate := ps.webhookRepository.UpdateDelivery(delivery)
+	if errUpdate != nil {
+		return nil, errUpdate
+	}
+
+	return delivery, nil
+}
+
 func (ps *PaystoreClient) SeedPayment() *PaymentSeeder {
 	return &PaymentSeeder{ps: ps}
 }
@@ -297,7 +2214,16 @@ func New(writeDB *sql.DB, readDB *sql.DB, redis redis.UniversalClient,
 	withdrawRepo := withdraw.NewRepository(readDB, redis, config)
 	organizationRepo := organization.NewRepository(writeDB, readDB, redis, config)
 
-	return Client(writeDB, balanceRepo, paymentRepo, transactionRepo, withdrawRepo, organizationRepo)
+	client := Client(writeDB, balanceRepo, paymentRepo, transactionRepo, withdrawRepo, organizationRepo)
+	client.statementRepository = statement.NewRepository(readDB)
+	client.reconciliationRepository = reconciliation.NewRepository(readDB, redis, config)
+	client.webhookRepository = webhook.NewRepository(writeDB, readDB, config)
+	client.feeRepository = fee.NewRepository(readDB)
+	client.limitRepository = limit.NewRepository(writeDB, readDB)
+	client.limitCounter = limit.NewCounter(redis)
+	client.settlementRepository = settlement.NewRepository(readDB)
+	client.SetVendors(config.PaymentVendors, config.WithdrawVendors)
+	return client
 }
 
 func Client(writeDB *sql.DB, balanceRepository balance.RepositoryClient,
@@ -310,5 +2236,7 @@ func Client(writeDB *sql.DB, balanceRepository balance.RepositoryClient,
 		transactionRepository:  transactionRepository,
 		withdrawRepository:     withdrawRepository,
 		organizationRepository: organizationRepo,
+		outboxRepository:       outbox.NewRepository(),
+		revenueRepository:      revenue.NewRepository(),
 	}
 }
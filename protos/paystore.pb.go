@@ -9,6 +9,7 @@ package protos
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -28,6 +29,8 @@ const (
 	PaymentStatus_PAYMENT_STATUS_PENDING     PaymentStatus = 1
 	PaymentStatus_PAYMENT_STATUS_PAID        PaymentStatus = 2
 	PaymentStatus_PAYMENT_STATUS_FAILED      PaymentStatus = 3
+	PaymentStatus_PAYMENT_STATUS_EXPIRED     PaymentStatus = 4
+	PaymentStatus_PAYMENT_STATUS_REVIEW      PaymentStatus = 5
 )
 
 // Enum value maps for PaymentStatus.
@@ -37,12 +40,16 @@ var (
 		1: "PAYMENT_STATUS_PENDING",
 		2: "PAYMENT_STATUS_PAID",
 		3: "PAYMENT_STATUS_FAILED",
+		4: "PAYMENT_STATUS_EXPIRED",
+		5: "PAYMENT_STATUS_REVIEW",
 	}
 	PaymentStatus_value = map[string]int32{
 		"PAYMENT_STATUS_UNSPECIFIED": 0,
 		"PAYMENT_STATUS_PENDING":     1,
 		"PAYMENT_STATUS_PAID":        2,
 		"PAYMENT_STATUS_FAILED":      3,
+		"PAYMENT_STATUS_EXPIRED":     4,
+		"PAYMENT_STATUS_REVIEW":      5,
 	}
 )
 
@@ -73,30 +80,3959 @@ func (PaymentStatus) EnumDescriptor() ([]byte, []int) {
 	return file_operation_paystore_proto_rawDescGZIP(), []int{0}
 }
 
+type StatementFormat int32
+
+const (
+	StatementFormat_STATEMENT_FORMAT_UNSPECIFIED StatementFormat = 0
+	StatementFormat_STATEMENT_FORMAT_JSON        StatementFormat = 1
+	StatementFormat_STATEMENT_FORMAT_CSV         StatementFormat = 2
+)
+
+// Enum value maps for StatementFormat.
+var (
+	StatementFormat_name = map[int32]string{
+		0: "STATEMENT_FORMAT_UNSPECIFIED",
+		1: "STATEMENT_FORMAT_JSON",
+		2: "STATEMENT_FORMAT_CSV",
+	}
+	StatementFormat_value = map[string]int32{
+		"STATEMENT_FORMAT_UNSPECIFIED": 0,
+		"STATEMENT_FORMAT_JSON":        1,
+		"STATEMENT_FORMAT_CSV":         2,
+	}
+)
+
+func (x StatementFormat) Enum() *StatementFormat {
+	p := new(StatementFormat)
+	*p = x
+	return p
+}
+
+func (x StatementFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StatementFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_operation_paystore_proto_enumTypes[1].Descriptor()
+}
+
+func (StatementFormat) Type() protoreflect.EnumType {
+	return &file_operation_paystore_proto_enumTypes[1]
+}
+
+func (x StatementFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StatementFormat.Descriptor instead.
+func (StatementFormat) EnumDescriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{1}
+}
+
+type FeeScheduleKind int32
+
+const (
+	FeeScheduleKind_FEE_SCHEDULE_KIND_UNSPECIFIED FeeScheduleKind = 0
+	FeeScheduleKind_FEE_SCHEDULE_KIND_PAYMENT     FeeScheduleKind = 1
+	FeeScheduleKind_FEE_SCHEDULE_KIND_WITHDRAW    FeeScheduleKind = 2
+)
+
+// Enum value maps for FeeScheduleKind.
+var (
+	FeeScheduleKind_name = map[int32]string{
+		0: "FEE_SCHEDULE_KIND_UNSPECIFIED",
+		1: "FEE_SCHEDULE_KIND_PAYMENT",
+		2: "FEE_SCHEDULE_KIND_WITHDRAW",
+	}
+	FeeScheduleKind_value = map[string]int32{
+		"FEE_SCHEDULE_KIND_UNSPECIFIED": 0,
+		"FEE_SCHEDULE_KIND_PAYMENT":     1,
+		"FEE_SCHEDULE_KIND_WITHDRAW":    2,
+	}
+)
+
+func (x FeeScheduleKind) Enum() *FeeScheduleKind {
+	p := new(FeeScheduleKind)
+	*p = x
+	return p
+}
+
+func (x FeeScheduleKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FeeScheduleKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_operation_paystore_proto_enumTypes[2].Descriptor()
+}
+
+func (FeeScheduleKind) Type() protoreflect.EnumType {
+	return &file_operation_paystore_proto_enumTypes[2]
+}
+
+func (x FeeScheduleKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FeeScheduleKind.Descriptor instead.
+func (FeeScheduleKind) EnumDescriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{2}
+}
+
+type FeeRounding int32
+
+const (
+	FeeRounding_FEE_ROUNDING_UNSPECIFIED FeeRounding = 0
+	FeeRounding_FEE_ROUNDING_DOWN        FeeRounding = 1
+	FeeRounding_FEE_ROUNDING_UP          FeeRounding = 2
+	FeeRounding_FEE_ROUNDING_HALF_UP     FeeRounding = 3
+	FeeRounding_FEE_ROUNDING_HALF_EVEN   FeeRounding = 4
+)
+
+// Enum value maps for FeeRounding.
+var (
+	FeeRounding_name = map[int32]string{
+		0: "FEE_ROUNDING_UNSPECIFIED",
+		1: "FEE_ROUNDING_DOWN",
+		2: "FEE_ROUNDING_UP",
+		3: "FEE_ROUNDING_HALF_UP",
+		4: "FEE_ROUNDING_HALF_EVEN",
+	}
+	FeeRounding_value = map[string]int32{
+		"FEE_ROUNDING_UNSPECIFIED": 0,
+		"FEE_ROUNDING_DOWN":        1,
+		"FEE_ROUNDING_UP":          2,
+		"FEE_ROUNDING_HALF_UP":     3,
+		"FEE_ROUNDING_HALF_EVEN":   4,
+	}
+)
+
+func (x FeeRounding) Enum() *FeeRounding {
+	p := new(FeeRounding)
+	*p = x
+	return p
+}
+
+func (x FeeRounding) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FeeRounding) Descriptor() protoreflect.EnumDescriptor {
+	return file_operation_paystore_proto_enumTypes[3].Descriptor()
+}
+
+func (FeeRounding) Type() protoreflect.EnumType {
+	return &file_operation_paystore_proto_enumTypes[3]
+}
+
+func (x FeeRounding) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FeeRounding.Descriptor instead.
+func (FeeRounding) EnumDescriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{3}
+}
+
+type LimitScope int32
+
+const (
+	LimitScope_LIMIT_SCOPE_UNSPECIFIED  LimitScope = 0
+	LimitScope_LIMIT_SCOPE_ORGANIZATION LimitScope = 1
+	LimitScope_LIMIT_SCOPE_BALANCE      LimitScope = 2
+)
+
+// Enum value maps for LimitScope.
+var (
+	LimitScope_name = map[int32]string{
+		0: "LIMIT_SCOPE_UNSPECIFIED",
+		1: "LIMIT_SCOPE_ORGANIZATION",
+		2: "LIMIT_SCOPE_BALANCE",
+	}
+	LimitScope_value = map[string]int32{
+		"LIMIT_SCOPE_UNSPECIFIED":  0,
+		"LIMIT_SCOPE_ORGANIZATION": 1,
+		"LIMIT_SCOPE_BALANCE":      2,
+	}
+)
+
+func (x LimitScope) Enum() *LimitScope {
+	p := new(LimitScope)
+	*p = x
+	return p
+}
+
+func (x LimitScope) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LimitScope) Descriptor() protoreflect.EnumDescriptor {
+	return file_operation_paystore_proto_enumTypes[4].Descriptor()
+}
+
+func (LimitScope) Type() protoreflect.EnumType {
+	return &file_operation_paystore_proto_enumTypes[4]
+}
+
+func (x LimitScope) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LimitScope.Descriptor instead.
+func (LimitScope) EnumDescriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{4}
+}
+
+type OrganizationStatus int32
+
+const (
+	OrganizationStatus_ORGANIZATION_STATUS_UNSPECIFIED OrganizationStatus = 0
+	OrganizationStatus_ORGANIZATION_STATUS_ACTIVE      OrganizationStatus = 1
+	OrganizationStatus_ORGANIZATION_STATUS_SUSPENDED   OrganizationStatus = 2
+	OrganizationStatus_ORGANIZATION_STATUS_CLOSED      OrganizationStatus = 3
+)
+
+// Enum value maps for OrganizationStatus.
+var (
+	OrganizationStatus_name = map[int32]string{
+		0: "ORGANIZATION_STATUS_UNSPECIFIED",
+		1: "ORGANIZATION_STATUS_ACTIVE",
+		2: "ORGANIZATION_STATUS_SUSPENDED",
+		3: "ORGANIZATION_STATUS_CLOSED",
+	}
+	OrganizationStatus_value = map[string]int32{
+		"ORGANIZATION_STATUS_UNSPECIFIED": 0,
+		"ORGANIZATION_STATUS_ACTIVE":      1,
+		"ORGANIZATION_STATUS_SUSPENDED":   2,
+		"ORGANIZATION_STATUS_CLOSED":      3,
+	}
+)
+
+func (x OrganizationStatus) Enum() *OrganizationStatus {
+	p := new(OrganizationStatus)
+	*p = x
+	return p
+}
+
+func (x OrganizationStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrganizationStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_operation_paystore_proto_enumTypes[5].Descriptor()
+}
+
+func (OrganizationStatus) Type() protoreflect.EnumType {
+	return &file_operation_paystore_proto_enumTypes[5]
+}
+
+func (x OrganizationStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrganizationStatus.Descriptor instead.
+func (OrganizationStatus) EnumDescriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{5}
+}
+
+type ReconciliationKind int32
+
+const (
+	ReconciliationKind_RECONCILIATION_KIND_UNSPECIFIED ReconciliationKind = 0
+	ReconciliationKind_RECONCILIATION_KIND_PAYMENT     ReconciliationKind = 1
+	ReconciliationKind_RECONCILIATION_KIND_WITHDRAW    ReconciliationKind = 2
+)
+
+// Enum value maps for ReconciliationKind.
+var (
+	ReconciliationKind_name = map[int32]string{
+		0: "RECONCILIATION_KIND_UNSPECIFIED",
+		1: "RECONCILIATION_KIND_PAYMENT",
+		2: "RECONCILIATION_KIND_WITHDRAW",
+	}
+	ReconciliationKind_value = map[string]int32{
+		"RECONCILIATION_KIND_UNSPECIFIED": 0,
+		"RECONCILIATION_KIND_PAYMENT":     1,
+		"RECONCILIATION_KIND_WITHDRAW":    2,
+	}
+)
+
+func (x ReconciliationKind) Enum() *ReconciliationKind {
+	p := new(ReconciliationKind)
+	*p = x
+	return p
+}
+
+func (x ReconciliationKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReconciliationKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_operation_paystore_proto_enumTypes[6].Descriptor()
+}
+
+func (ReconciliationKind) Type() protoreflect.EnumType {
+	return &file_operation_paystore_proto_enumTypes[6]
+}
+
+func (x ReconciliationKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReconciliationKind.Descriptor instead.
+func (ReconciliationKind) EnumDescriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{6}
+}
+
+type ReconciliationResult int32
+
+const (
+	ReconciliationResult_RECONCILIATION_RESULT_UNSPECIFIED         ReconciliationResult = 0
+	ReconciliationResult_RECONCILIATION_RESULT_MATCHED             ReconciliationResult = 1
+	ReconciliationResult_RECONCILIATION_RESULT_AMOUNT_MISMATCH     ReconciliationResult = 2
+	ReconciliationResult_RECONCILIATION_RESULT_STATUS_MISMATCH     ReconciliationResult = 3
+	ReconciliationResult_RECONCILIATION_RESULT_MISSING_IN_PAYSTORE ReconciliationResult = 4
+	ReconciliationResult_RECONCILIATION_RESULT_MISSING_AT_VENDOR   ReconciliationResult = 5
+)
+
+// Enum value maps for ReconciliationResult.
+var (
+	ReconciliationResult_name = map[int32]string{
+		0: "RECONCILIATION_RESULT_UNSPECIFIED",
+		1: "RECONCILIATION_RESULT_MATCHED",
+		2: "RECONCILIATION_RESULT_AMOUNT_MISMATCH",
+		3: "RECONCILIATION_RESULT_STATUS_MISMATCH",
+		4: "RECONCILIATION_RESULT_MISSING_IN_PAYSTORE",
+		5: "RECONCILIATION_RESULT_MISSING_AT_VENDOR",
+	}
+	ReconciliationResult_value = map[string]int32{
+		"RECONCILIATION_RESULT_UNSPECIFIED":         0,
+		"RECONCILIATION_RESULT_MATCHED":             1,
+		"RECONCILIATION_RESULT_AMOUNT_MISMATCH":     2,
+		"RECONCILIATION_RESULT_STATUS_MISMATCH":     3,
+		"RECONCILIATION_RESULT_MISSING_IN_PAYSTORE": 4,
+		"RECONCILIATION_RESULT_MISSING_AT_VENDOR":   5,
+	}
+)
+
+func (x ReconciliationResult) Enum() *ReconciliationResult {
+	p := new(ReconciliationResult)
+	*p = x
+	return p
+}
+
+func (x ReconciliationResult) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReconciliationResult) Descriptor() protoreflect.EnumDescriptor {
+	return file_operation_paystore_proto_enumTypes[7].Descriptor()
+}
+
+func (ReconciliationResult) Type() protoreflect.EnumType {
+	return &file_operation_paystore_proto_enumTypes[7]
+}
+
+func (x ReconciliationResult) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReconciliationResult.Descriptor instead.
+func (ReconciliationResult) EnumDescriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{7}
+}
+
 type CreateBalanceRequest struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
-	ExternalID       string                 `protobuf:"bytes,1,opt,name=ExternalID,proto3" json:"ExternalID,omitempty"`
-	OrganizationSlug string                 `protobuf:"bytes,2,opt,name=OrganizationSlug,proto3" json:"OrganizationSlug,omitempty"`
-	Currency         string                 `protobuf:"bytes,3,opt,name=Currency,proto3" json:"Currency,omitempty"`
+	ExternalID       string                 `protobuf:"bytes,1,opt,name=ExternalID,proto3" json:"ExternalID,omitempty"`
+	OrganizationSlug string                 `protobuf:"bytes,2,opt,name=OrganizationSlug,proto3" json:"OrganizationSlug,omitempty"`
+	Currency         string                 `protobuf:"bytes,3,opt,name=Currency,proto3" json:"Currency,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CreateBalanceRequest) Reset() {
+	*x = CreateBalanceRequest{}
+	mi := &file_operation_paystore_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBalanceRequest) ProtoMessage() {}
+
+func (x *CreateBalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_operation_paystore_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBalanceRequest.ProtoReflect.Descriptor instead.
+func (*CreateBalanceRequest) Descriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CreateBalanceRequest) GetExternalID() string {
+	if x != nil {
+		return x.ExternalID
+	}
+	return ""
+}
+
+func (x *CreateBalanceRequest) GetOrganizationSlug() string {
+	if x != nil {
+		return x.OrganizationSlug
+	}
+	return ""
+}
+
+func (x *CreateBalanceRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type CreatePaymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountUUID   string                 `protobuf:"bytes,1,opt,name=AccountUUID,proto3" json:"AccountUUID,omitempty"`
+	Amount        int64                  `protobuf:"varint,2,opt,name=Amount,proto3" json:"Amount,omitempty"`
+	VendorCode    string                 `protobuf:"bytes,3,opt,name=VendorCode,proto3" json:"VendorCode,omitempty"`
+	Channel       string                 `protobuf:"bytes,4,opt,name=Channel,proto3" json:"Channel,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePaymentRequest) Reset() {
+	*x = CreatePaymentRequest{}
+	mi := &file_operation_paystore_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePaymentRequest) ProtoMessage() {}
+
+func (x *CreatePaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_operation_paystore_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePaymentRequest.ProtoReflect.Descriptor instead.
+func (*CreatePaymentRequest) Descriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreatePaymentRequest) GetAccountUUID() string {
+	if x != nil {
+		return x.AccountUUID
+	}
+	return ""
+}
+
+func (x *CreatePaymentRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *CreatePaymentRequest) GetVendorCode() string {
+	if x != nil {
+		return x.VendorCode
+	}
+	return ""
+}
+
+func (x *CreatePaymentRequest) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+// PaymentShare is one balance's part of a split payment: a Fixed amount or PercentBps of the
+// amount. The first share is the parent payment and also receives what the others leave over.
+type PaymentShare struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BalanceUUID   string                 `protobuf:"bytes,1,opt,name=BalanceUUID,proto3" json:"BalanceUUID,omitempty"`
+	Fixed         int64                  `protobuf:"varint,2,opt,name=Fixed,proto3" json:"Fixed,omitempty"`
+	PercentBps    int64                  `protobuf:"varint,3,opt,name=PercentBps,proto3" json:"PercentBps,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PaymentShare) Reset() {
+	*x = PaymentShare{}
+	mi := &file_operation_paystore_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaymentShare) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentShare) ProtoMessage() {}
+
+func (x *PaymentShare) ProtoReflect() protoreflect.Message {
+	mi := &file_operation_paystore_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentShare.ProtoReflect.Descriptor instead.
+func (*PaymentShare) Descriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PaymentShare) GetBalanceUUID() string {
+	if x != nil {
+		return x.BalanceUUID
+	}
+	return ""
+}
+
+func (x *PaymentShare) GetFixed() int64 {
+	if x != nil {
+		return x.Fixed
+	}
+	return 0
+}
+
+func (x *PaymentShare) GetPercentBps() int64 {
+	if x != nil {
+		return x.PercentBps
+	}
+	return 0
+}
+
+type CreateSplitPaymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Amount        int64                  `protobuf:"varint,1,opt,name=Amount,proto3" json:"Amount,omitempty"`
+	Shares        []*PaymentShare        `protobuf:"bytes,2,rep,name=Shares,proto3" json:"Shares,omitempty"`
+	VendorCode    string                 `protobuf:"bytes,3,opt,name=VendorCode,proto3" json:"VendorCode,omitempty"`
+	Channel       string                 `protobuf:"bytes,4,opt,name=Channel,proto3" json:"Channel,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSplitPaymentRequest) Reset() {
+	*x = CreateSplitPaymentRequest{}
+	mi := &file_operation_paystore_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSplitPaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSplitPaymentRequest) ProtoMessage() {}
+
+func (x *CreateSplitPaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_operation_paystore_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSplitPaymentRequest.ProtoReflect.Descriptor instead.
+func (*CreateSplitPaymentRequest) Descriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateSplitPaymentRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *CreateSplitPaymentRequest) GetShares() []*PaymentShare {
+	if x != nil {
+		return x.Shares
+	}
+	return nil
+}
+
+func (x *CreateSplitPaymentRequest) GetVendorCode() string {
+	if x != nil {
+		return x.VendorCode
+	}
+	return ""
+}
+
+func (x *CreateSplitPaymentRequest) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+type FinalizedPaymentRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	AccountUUID    string                 `protobuf:"bytes,1,opt,name=AccountUUID,proto3" json:"AccountUUID,omitempty"`
+	PaymentUUID    string                 `protobuf:"bytes,2,opt,name=PaymentUUID,proto3" json:"PaymentUUID,omitempty"`
+	VendorRecordId string                 `protobuf:"bytes,3,opt,name=VendorRecordId,proto3" json:"VendorRecordId,omitempty"`
+	PaymentStatus  PaymentStatus          `protobuf:"varint,4,opt,name=PaymentStatus,proto3,enum=paystore.PaymentStatus" json:"PaymentStatus,omitempty"`
+	VendorPayload  []byte                 `protobuf:"bytes,5,opt,name=VendorPayload,proto3" json:"VendorPayload,omitempty"`
+	VendorCode     string                 `protobuf:"bytes,6,opt,name=VendorCode,proto3" json:"VendorCode,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *FinalizedPaymentRequest) Reset() {
+	*x = FinalizedPaymentRequest{}
+	mi := &file_operation_paystore_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FinalizedPaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FinalizedPaymentRequest) ProtoMessage() {}
+
+func (x *FinalizedPaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_operation_paystore_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FinalizedPaymentRequest.ProtoReflect.Descriptor instead.
+func (*FinalizedPaymentRequest) Descriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *FinalizedPaymentRequest) GetAccountUUID() string {
+	if x != nil {
+		return x.AccountUUID
+	}
+	return ""
+}
+
+func (x *FinalizedPaymentRequest) GetPaymentUUID() string {
+	if x != nil {
+		return x.PaymentUUID
+	}
+	return ""
+}
+
+func (x *FinalizedPaymentRequest) GetVendorRecordId() string {
+	if x != nil {
+		return x.VendorRecordId
+	}
+	return ""
+}
+
+func (x *FinalizedPaymentRequest) GetPaymentStatus() PaymentStatus {
+	if x != nil {
+		return x.PaymentStatus
+	}
+	return PaymentStatus_PAYMENT_STATUS_UNSPECIFIED
+}
+
+func (x *FinalizedPaymentRequest) GetVendorPayload() []byte {
+	if x != nil {
+		return x.VendorPayload
+	}
+	return nil
+}
+
+func (x *FinalizedPaymentRequest) GetVendorCode() string {
+	if x != nil {
+		return x.VendorCode
+	}
+	return ""
+}
+
+type CreateWithdrawRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	AccountUUID       string                 `protobuf:"bytes,1,opt,name=AccountUUID,proto3" json:"AccountUUID,omitempty"`
+	Amount            int64                  `protobuf:"varint,2,opt,name=Amount,proto3" json:"Amount,omitempty"`
+	ChannelCode       string                 `protobuf:"bytes,3,opt,name=ChannelCode,proto3" json:"ChannelCode,omitempty"`
+	AccountNumber     string                 `protobuf:"bytes,4,opt,name=AccountNumber,proto3" json:"AccountNumber,omitempty"`
+	AccountHolderName string                 `protobuf:"bytes,5,opt,name=AccountHolderName,proto3" json:"AccountHolderName,omitempty"`
+	VendorCode        string                 `protobuf:"bytes,6,opt,name=VendorCode,proto3" json:"VendorCode,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CreateWithdrawRequest) Reset() {
+	*x = CreateWithdrawRequest{}
+	mi := &file_operation_paystore_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWithdrawRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWithdrawRequest) ProtoMessage() {}
+
+func (x *CreateWithdrawRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_operation_paystore_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWithdrawRequest.ProtoReflect.Descriptor instead.
+func (*CreateWithdrawRequest) Descriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateWithdrawRequest) GetAccountUUID() string {
+	if x != nil {
+		return x.AccountUUID
+	}
+	return ""
+}
+
+func (x *CreateWithdrawRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *CreateWithdrawRequest) GetChannelCode() string {
+	if x != nil {
+		return x.ChannelCode
+	}
+	return ""
+}
+
+func (x *CreateWithdrawRequest) GetAccountNumber() string {
+	if x != nil {
+		return x.AccountNumber
+	}
+	return ""
+}
+
+func (x *CreateWithdrawRequest) GetAccountHolderName() string {
+	if x != nil {
+		return x.AccountHolderName
+	}
+	return ""
+}
+
+func (x *CreateWithdrawRequest) GetVendorCode() string {
+	if x != nil {
+		return x.VendorCode
+	}
+	return ""
+}
+
+type FinalizedWithdrawRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	AccountUUId    string                 `protobuf:"bytes,1,opt,name=AccountUUId,proto3" json:"AccountUUId,omitempty"`
+	WithdrawUUID   string                 `protobuf:"bytes,2,opt,name=WithdrawUUID,proto3" json:"WithdrawUUID,omitempty"`
+	VendorRecordId string                 `protobuf:"bytes,3,opt,name=VendorRecordId,proto3" json:"VendorRecordId,omitempty"`
+	WithdrawStatus PaymentStatus          `protobuf:"varint,4,opt,name=WithdrawStatus,proto3,enum=paystore.PaymentStatus" json:"WithdrawStatus,omitempty"`
+	VendorPayload  []byte                 `protobuf:"bytes,5,opt,name=VendorPayload,proto3" json:"VendorPayload,omitempty"`
+	VendorCode     string                 `protobuf:"bytes,6,opt,name=VendorCode,proto3" json:"VendorCode,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *FinalizedWithdrawRequest) Reset() {
+	*x = FinalizedWithdrawRequest{}
+	mi := &file_operation_paystore_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FinalizedWithdrawRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FinalizedWithdrawRequest) ProtoMessage() {}
+
+func (x *FinalizedWithdrawRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_operation_paystore_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FinalizedWithdrawRequest.ProtoReflect.Descriptor instead.
+func (*FinalizedWithdrawRequest) Descriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *FinalizedWithdrawRequest) GetAccountUUId() string {
+	if x != nil {
+		return x.AccountUUId
+	}
+	return ""
+}
+
+func (x *FinalizedWithdrawRequest) GetWithdrawUUID() string {
+	if x != nil {
+		return x.WithdrawUUID
+	}
+	return ""
+}
+
+func (x *FinalizedWithdrawRequest) GetVendorRecordId() string {
+	if x != nil {
+		return x.VendorRecordId
+	}
+	return ""
+}
+
+func (x *FinalizedWithdrawRequest) GetWithdrawStatus() PaymentStatus {
+	if x != nil {
+		return x.WithdrawStatus
+	}
+	return PaymentStatus_PAYMENT_STATUS_UNSPECIFIED
+}
+
+func (x *FinalizedWithdrawRequest) GetVendorPayload() []byte {
+	if x != nil {
+		return x.VendorPayload
+	}
+	return nil
+}
+
+func (x *FinalizedWithdrawRequest) GetVendorCode() string {
+	if x != nil {
+		return x.VendorCode
+	}
+	return ""
+}
+
+type SearchPaymentsRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationUUID string                 `protobuf:"bytes,1,opt,name=OrganizationUUID,proto3" json:"OrganizationUUID,omitempty"`
+	BalanceUUID      string                 `protobuf:"bytes,2,opt,name=BalanceUUID,proto3" json:"BalanceUUID,omitempty"`
+	Statuses         []PaymentStatus        `protobuf:"varint,3,rep,packed,name=Statuses,proto3,enum=paystore.PaymentStatus" json:"Statuses,omitempty"`
+	MinAmount        int64                  `protobuf:"varint,4,opt,name=MinAmount,proto3" json:"MinAmount,omitempty"`
+	MaxAmount        int64                  `protobuf:"varint,5,opt,name=MaxAmount,proto3" json:"MaxAmount,omitempty"`
+	CreatedFrom      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=CreatedFrom,proto3" json:"CreatedFrom,omitempty"`
+	CreatedTo        *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=CreatedTo,proto3" json:"CreatedTo,omitempty"`
+	VendorRecordID   string                 `protobuf:"bytes,8,opt,name=VendorRecordID,proto3" json:"VendorRecordID,omitempty"`
+	Cursor           string                 `protobuf:"bytes,9,opt,name=Cursor,proto3" json:"Cursor,omitempty"`
+	Limit            int64                  `protobuf:"varint,10,opt,name=Limit,proto3" json:"Limit,omitempty"`
+	VendorCode       string                 `protobuf:"bytes,11,opt,name=VendorCode,proto3" json:"VendorCode,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *SearchPaymentsRequest) Reset() {
+	*x = SearchPaymentsRequest{}
+	mi := &file_operation_paystore_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchPaymentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchPaymentsRequest) ProtoMessage() {}
+
+func (x *SearchPaymentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_operation_paystore_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchPaymentsRequest.ProtoReflect.Descriptor instead.
+func (*SearchPaymentsRequest) Descriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SearchPaymentsRequest) GetOrganizationUUID() string {
+	if x != nil {
+		return x.OrganizationUUID
+	}
+	return ""
+}
+
+func (x *SearchPaymentsRequest) GetBalanceUUID() string {
+	if x != nil {
+		return x.BalanceUUID
+	}
+	return ""
+}
+
+func (x *SearchPaymentsRequest) GetStatuses() []PaymentStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *SearchPaymentsRequest) GetMinAmount() int64 {
+	if x != nil {
+		return x.MinAmount
+	}
+	return 0
+}
+
+func (x *SearchPaymentsRequest) GetMaxAmount() int64 {
+	if x != nil {
+		return x.MaxAmount
+	}
+	return 0
+}
+
+func (x *SearchPaymentsRequest) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *SearchPaymentsRequest) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
+
+func (x *SearchPaymentsRequest) GetVendorRecordID() string {
+	if x != nil {
+		return x.VendorRecordID
+	}
+	return ""
+}
+
+func (x *SearchPaymentsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *SearchPaymentsRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SearchPaymentsRequest) GetVendorCode() string {
+	if x != nil {
+		return x.VendorCode
+	}
+	return ""
+}
+
+type SearchPaymentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Payments      []*Payment             `protobuf:"bytes,1,rep,name=Payments,proto3" json:"Payments,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=NextCursor,proto3" json:"NextCursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchPaymentsResponse) Reset() {
+	*x = SearchPaymentsResponse{}
+	mi := &file_operation_paystore_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchPaymentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchPaymentsResponse) ProtoMessage() {}
+
+func (x *SearchPaymentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_operation_paystore_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchPaymentsResponse.ProtoReflect.Descriptor instead.
+func (*SearchPaymentsResponse) Descriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SearchPaymentsResponse) GetPayments() []*Payment {
+	if x != nil {
+		return x.Payments
+	}
+	return nil
+}
+
+func (x *SearchPaymentsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type SearchWithdrawsRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationUUID string                 `protobuf:"bytes,1,opt,name=OrganizationUUID,proto3" json:"OrganizationUUID,omitempty"`
+	BalanceUUID      string                 `protobuf:"bytes,2,opt,name=BalanceUUID,proto3" json:"BalanceUUID,omitempty"`
+	Statuses         []PaymentStatus        `protobuf:"varint,3,rep,packed,name=Statuses,proto3,enum=paystore.PaymentStatus" json:"Statuses,omitempty"`
+	MinAmount        int64                  `protobuf:"varint,4,opt,name=MinAmount,proto3" json:"MinAmount,omitempty"`
+	MaxAmount        int64                  `protobuf:"varint,5,opt,name=MaxAmount,proto3" json:"MaxAmount,omitempty"`
+	CreatedFrom      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=CreatedFrom,proto3" json:"CreatedFrom,omitempty"`
+	CreatedTo        *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=CreatedTo,proto3" json:"CreatedTo,omitempty"`
+	VendorRecordID   string                 `protobuf:"bytes,8,opt,name=VendorRecordID,proto3" json:"VendorRecordID,omitempty"`
+	Cursor           string                 `protobuf:"bytes,9,opt,name=Cursor,proto3" json:"Cursor,omitempty"`
+	Limit            int64                  `protobuf:"varint,10,opt,name=Limit,proto3" json:"Limit,omitempty"`
+	VendorCode       string                 `protobuf:"bytes,11,opt,name=VendorCode,proto3" json:"VendorCode,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *SearchWithdrawsRequest) Reset() {
+	*x = SearchWithdrawsRequest{}
+	mi := &file_operation_paystore_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchWithdrawsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchWithdrawsRequest) ProtoMessage() {}
+
+func (x *SearchWithdrawsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_operation_paystore_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchWithdrawsRequest.ProtoReflect.Descriptor instead.
+func (*SearchWithdrawsRequest) Descriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SearchWithdrawsRequest) GetOrganizationUUID() string {
+	if x != nil {
+		return x.OrganizationUUID
+	}
+	return ""
+}
+
+func (x *SearchWithdrawsRequest) GetBalanceUUID() string {
+	if x != nil {
+		return x.BalanceUUID
+	}
+	return ""
+}
+
+func (x *SearchWithdrawsRequest) GetStatuses() []PaymentStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *SearchWithdrawsRequest) GetMinAmount() int64 {
+	if x != nil {
+		return x.MinAmount
+	}
+	return 0
+}
+
+func (x *SearchWithdrawsRequest) GetMaxAmount() int64 {
+	if x != nil {
+		return x.MaxAmount
+	}
+	return 0
+}
+
+func (x *SearchWithdrawsRequest) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *SearchWithdrawsRequest) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
+
+func (x *SearchWithdrawsRequest) GetVendorRecordID() string {
+	if x != nil {
+		return x.VendorRecordID
+	}
+	return ""
+}
+
+func (x *SearchWithdrawsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *SearchWithdrawsRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SearchWithdrawsRequest) GetVendorCode() string {
+	if x != nil {
+		return x.VendorCode
+	}
+	return ""
+}
+
+type SearchWithdrawsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Withdraws     []*Withdraw            `protobuf:"bytes,1,rep,name=Withdraws,proto3" json:"Withdraws,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=NextCursor,proto3" json:"NextCursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchWithdrawsResponse) Reset() {
+	*x = SearchWithdrawsResponse{}
+	mi := &file_operation_paystore_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchWithdrawsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchWithdrawsResponse) ProtoMessage() {}
+
+func (x *SearchWithdrawsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_operation_paystore_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchWithdrawsResponse.ProtoReflect.Descriptor instead.
+func (*SearchWithdrawsResponse) Descriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SearchWithdrawsResponse) GetWithdraws() []*Withdraw {
+	if x != nil {
+		return x.Withdraws
+	}
+	return nil
+}
+
+func (x *SearchWithdrawsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type Payment struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	UUID                 string                 `protobuf:"bytes,1,opt,name=UUID,proto3" json:"UUID,omitempty"`
+	RandId               string                 `protobuf:"bytes,2,opt,name=RandId,proto3" json:"RandId,omitempty"`
+	CreatedAt            *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
+	UpdatedAt            *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=UpdatedAt,proto3" json:"UpdatedAt,omitempty"`
+	Amount               int64                  `protobuf:"varint,5,opt,name=Amount,proto3" json:"Amount,omitempty"`
+	Fees                 int64                  `protobuf:"varint,6,opt,name=Fees,proto3" json:"Fees,omitempty"`
+	BalanceBeforePayment int64                  `protobuf:"varint,7,opt,name=BalanceBeforePayment,proto3" json:"BalanceBeforePayment,omitempty"`
+	BalanceAfterPayment  int64                  `protobuf:"varint,8,opt,name=BalanceAfterPayment,proto3" json:"BalanceAfterPayment,omitempty"`
+	BalanceUUID          string                 `protobuf:"bytes,9,opt,name=BalanceUUID,proto3" json:"BalanceUUID,omitempty"`
+	OrganizationUUID     string                 `protobuf:"bytes,10,opt,name=OrganizationUUID,proto3" json:"OrganizationUUID,omitempty"`
+	VendorRecordID       string                 `protobuf:"bytes,11,opt,name=VendorRecordID,proto3" json:"VendorRecordID,omitempty"`
+	Status               PaymentStatus          `protobuf:"varint,12,opt,name=Status,proto3,enum=paystore.PaymentStatus" json:"Status,omitempty"`
+	Hash                 string                 `protobuf:"bytes,13,opt,name=Hash,proto3" json:"Hash,omitempty"`
+	VendorCode           string                 `protobuf:"bytes,14,opt,name=VendorCode,proto3" json:"VendorCode,omitempty"`
+	Channel              string                 `protobuf:"bytes,15,opt,name=Channel,proto3" json:"Channel,omitempty"`
+	FeeBreakdown         *FeeBreakdown          `protobuf:"bytes,16,opt,name=FeeBreakdown,proto3" json:"FeeBreakdown,omitempty"`
+	FeeScheduleUUID      string                 `protobuf:"bytes,17,opt,name=FeeScheduleUUID,proto3" json:"FeeScheduleUUID,omitempty"`
+	ParentPaymentUUID    string                 `protobuf:"bytes,18,opt,name=ParentPaymentUUID,proto3" json:"ParentPaymentUUID,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *Payment) Reset() {
+	*x = Payment{}
+	mi := &file_operation_paystore_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Payment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Payment) ProtoMessage() {}
+
+func (x *Payment) ProtoReflect() protoreflect.Message {
+	mi := &file_operation_paystore_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Payment.ProtoReflect.Descriptor instead.
+func (*Payment) Descriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Payment) GetUUID() string {
+	if x != nil {
+		return x.UUID
+	}
+	return ""
+}
+
+func (x *Payment) GetRandId() string {
+	if x != nil {
+		return x.RandId
+	}
+	return ""
+}
+
+func (x *Payment) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Payment) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Payment) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Payment) GetFees() int64 {
+	if x != nil {
+		return x.Fees
+	}
+	return 0
+}
+
+func (x *Payment) GetBalanceBeforePayment() int64 {
+	if x != nil {
+		return x.BalanceBeforePayment
+	}
+	return 0
+}
+
+func (x *Payment) GetBalanceAfterPayment() int64 {
+	if x != nil {
+		return x.BalanceAfterPayment
+	}
+	return 0
+}
+
+func (x *Payment) GetBalanceUUID() string {
+	if x != nil {
+		return x.BalanceUUID
+	}
+	return ""
+}
+
+func (x *Payment) GetOrganizationUUID() string {
+	if x != nil {
+		return x.OrganizationUUID
+	}
+	return ""
+}
+
+func (x *Payment) GetVendorRecordID() string {
+	if x != nil {
+		return x.VendorRecordID
+	}
+	return ""
+}
+
+func (x *Payment) GetStatus() PaymentStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PaymentStatus_PAYMENT_STATUS_UNSPECIFIED
+}
+
+func (x *Payment) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *Payment) GetVendorCode() string {
+	if x != nil {
+		return x.VendorCode
+	}
+	return ""
+}
+
+func (x *Payment) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *Payment) GetFeeBreakdown() *FeeBreakdown {
+	if x != nil {
+		return x.FeeBreakdown
+	}
+	return nil
+}
+
+func (x *Payment) GetFeeScheduleUUID() string {
+	if x != nil {
+		return x.FeeScheduleUUID
+	}
+	return ""
+}
+
+func (x *Payment) GetParentPaymentUUID() string {
+	if x != nil {
+		return x.ParentPaymentUUID
+	}
+	return ""
+}
+
+type FeeBreakdown struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ScheduleUUID    string                 `protobuf:"bytes,1,opt,name=ScheduleUUID,proto3" json:"ScheduleUUID,omitempty"`
+	Channel         string                 `protobuf:"bytes,2,opt,name=Channel,proto3" json:"Channel,omitempty"`
+	PercentBps      int64                  `protobuf:"varint,3,opt,name=PercentBps,proto3" json:"PercentBps,omitempty"`
+	PercentFee      int64                  `protobuf:"varint,4,opt,name=PercentFee,proto3" json:"PercentFee,omitempty"`
+	FixedFee        int64                  `protobuf:"varint,5,opt,name=FixedFee,proto3" json:"FixedFee,omitempty"`
+	Adjustment      int64                  `protobuf:"varint,6,opt,name=Adjustment,proto3" json:"Adjustment,omitempty"`
+	Total           int64                  `protobuf:"varint,7,opt,name=Total,proto3" json:"Total,omitempty"`
+	RateNumerator   int64                  `protobuf:"varint,8,opt,name=RateNumerator,proto3" json:"RateNumerator,omitempty"`
+	RateDenominator int64                  `protobuf:"varint,9,opt,name=RateDenominator,proto3" json:"RateDenominator,omitempty"`
+	Rounding        FeeRounding            `protobuf:"varint,10,opt,name=Rounding,proto3,enum=paystore.FeeRounding" json:"Rounding,omitempty"`
+	Residue         int64                  `protobuf:"varint,11,opt,name=Residue,proto3" json:"Residue,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *FeeBreakdown) Reset() {
+	*x = FeeBreakdown{}
+	mi := &file_operation_paystore_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeeBreakdown) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeeBreakdown) ProtoMessage() {}
+
+func (x *FeeBreakdown) ProtoReflect() protoreflect.Message {
+	mi := &file_operation_paystore_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeeBreakdown.ProtoReflect.Descriptor instead.
+func (*FeeBreakdown) Descriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *FeeBreakdown) GetScheduleUUID() string {
+	if x != nil {
+		return x.ScheduleUUID
+	}
+	return ""
+}
+
+func (x *FeeBreakdown) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *FeeBreakdown) GetPercentBps() int64 {
+	if x != nil {
+		return x.PercentBps
+	}
+	return 0
+}
+
+func (x *FeeBreakdown) GetPercentFee() int64 {
+	if x != nil {
+		return x.PercentFee
+	}
+	return 0
+}
+
+func (x *FeeBreakdown) GetFixedFee() int64 {
+	if x != nil {
+		return x.FixedFee
+	}
+	return 0
+}
+
+func (x *FeeBreakdown) GetAdjustment() int64 {
+	if x != nil {
+		return x.Adjustment
+	}
+	return 0
+}
+
+func (x *FeeBreakdown) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *FeeBreakdown) GetRateNumerator() int64 {
+	if x != nil {
+		return x.RateNumerator
+	}
+	return 0
+}
+
+func (x *FeeBreakdown) GetRateDenominator() int64 {
+	if x != nil {
+		return x.RateDenominator
+	}
+	return 0
+}
+
+func (x *FeeBreakdown) GetRounding() FeeRounding {
+	if x != nil {
+		return x.Rounding
+	}
+	return FeeRounding_FEE_ROUNDING_UNSPECIFIED
+}
+
+func (x *FeeBreakdown) GetResidue() int64 {
+	if x != nil {
+		return x.Residue
+	}
+	return 0
+}
+
+type Withdraw struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	UUID                  string                 `protobuf:"bytes,1,opt,name=UUID,proto3" json:"UUID,omitempty"`
+	RandId                string                 `protobuf:"bytes,2,opt,name=RandId,proto3" json:"RandId,omitempty"`
+	CreatedAt             *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
+	UpdatedAt             *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=UpdatedAt,proto3" json:"UpdatedAt,omitempty"`
+	Amount                int64                  `protobuf:"varint,5,opt,name=Amount,proto3" json:"Amount,omitempty"`
+	BalanceBeforeWithdraw int64                  `protobuf:"varint,6,opt,name=BalanceBeforeWithdraw,proto3" json:"BalanceBeforeWithdraw,omitempty"`
+	BalanceAfterWithdraw  int64                  `protobuf:"varint,7,opt,name=BalanceAfterWithdraw,proto3" json:"BalanceAfterWithdraw,omitempty"`
+	BalanceUUID           string                 `protobuf:"bytes,8,opt,name=BalanceUUID,proto3" json:"BalanceUUID,omitempty"`
+	OrganizationUUID      string                 `protobuf:"bytes,9,opt,name=OrganizationUUID,proto3" json:"OrganizationUUID,omitempty"`
+	VendorRecordID        string                 `protobuf:"bytes,10,opt,name=VendorRecordID,proto3" json:"VendorRecordID,omitempty"`
+	Status                PaymentStatus          `protobuf:"varint,11,opt,name=Status,proto3,enum=paystore.PaymentStatus" json:"Status,omitempty"`
+	Hash                  string                 `protobuf:"bytes,12,opt,name=Hash,proto3" json:"Hash,omitempty"`
+	VendorCode            string                 `protobuf:"bytes,13,opt,name=VendorCode,proto3" json:"VendorCode,omitempty"`
+	Fees                  int64                  `protobuf:"varint,14,opt,name=Fees,proto3" json:"Fees,omitempty"`
+	Channel               string                 `protobuf:"bytes,15,opt,name=Channel,proto3" json:"Channel,omitempty"`
+	FeeBreakdown          *FeeBreakdown          `protobuf:"bytes,16,opt,name=FeeBreakdown,proto3" json:"FeeBreakdown,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *Withdraw) Reset() {
+	*x = Withdraw{}
+	mi := &file_operation_paystore_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Withdraw) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Withdraw) ProtoMessage() {}
+
+func (x *Withdraw) ProtoReflect() protoreflect.Message {
+	mi := &file_operation_paystore_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Withdraw.ProtoReflect.Descriptor instead.
+func (*Withdraw) Descriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Withdraw) GetUUID() string {
+	if x != nil {
+		return x.UUID
+	}
+	return ""
+}
+
+func (x *Withdraw) GetRandId() string {
+	if x != nil {
+		return x.RandId
+	}
+	return ""
+}
+
+func (x *Withdraw) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Withdraw) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Withdraw) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Withdraw) GetBalanceBeforeWithdraw() int64 {
+	if x != nil {
+		return x.BalanceBeforeWithdraw
+	}
+	return 0
+}
+
+func (x *Withdraw) GetBalanceAfterWithdraw() int64 {
+	if x != nil {
+		return x.BalanceAfterWithdraw
+	}
+	return 0
+}
+
+func (x *Withdraw) GetBalanceUUID() string {
+	if x != nil {
+		return x.BalanceUUID
+	}
+	return ""
+}
+
+func (x *Withdraw) GetOrganizationUUID() string {
+	if x != nil {
+		return x.OrganizationUUID
+	}
+	return ""
+}
+
+func (x *Withdraw) GetVendorRecordID() string {
+	if x != nil {
+		return x.VendorRecordID
+	}
+	return ""
+}
+
+func (x *Withdraw) GetStatus() PaymentStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PaymentStatus_PAYMENT_STATUS_UNSPECIFIED
+}
+
+func (x *Withdraw) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *Withdraw) GetVendorCode() string {
+	if x != nil {
+		return x.VendorCode
+	}
+	return ""
+}
+
+func (x *Withdraw) GetFees() int64 {
+	if x != nil {
+		return x.Fees
+	}
+	return 0
+}
+
+func (x *Withdraw) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *Withdraw) GetFeeBreakdown() *FeeBreakdown {
+	if x != nil {
+		return x.FeeBreakdown
+	}
+	return nil
+}
+
+type GenerateStatementRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BalanceUUID   string                 `protobuf:"bytes,1,opt,name=BalanceUUID,proto3" json:"BalanceUUID,omitempty"`
+	PeriodStart   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=PeriodStart,proto3" json:"PeriodStart,omitempty"`
+	PeriodEnd     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=PeriodEnd,proto3" json:"PeriodEnd,omitempty"`
+	Format        StatementFormat        `protobuf:"varint,4,opt,name=Format,proto3,enum=paystore.StatementFormat" json:"Format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateStatementRequest) Reset() {
+	*x = GenerateStatementRequest{}
+	mi := &file_operation_paystore_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateStatementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateStatementRequest) ProtoMessage() {}
+
+func (x *GenerateStatementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_operation_paystore_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateStatementRequest.ProtoReflect.Descriptor instead.
+func (*GenerateStatementRequest) Descriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GenerateStatementRequest) GetBalanceUUID() string {
+	if x != nil {
+		return x.BalanceUUID
+	}
+	return ""
+}
+
+func (x *GenerateStatementRequest) GetPeriodStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PeriodStart
+	}
+	return nil
+}
+
+func (x *GenerateStatementRequest) GetPeriodEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PeriodEnd
+	}
+	return nil
+}
+
+func (x *GenerateStatementRequest) GetFormat() StatementFormat {
+	if x != nil {
+		return x.Format
+	}
+	return StatementFormat_STATEMENT_FORMAT_UNSPECIFIED
+}
+
+type StatementResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ContentType   string                 `protobuf:"bytes,1,opt,name=ContentType,proto3" json:"ContentType,omitempty"`
+	Content       []byte                 `protobuf:"bytes,2,opt,name=Content,proto3" json:"Content,omitempty"`
+	Consistent    bool                   `protobuf:"varint,3,opt,name=Consistent,proto3" json:"Consistent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatementResponse) Reset() {
+	*x = StatementResponse{}
+	mi := &file_operation_paystore_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatementResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatementResponse) ProtoMessage() {}
+
+func (x *StatementResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_operation_paystore_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatementResponse.ProtoReflect.Descriptor instead.
+func (*StatementResponse) Descriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *StatementResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *StatementResponse) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *StatementResponse) GetConsistent() bool {
+	if x != nil {
+		return x.Consistent
+	}
+	return false
+}
+
+type ReconcileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          ReconciliationKind     `protobuf:"varint,1,opt,name=Kind,proto3,enum=paystore.ReconciliationKind" json:"Kind,omitempty"`
+	PeriodStart   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=PeriodStart,proto3" json:"PeriodStart,omitempty"`
+	PeriodEnd     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=PeriodEnd,proto3" json:"PeriodEnd,omitempty"`
+	AutoFinalize  bool                   `protobuf:"varint,4,opt,name=AutoFinalize,proto3" json:"AutoFinalize,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReconcileRequest) Reset() {
+	*x = ReconcileRequest{}
+	mi := &file_operation_paystore_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconcileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcileRequest) ProtoMessage() {}
+
+func (x *ReconcileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_operation_paystore_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconcileRequest.ProtoReflect.Descriptor instead.
+func (*ReconcileRequest) Descriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ReconcileRequest) GetKind() ReconciliationKind {
+	if x != nil {
+		return x.Kind
+	}
+	return ReconciliationKind_RECONCILIATION_KIND_UNSPECIFIED
+}
+
+func (x *ReconcileRequest) GetPeriodStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PeriodStart
+	}
+	return nil
+}
+
+func (x *ReconcileRequest) GetPeriodEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PeriodEnd
+	}
+	return nil
+}
+
+func (x *ReconcileRequest) GetAutoFinalize() bool {
+	if x != nil {
+		return x.AutoFinalize
+	}
+	return false
+}
+
+type GetReconciliationReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReportUUID    string                 `protobuf:"bytes,1,opt,name=ReportUUID,proto3" json:"ReportUUID,omitempty"`
+	Result        ReconciliationResult   `protobuf:"varint,2,opt,name=Result,proto3,enum=paystore.ReconciliationResult" json:"Result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReconciliationReportRequest) Reset() {
+	*x = GetReconciliationReportRequest{}
+	mi := &file_operation_paystore_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReconciliationReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReconciliationReportRequest) ProtoMessage() {}
+
+func (x *GetReconciliationReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_operation_paystore_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReconciliationReportRequest.ProtoReflect.Descriptor instead.
+func (*GetReconciliationReportRequest) Descriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetReconciliationReportRequest) GetReportUUID() string {
+	if x != nil {
+		return x.ReportUUID
+	}
+	return ""
+}
+
+func (x *GetReconciliationReportRequest) GetResult() ReconciliationResult {
+	if x != nil {
+		return x.Result
+	}
+	return ReconciliationResult_RECONCILIATION_RESULT_UNSPECIFIED
+}
+
+type ReconciliationReport struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	UUID                   string                 `protobuf:"bytes,1,opt,name=UUID,proto3" json:"UUID,omitempty"`
+	Kind                   ReconciliationKind     `protobuf:"varint,2,opt,name=Kind,proto3,enum=paystore.ReconciliationKind" json:"Kind,omitempty"`
+	PeriodStart            *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=PeriodStart,proto3" json:"PeriodStart,omitempty"`
+	PeriodEnd              *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=PeriodEnd,proto3" json:"PeriodEnd,omitempty"`
+	MatchedCount           int64                  `protobuf:"varint,5,opt,name=MatchedCount,proto3" json:"MatchedCount,omitempty"`
+	AmountMismatchCount    int64                  `protobuf:"varint,6,opt,name=AmountMismatchCount,proto3" json:"AmountMismatchCount,omitempty"`
+	StatusMismatchCount    int64                  `protobuf:"varint,7,opt,name=StatusMismatchCount,proto3" json:"StatusMismatchCount,omitempty"`
+	MissingInPaystoreCount int64                  `protobuf:"varint,8,opt,name=MissingInPaystoreCount,proto3" json:"MissingInPaystoreCount,omitempty"`
+	MissingAtVendorCount   int64                  `protobuf:"varint,9,opt,name=MissingAtVendorCount,proto3" json:"MissingAtVendorCount,omitempty"`
+	AutoFinalizedCount     int64                  `protobuf:"varint,10,opt,name=AutoFinalizedCount,proto3" json:"AutoFinalizedCount,omitempty"`
+	Items                  []*ReconciliationItem  `protobuf:"bytes,11,rep,name=Items,proto3" json:"Items,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ReconciliationReport) Reset() {
+	*x = ReconciliationReport{}
+	mi := &file_operation_paystore_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconciliationReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconciliationReport) ProtoMessage() {}
+
+func (x *ReconciliationReport) ProtoReflect() protoreflect.Message {
+	mi := &file_operation_paystore_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconciliationReport.ProtoReflect.Descriptor instead.
+func (*ReconciliationReport) Descriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ReconciliationReport) GetUUID() string {
+	if x != nil {
+		return x.UUID
+	}
+	return ""
+}
+
+func (x *ReconciliationReport) GetKind() ReconciliationKind {
+	if x != nil {
+		return x.Kind
+	}
+	return ReconciliationKind_RECONCILIATION_KIND_UNSPECIFIED
+}
+
+func (x *ReconciliationReport) GetPeriodStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PeriodStart
+	}
+	return nil
+}
+
+func (x *ReconciliationReport) GetPeriodEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PeriodEnd
+	}
+	return nil
+}
+
+func (x *ReconciliationReport) GetMatchedCount() int64 {
+	if x != nil {
+		return x.MatchedCount
+	}
+	return 0
+}
+
+func (x *ReconciliationReport) GetAmountMismatchCount() int64 {
+	if x != nil {
+		return x.AmountMismatchCount
+	}
+	return 0
+}
+
+func (x *ReconciliationReport) GetStatusMismatchCount() int64 {
+	if x != nil {
+		return x.StatusMismatchCount
+	}
+	return 0
+}
+
+func (x *ReconciliationReport) GetMissingInPaystoreCount() int64 {
+	if x != nil {
+		return x.MissingInPaystoreCount
+	}
+	return 0
+}
+
+func (x *ReconciliationReport) GetMissingAtVendorCount() int64 {
+	if x != nil {
+		return x.MissingAtVendorCount
+	}
+	return 0
+}
+
+func (x *ReconciliationReport) GetAutoFinalizedCount() int64 {
+	if x != nil {
+		return x.AutoFinalizedCount
+	}
+	return 0
+}
+
+func (x *ReconciliationReport) GetItems() []*ReconciliationItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type ReconciliationItem struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Result            ReconciliationResult   `protobuf:"varint,1,opt,name=Result,proto3,enum=paystore.ReconciliationResult" json:"Result,omitempty"`
+	RecordUUID        string                 `protobuf:"bytes,2,opt,name=RecordUUID,proto3" json:"RecordUUID,omitempty"`
+	BalanceUUID       string                 `protobuf:"bytes,3,opt,name=BalanceUUID,proto3" json:"BalanceUUID,omitempty"`
+	VendorRecordID    string                 `protobuf:"bytes,4,opt,name=VendorRecordID,proto3" json:"VendorRecordID,omitempty"`
+	PaystoreAmount    int64                  `protobuf:"varint,5,opt,name=PaystoreAmount,proto3" json:"PaystoreAmount,omitempty"`
+	VendorAmount      int64                  `protobuf:"varint,6,opt,name=VendorAmount,proto3" json:"VendorAmount,omitempty"`
+	PaystoreStatus    string                 `protobuf:"bytes,7,opt,name=PaystoreStatus,proto3" json:"PaystoreStatus,omitempty"`
+	VendorStatus      string                 `protobuf:"bytes,8,opt,name=VendorStatus,proto3" json:"VendorStatus,omitempty"`
+	VendorRawStatus   string                 `protobuf:"bytes,9,opt,name=VendorRawStatus,proto3" json:"VendorRawStatus,omitempty"`
+	PaystoreCurrency  string                 `protobuf:"bytes,10,opt,name=PaystoreCurrency,proto3" json:"PaystoreCurrency,omitempty"`
+	VendorCurrency    string                 `protobuf:"bytes,11,opt,name=VendorCurrency,proto3" json:"VendorCurrency,omitempty"`
+	VendorFailureCode string                 `protobuf:"bytes,12,opt,name=VendorFailureCode,proto3" json:"VendorFailureCode,omitempty"`
+	AutoFinalized     bool                   `protobuf:"varint,13,opt,name=AutoFinalized,proto3" json:"AutoFinalized,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ReconciliationItem) Reset() {
+	*x = ReconciliationItem{}
+	mi := &file_operation_paystore_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconciliationItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconciliationItem) ProtoMessage() {}
+
+func (x *ReconciliationItem) ProtoReflect() protoreflect.Message {
+	mi := &file_operation_paystore_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconciliationItem.ProtoReflect.Descriptor instead.
+func (*ReconciliationItem) Descriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ReconciliationItem) GetResult() ReconciliationResult {
+	if x != nil {
+		return x.Result
+	}
+	return ReconciliationResult_RECONCILIATION_RESULT_UNSPECIFIED
+}
+
+func (x *ReconciliationItem) GetRecordUUID() string {
+	if x != nil {
+		return x.RecordUUID
+	}
+	return ""
+}
+
+func (x *ReconciliationItem) GetBalanceUUID() string {
+	if x != nil {
+		return x.BalanceUUID
+	}
+	return ""
+}
+
+func (x *ReconciliationItem) GetVendorRecordID() string {
+	if x != nil {
+		return x.VendorRecordID
+	}
+	return ""
+}
+
+func (x *ReconciliationItem) GetPaystoreAmount() int64 {
+	if x != nil {
+		return x.PaystoreAmount
+	}
+	return 0
+}
+
+func (x *ReconciliationItem) GetVendorAmount() int64 {
+	if x != nil {
+		return x.VendorAmount
+	}
+	return 0
+}
+
+func (x *ReconciliationItem) GetPaystoreStatus() string {
+	if x != nil {
+		return x.PaystoreStatus
+	}
+	return ""
+}
+
+func (x *ReconciliationItem) GetVendorStatus() string {
+	if x != nil {
+		return x.VendorStatus
+	}
+	return ""
+}
+
+func (x *ReconciliationItem) GetVendorRawStatus() string {
+	if x != nil {
+		return x.VendorRawStatus
+	}
+	return ""
+}
+
+func (x *ReconciliationItem) GetPaystoreCurrency() string {
+	if x != nil {
+		return x.PaystoreCurrency
+	}
+	return ""
+}
+
+func (x *ReconciliationItem) GetVendorCurrency() string {
+	if x != nil {
+		return x.VendorCurrency
+	}
+	return ""
+}
+
+func (x *ReconciliationItem) GetVendorFailureCode() string {
+	if x != nil {
+		return x.VendorFailureCode
+	}
+	return ""
+}
+
+func (x *ReconciliationItem) GetAutoFinalized() bool {
+	if x != nil {
+		return x.AutoFinalized
+	}
+	return false
+}
+
+type RegisterWebhookEndpointRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationUUID string                 `protobuf:"bytes,1,opt,name=OrganizationUUID,proto3" json:"OrganizationUUID,omitempty"`
+	URL              string                 `protobuf:"bytes,2,opt,name=URL,proto3" json:"URL,omitempty"`
+	EventTypes       []string               `protobuf:"bytes,3,rep,name=EventTypes,proto3" json:"EventTypes,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *RegisterWebhookEndpointRequest) Reset() {
+	*x = RegisterWebhookEndpointRequest{}
+	mi := &file_operation_paystore_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterWebhookEndpointRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterWebhookEndpointRequest) ProtoMessage() {}
+
+func (x *RegisterWebhookEndpointRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_operation_paystore_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterWebhookEndpointRequest.ProtoReflect.Descriptor instead.
+func (*RegisterWebhookEndpointRequest) Descriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RegisterWebhookEndpointRequest) GetOrganizationUUID() string {
+	if x != nil {
+		return x.OrganizationUUID
+	}
+	return ""
+}
+
+func (x *RegisterWebhookEndpointRequest) GetURL() string {
+	if x != nil {
+		return x.URL
+	}
+	return ""
+}
+
+func (x *RegisterWebhookEndpointRequest) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+type WebhookEndpointResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EndpointUUID  string                 `protobuf:"bytes,1,opt,name=EndpointUUID,proto3" json:"EndpointUUID,omitempty"`
+	Secret        string                 `protobuf:"bytes,2,opt,name=Secret,proto3" json:"Secret,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookEndpointResponse) Reset() {
+	*x = WebhookEndpointResponse{}
+	mi := &file_operation_paystore_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookEndpointResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookEndpointResponse) ProtoMessage() {}
+
+func (x *WebhookEndpointResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_operation_paystore_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookEndpointResponse.ProtoReflect.Descriptor instead.
+func (*WebhookEndpointResponse) Descriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *WebhookEndpointResponse) GetEndpointUUID() string {
+	if x != nil {
+		return x.EndpointUUID
+	}
+	return ""
+}
+
+func (x *WebhookEndpointResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+type RemoveWebhookEndpointRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationUUID string                 `protobuf:"bytes,1,opt,name=OrganizationUUID,proto3" json:"OrganizationUUID,omitempty"`
+	EndpointUUID     string                 `protobuf:"bytes,2,opt,name=EndpointUUID,proto3" json:"EndpointUUID,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *RemoveWebhookEndpointRequest) Reset() {
+	*x = RemoveWebhookEndpointRequest{}
+	mi := &file_operation_paystore_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveWebhookEndpointRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveWebhookEndpointRequest) ProtoMessage() {}
+
+func (x *RemoveWebhookEndpointRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_operation_paystore_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveWebhookEndpointRequest.ProtoReflect.Descriptor instead.
+func (*RemoveWebhookEndpointRequest) Descriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *RemoveWebhookEndpointRequest) GetOrganizationUUID() string {
+	if x != nil {
+		return x.OrganizationUUID
+	}
+	return ""
+}
+
+func (x *RemoveWebhookEndpointRequest) GetEndpointUUID() string {
+	if x != nil {
+		return x.EndpointUUID
+	}
+	return ""
+}
+
+type RotateWebhookSecretRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationUUID string                 `protobuf:"bytes,1,opt,name=OrganizationUUID,proto3" json:"OrganizationUUID,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *RotateWebhookSecretRequest) Reset() {
+	*x = RotateWebhookSecretRequest{}
+	mi := &file_operation_paystore_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateWebhookSecretRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateWebhookSecretRequest) ProtoMessage() {}
+
+func (x *RotateWebhookSecretRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_operation_paystore_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateWebhookSecretRequest.ProtoReflect.Descriptor instead.
+func (*RotateWebhookSecretRequest) Descriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *RotateWebhookSecretRequest) GetOrganizationUUID() string {
+	if x != nil {
+		return x.OrganizationUUID
+	}
+	return ""
+}
+
+type WebhookSecretResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Secret        string                 `protobuf:"bytes,1,opt,name=Secret,proto3" json:"Secret,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookSecretResponse) Reset() {
+	*x = WebhookSecretResponse{}
+	mi := &file_operation_paystore_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookSecretResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookSecretResponse) ProtoMessage() {}
+
+func (x *WebhookSecretResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_operation_paystore_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookSecretResponse.ProtoReflect.Descriptor instead.
+func (*WebhookSecretResponse) Descriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *WebhookSecretResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+type ReplayWebhookDeliveryRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationUUID string                 `protobuf:"bytes,1,opt,name=OrganizationUUID,proto3" json:"OrganizationUUID,omitempty"`
+	DeliveryUUID     string                 `protobuf:"bytes,2,opt,name=DeliveryUUID,proto3" json:"DeliveryUUID,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ReplayWebhookDeliveryRequest) Reset() {
+	*x = ReplayWebhookDeliveryRequest{}
+	mi := &file_operation_paystore_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayWebhookDeliveryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayWebhookDeliveryRequest) ProtoMessage() {}
+
+func (x *ReplayWebhookDeliveryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_operation_paystore_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayWebhookDeliveryRequest.ProtoReflect.Descriptor instead.
+func (*ReplayWebhookDeliveryRequest) Descriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ReplayWebhookDeliveryRequest) GetOrganizationUUID() string {
+	if x != nil {
+		return x.OrganizationUUID
+	}
+	return ""
+}
+
+func (x *ReplayWebhookDeliveryRequest) GetDeliveryUUID() string {
+	if x != nil {
+		return x.DeliveryUUID
+	}
+	return ""
+}
+
+type FeeRule struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Channel         string                 `protobuf:"bytes,1,opt,name=Channel,proto3" json:"Channel,omitempty"`
+	PercentBps      int64                  `protobuf:"varint,2,opt,name=PercentBps,proto3" json:"PercentBps,omitempty"`
+	Fixed           int64                  `protobuf:"varint,3,opt,name=Fixed,proto3" json:"Fixed,omitempty"`
+	Min             int64                  `protobuf:"varint,4,opt,name=Min,proto3" json:"Min,omitempty"`
+	Max             int64                  `protobuf:"varint,5,opt,name=Max,proto3" json:"Max,omitempty"`
+	RateNumerator   int64                  `protobuf:"varint,6,opt,name=RateNumerator,proto3" json:"RateNumerator,omitempty"`
+	RateDenominator int64                  `protobuf:"varint,7,opt,name=RateDenominator,proto3" json:"RateDenominator,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *FeeRule) Reset() {
+	*x = FeeRule{}
+	mi := &file_operation_paystore_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeeRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeeRule) ProtoMessage() {}
+
+func (x *FeeRule) ProtoReflect() protoreflect.Message {
+	mi := &file_operation_paystore_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeeRule.ProtoReflect.Descriptor instead.
+func (*FeeRule) Descriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *FeeRule) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *FeeRule) GetPercentBps() int64 {
+	if x != nil {
+		return x.PercentBps
+	}
+	return 0
+}
+
+func (x *FeeRule) GetFixed() int64 {
+	if x != nil {
+		return x.Fixed
+	}
+	return 0
+}
+
+func (x *FeeRule) GetMin() int64 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *FeeRule) GetMax() int64 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+func (x *FeeRule) GetRateNumerator() int64 {
+	if x != nil {
+		return x.RateNumerator
+	}
+	return 0
+}
+
+func (x *FeeRule) GetRateDenominator() int64 {
+	if x != nil {
+		return x.RateDenominator
+	}
+	return 0
+}
+
+type SetFeeScheduleRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationUUID string                 `protobuf:"bytes,1,opt,name=OrganizationUUID,proto3" json:"OrganizationUUID,omitempty"`
+	Rules            []*FeeRule             `protobuf:"bytes,2,rep,name=Rules,proto3" json:"Rules,omitempty"`
+	Kind             FeeScheduleKind        `protobuf:"varint,3,opt,name=Kind,proto3,enum=paystore.FeeScheduleKind" json:"Kind,omitempty"`
+	EffectiveFrom    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=EffectiveFrom,proto3" json:"EffectiveFrom,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *SetFeeScheduleRequest) Reset() {
+	*x = SetFeeScheduleRequest{}
+	mi := &file_operation_paystore_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetFeeScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetFeeScheduleRequest) ProtoMessage() {}
+
+func (x *SetFeeScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_operation_paystore_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetFeeScheduleRequest.ProtoReflect.Descriptor instead.
+func (*SetFeeScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *SetFeeScheduleRequest) GetOrganizationUUID() string {
+	if x != nil {
+		return x.OrganizationUUID
+	}
+	return ""
+}
+
+func (x *SetFeeScheduleRequest) GetRules() []*FeeRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *SetFeeScheduleRequest) GetKind() FeeScheduleKind {
+	if x != nil {
+		return x.Kind
+	}
+	return FeeScheduleKind_FEE_SCHEDULE_KIND_UNSPECIFIED
+}
+
+func (x *SetFeeScheduleRequest) GetEffectiveFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EffectiveFrom
+	}
+	return nil
+}
+
+type GetFeeScheduleRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationUUID string                 `protobuf:"bytes,1,opt,name=OrganizationUUID,proto3" json:"OrganizationUUID,omitempty"`
+	Kind             FeeScheduleKind        `protobuf:"varint,2,opt,name=Kind,proto3,enum=paystore.FeeScheduleKind" json:"Kind,omitempty"`
+	At               *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=At,proto3" json:"At,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetFeeScheduleRequest) Reset() {
+	*x = GetFeeScheduleRequest{}
+	mi := &file_operation_paystore_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFeeScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFeeScheduleRequest) ProtoMessage() {}
+
+func (x *GetFeeScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_operation_paystore_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFeeScheduleRequest.ProtoReflect.Descriptor instead.
+func (*GetFeeScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetFeeScheduleRequest) GetOrganizationUUID() string {
+	if x != nil {
+		return x.OrganizationUUID
+	}
+	return ""
+}
+
+func (x *GetFeeScheduleRequest) GetKind() FeeScheduleKind {
+	if x != nil {
+		return x.Kind
+	}
+	return FeeScheduleKind_FEE_SCHEDULE_KIND_UNSPECIFIED
+}
+
+func (x *GetFeeScheduleRequest) GetAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.At
+	}
+	return nil
+}
+
+type ListFeeSchedulesRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationUUID string                 `protobuf:"bytes,1,opt,name=OrganizationUUID,proto3" json:"OrganizationUUID,omitempty"`
+	Kind             FeeScheduleKind        `protobuf:"varint,2,opt,name=Kind,proto3,enum=paystore.FeeScheduleKind" json:"Kind,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ListFeeSchedulesRequest) Reset() {
+	*x = ListFeeSchedulesRequest{}
+	mi := &file_operation_paystore_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFeeSchedulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFeeSchedulesRequest) ProtoMessage() {}
+
+func (x *ListFeeSchedulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_operation_paystore_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFeeSchedulesRequest.ProtoReflect.Descriptor instead.
+func (*ListFeeSchedulesRequest) Descriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListFeeSchedulesRequest) GetOrganizationUUID() string {
+	if x != nil {
+		return x.OrganizationUUID
+	}
+	return ""
+}
+
+func (x *ListFeeSchedulesRequest) GetKind() FeeScheduleKind {
+	if x != nil {
+		return x.Kind
+	}
+	return FeeScheduleKind_FEE_SCHEDULE_KIND_UNSPECIFIED
+}
+
+type FeeSchedule struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ScheduleUUID     string                 `protobuf:"bytes,1,opt,name=ScheduleUUID,proto3" json:"ScheduleUUID,omitempty"`
+	OrganizationUUID string                 `protobuf:"bytes,2,opt,name=OrganizationUUID,proto3" json:"OrganizationUUID,omitempty"`
+	Rules            []*FeeRule             `protobuf:"bytes,3,rep,name=Rules,proto3" json:"Rules,omitempty"`
+	Kind             FeeScheduleKind        `protobuf:"varint,4,opt,name=Kind,proto3,enum=paystore.FeeScheduleKind" json:"Kind,omitempty"`
+	Rounding         FeeRounding            `protobuf:"varint,5,opt,name=Rounding,proto3,enum=paystore.FeeRounding" json:"Rounding,omitempty"`
+	EffectiveFrom    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=EffectiveFrom,proto3" json:"EffectiveFrom,omitempty"`
+	EffectiveTo      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=EffectiveTo,proto3" json:"EffectiveTo,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *FeeSchedule) Reset() {
+	*x = FeeSchedule{}
+	mi := &file_operation_paystore_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeeSchedule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeeSchedule) ProtoMessage() {}
+
+func (x *FeeSchedule) ProtoReflect() protoreflect.Message {
+	mi := &file_operation_paystore_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeeSchedule.ProtoReflect.Descriptor instead.
+func (*FeeSchedule) Descriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *FeeSchedule) GetScheduleUUID() string {
+	if x != nil {
+		return x.ScheduleUUID
+	}
+	return ""
+}
+
+func (x *FeeSchedule) GetOrganizationUUID() string {
+	if x != nil {
+		return x.OrganizationUUID
+	}
+	return ""
+}
+
+func (x *FeeSchedule) GetRules() []*FeeRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *FeeSchedule) GetKind() FeeScheduleKind {
+	if x != nil {
+		return x.Kind
+	}
+	return FeeScheduleKind_FEE_SCHEDULE_KIND_UNSPECIFIED
+}
+
+func (x *FeeSchedule) GetRounding() FeeRounding {
+	if x != nil {
+		return x.Rounding
+	}
+	return FeeRounding_FEE_ROUNDING_UNSPECIFIED
+}
+
+func (x *FeeSchedule) GetEffectiveFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EffectiveFrom
+	}
+	return nil
+}
+
+func (x *FeeSchedule) GetEffectiveTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EffectiveTo
+	}
+	return nil
+}
+
+type FeeSchedules struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schedules     []*FeeSchedule         `protobuf:"bytes,1,rep,name=Schedules,proto3" json:"Schedules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeeSchedules) Reset() {
+	*x = FeeSchedules{}
+	mi := &file_operation_paystore_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeeSchedules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeeSchedules) ProtoMessage() {}
+
+func (x *FeeSchedules) ProtoReflect() protoreflect.Message {
+	mi := &file_operation_paystore_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeeSchedules.ProtoReflect.Descriptor instead.
+func (*FeeSchedules) Descriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *FeeSchedules) GetSchedules() []*FeeSchedule {
+	if x != nil {
+		return x.Schedules
+	}
+	return nil
+}
+
+type SetFeeRoundingRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationUUID string                 `protobuf:"bytes,1,opt,name=OrganizationUUID,proto3" json:"OrganizationUUID,omitempty"`
+	Rounding         FeeRounding            `protobuf:"varint,2,opt,name=Rounding,proto3,enum=paystore.FeeRounding" json:"Rounding,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *SetFeeRoundingRequest) Reset() {
+	*x = SetFeeRoundingRequest{}
+	mi := &file_operation_paystore_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetFeeRoundingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetFeeRoundingRequest) ProtoMessage() {}
+
+func (x *SetFeeRoundingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_operation_paystore_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetFeeRoundingRequest.ProtoReflect.Descriptor instead.
+func (*SetFeeRoundingRequest) Descriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *SetFeeRoundingRequest) GetOrganizationUUID() string {
+	if x != nil {
+		return x.OrganizationUUID
+	}
+	return ""
+}
+
+func (x *SetFeeRoundingRequest) GetRounding() FeeRounding {
+	if x != nil {
+		return x.Rounding
+	}
+	return FeeRounding_FEE_ROUNDING_UNSPECIFIED
+}
+
+type GetRevenueBalanceRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationUUID string                 `protobuf:"bytes,1,opt,name=OrganizationUUID,proto3" json:"OrganizationUUID,omitempty"`
+	Currency         string                 `protobuf:"bytes,2,opt,name=Currency,proto3" json:"Currency,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetRevenueBalanceRequest) Reset() {
+	*x = GetRevenueBalanceRequest{}
+	mi := &file_operation_paystore_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRevenueBalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRevenueBalanceRequest) ProtoMessage() {}
+
+func (x *GetRevenueBalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_operation_paystore_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRevenueBalanceRequest.ProtoReflect.Descriptor instead.
+func (*GetRevenueBalanceRequest) Descriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetRevenueBalanceRequest) GetOrganizationUUID() string {
+	if x != nil {
+		return x.OrganizationUUID
+	}
+	return ""
+}
+
+func (x *GetRevenueBalanceRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type RevenueBalance struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	BalanceUUID          string                 `protobuf:"bytes,1,opt,name=BalanceUUID,proto3" json:"BalanceUUID,omitempty"`
+	OrganizationUUID     string                 `protobuf:"bytes,2,opt,name=OrganizationUUID,proto3" json:"OrganizationUUID,omitempty"`
+	Currency             string                 `protobuf:"bytes,3,opt,name=Currency,proto3" json:"Currency,omitempty"`
+	Balance              int64                  `protobuf:"varint,4,opt,name=Balance,proto3" json:"Balance,omitempty"`
+	IncomeAccumulation   int64                  `protobuf:"varint,5,opt,name=IncomeAccumulation,proto3" json:"IncomeAccumulation,omitempty"`
+	WithdrawAccumulation int64                  `protobuf:"varint,6,opt,name=WithdrawAccumulation,proto3" json:"WithdrawAccumulation,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *RevenueBalance) Reset() {
+	*x = RevenueBalance{}
+	mi := &file_operation_paystore_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevenueBalance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevenueBalance) ProtoMessage() {}
+
+func (x *RevenueBalance) ProtoReflect() protoreflect.Message {
+	mi := &file_operation_paystore_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevenueBalance.ProtoReflect.Descriptor instead.
+func (*RevenueBalance) Descriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *RevenueBalance) GetBalanceUUID() string {
+	if x != nil {
+		return x.BalanceUUID
+	}
+	return ""
+}
+
+func (x *RevenueBalance) GetOrganizationUUID() string {
+	if x != nil {
+		return x.OrganizationUUID
+	}
+	return ""
+}
+
+func (x *RevenueBalance) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *RevenueBalance) GetBalance() int64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+func (x *RevenueBalance) GetIncomeAccumulation() int64 {
+	if x != nil {
+		return x.IncomeAccumulation
+	}
+	return 0
+}
+
+func (x *RevenueBalance) GetWithdrawAccumulation() int64 {
+	if x != nil {
+		return x.WithdrawAccumulation
+	}
+	return 0
+}
+
+type Limit struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	UUID               string                 `protobuf:"bytes,1,opt,name=UUID,proto3" json:"UUID,omitempty"`
+	Scope              LimitScope             `protobuf:"varint,2,opt,name=Scope,proto3,enum=paystore.LimitScope" json:"Scope,omitempty"`
+	ScopeUUID          string                 `protobuf:"bytes,3,opt,name=ScopeUUID,proto3" json:"ScopeUUID,omitempty"`
+	MaxPayment         int64                  `protobuf:"varint,4,opt,name=MaxPayment,proto3" json:"MaxPayment,omitempty"`
+	MaxWithdraw        int64                  `protobuf:"varint,5,opt,name=MaxWithdraw,proto3" json:"MaxWithdraw,omitempty"`
+	DailyPayment       int64                  `protobuf:"varint,6,opt,name=DailyPayment,proto3" json:"DailyPayment,omitempty"`
+	MonthlyPayment     int64                  `protobuf:"varint,7,opt,name=MonthlyPayment,proto3" json:"MonthlyPayment,omitempty"`
+	DailyWithdraw      int64                  `protobuf:"varint,8,opt,name=DailyWithdraw,proto3" json:"DailyWithdraw,omitempty"`
+	MonthlyWithdraw    int64                  `protobuf:"varint,9,opt,name=MonthlyWithdraw,proto3" json:"MonthlyWithdraw,omitempty"`
+	DailyWithdrawCount int64                  `protobuf:"varint,10,opt,name=DailyWithdrawCount,proto3" json:"DailyWithdrawCount,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *Limit) Reset() {
+	*x = Limit{}
+	mi := &file_operation_paystore_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Limit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Limit) ProtoMessage() {}
+
+func (x *Limit) ProtoReflect() protoreflect.Message {
+	mi := &file_operation_paystore_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Limit.ProtoReflect.Descriptor instead.
+func (*Limit) Descriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *Limit) GetUUID() string {
+	if x != nil {
+		return x.UUID
+	}
+	return ""
+}
+
+func (x *Limit) GetScope() LimitScope {
+	if x != nil {
+		return x.Scope
+	}
+	return LimitScope_LIMIT_SCOPE_UNSPECIFIED
+}
+
+func (x *Limit) GetScopeUUID() string {
+	if x != nil {
+		return x.ScopeUUID
+	}
+	return ""
+}
+
+func (x *Limit) GetMaxPayment() int64 {
+	if x != nil {
+		return x.MaxPayment
+	}
+	return 0
+}
+
+func (x *Limit) GetMaxWithdraw() int64 {
+	if x != nil {
+		return x.MaxWithdraw
+	}
+	return 0
+}
+
+func (x *Limit) GetDailyPayment() int64 {
+	if x != nil {
+		return x.DailyPayment
+	}
+	return 0
+}
+
+func (x *Limit) GetMonthlyPayment() int64 {
+	if x != nil {
+		return x.MonthlyPayment
+	}
+	return 0
+}
+
+func (x *Limit) GetDailyWithdraw() int64 {
+	if x != nil {
+		return x.DailyWithdraw
+	}
+	return 0
+}
+
+func (x *Limit) GetMonthlyWithdraw() int64 {
+	if x != nil {
+		return x.MonthlyWithdraw
+	}
+	return 0
+}
+
+func (x *Limit) GetDailyWithdrawCount() int64 {
+	if x != nil {
+		return x.DailyWithdrawCount
+	}
+	return 0
+}
+
+type SetLimitRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Scope              LimitScope             `protobuf:"varint,1,opt,name=Scope,proto3,enum=paystore.LimitScope" json:"Scope,omitempty"`
+	ScopeUUID          string                 `protobuf:"bytes,2,opt,name=ScopeUUID,proto3" json:"ScopeUUID,omitempty"`
+	MaxPayment         int64                  `protobuf:"varint,3,opt,name=MaxPayment,proto3" json:"MaxPayment,omitempty"`
+	MaxWithdraw        int64                  `protobuf:"varint,4,opt,name=MaxWithdraw,proto3" json:"MaxWithdraw,omitempty"`
+	DailyPayment       int64                  `protobuf:"varint,5,opt,name=DailyPayment,proto3" json:"DailyPayment,omitempty"`
+	MonthlyPayment     int64                  `protobuf:"varint,6,opt,name=MonthlyPayment,proto3" json:"MonthlyPayment,omitempty"`
+	DailyWithdraw      int64                  `protobuf:"varint,7,opt,name=DailyWithdraw,proto3" json:"DailyWithdraw,omitempty"`
+	MonthlyWithdraw    int64                  `protobuf:"varint,8,opt,name=MonthlyWithdraw,proto3" json:"MonthlyWithdraw,omitempty"`
+	DailyWithdrawCount int64                  `protobuf:"varint,9,opt,name=DailyWithdrawCount,proto3" json:"DailyWithdrawCount,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *SetLimitRequest) Reset() {
+	*x = SetLimitRequest{}
+	mi := &file_operation_paystore_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetLimitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLimitRequest) ProtoMessage() {}
+
+func (x *SetLimitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_operation_paystore_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLimitRequest.ProtoReflect.Descriptor instead.
+func (*SetLimitRequest) Descriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *SetLimitRequest) GetScope() LimitScope {
+	if x != nil {
+		return x.Scope
+	}
+	return LimitScope_LIMIT_SCOPE_UNSPECIFIED
+}
+
+func (x *SetLimitRequest) GetScopeUUID() string {
+	if x != nil {
+		return x.ScopeUUID
+	}
+	return ""
+}
+
+func (x *SetLimitRequest) GetMaxPayment() int64 {
+	if x != nil {
+		return x.MaxPayment
+	}
+	return 0
+}
+
+func (x *SetLimitRequest) GetMaxWithdraw() int64 {
+	if x != nil {
+		return x.MaxWithdraw
+	}
+	return 0
+}
+
+func (x *SetLimitRequest) GetDailyPayment() int64 {
+	if x != nil {
+		return x.DailyPayment
+	}
+	return 0
+}
+
+func (x *SetLimitRequest) GetMonthlyPayment() int64 {
+	if x != nil {
+		return x.MonthlyPayment
+	}
+	return 0
+}
+
+func (x *SetLimitRequest) GetDailyWithdraw() int64 {
+	if x != nil {
+		return x.DailyWithdraw
+	}
+	return 0
+}
+
+func (x *SetLimitRequest) GetMonthlyWithdraw() int64 {
+	if x != nil {
+		return x.MonthlyWithdraw
+	}
+	return 0
+}
+
+func (x *SetLimitRequest) GetDailyWithdrawCount() int64 {
+	if x != nil {
+		return x.DailyWithdrawCount
+	}
+	return 0
+}
+
+type GetLimitRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Scope         LimitScope             `protobuf:"varint,1,opt,name=Scope,proto3,enum=paystore.LimitScope" json:"Scope,omitempty"`
+	ScopeUUID     string                 `protobuf:"bytes,2,opt,name=ScopeUUID,proto3" json:"ScopeUUID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLimitRequest) Reset() {
+	*x = GetLimitRequest{}
+	mi := &file_operation_paystore_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLimitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLimitRequest) ProtoMessage() {}
+
+func (x *GetLimitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_operation_paystore_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLimitRequest.ProtoReflect.Descriptor instead.
+func (*GetLimitRequest) Descriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *GetLimitRequest) GetScope() LimitScope {
+	if x != nil {
+		return x.Scope
+	}
+	return LimitScope_LIMIT_SCOPE_UNSPECIFIED
+}
+
+func (x *GetLimitRequest) GetScopeUUID() string {
+	if x != nil {
+		return x.ScopeUUID
+	}
+	return ""
+}
+
+type SetOrganizationParentRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationUUID string                 `protobuf:"bytes,1,opt,name=OrganizationUUID,proto3" json:"OrganizationUUID,omitempty"`
+	ParentUUID       string                 `protobuf:"bytes,2,opt,name=ParentUUID,proto3" json:"ParentUUID,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *SetOrganizationParentRequest) Reset() {
+	*x = SetOrganizationParentRequest{}
+	mi := &file_operation_paystore_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetOrganizationParentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetOrganizationParentRequest) ProtoMessage() {}
+
+func (x *SetOrganizationParentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_operation_paystore_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetOrganizationParentRequest.ProtoReflect.Descriptor instead.
+func (*SetOrganizationParentRequest) Descriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *SetOrganizationParentRequest) GetOrganizationUUID() string {
+	if x != nil {
+		return x.OrganizationUUID
+	}
+	return ""
+}
+
+func (x *SetOrganizationParentRequest) GetParentUUID() string {
+	if x != nil {
+		return x.ParentUUID
+	}
+	return ""
+}
+
+type GetBalanceRollupRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationUUID string                 `protobuf:"bytes,1,opt,name=OrganizationUUID,proto3" json:"OrganizationUUID,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetBalanceRollupRequest) Reset() {
+	*x = GetBalanceRollupRequest{}
+	mi := &file_operation_paystore_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBalanceRollupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalanceRollupRequest) ProtoMessage() {}
+
+func (x *GetBalanceRollupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_operation_paystore_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalanceRollupRequest.ProtoReflect.Descriptor instead.
+func (*GetBalanceRollupRequest) Descriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *GetBalanceRollupRequest) GetOrganizationUUID() string {
+	if x != nil {
+		return x.OrganizationUUID
+	}
+	return ""
+}
+
+type BalanceRollup struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationUUID     string                 `protobuf:"bytes,1,opt,name=OrganizationUUID,proto3" json:"OrganizationUUID,omitempty"`
+	Currency             string                 `protobuf:"bytes,2,opt,name=Currency,proto3" json:"Currency,omitempty"`
+	BalanceCount         int64                  `protobuf:"varint,3,opt,name=BalanceCount,proto3" json:"BalanceCount,omitempty"`
+	Balance              int64                  `protobuf:"varint,4,opt,name=Balance,proto3" json:"Balance,omitempty"`
+	IncomeAccumulation   int64                  `protobuf:"varint,5,opt,name=IncomeAccumulation,proto3" json:"IncomeAccumulation,omitempty"`
+	WithdrawAccumulation int64                  `protobuf:"varint,6,opt,name=WithdrawAccumulation,proto3" json:"WithdrawAccumulation,omitempty"`
+	Organizations        []*BalanceRollup       `protobuf:"bytes,7,rep,name=Organizations,proto3" json:"Organizations,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *BalanceRollup) Reset() {
+	*x = BalanceRollup{}
+	mi := &file_operation_paystore_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BalanceRollup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BalanceRollup) ProtoMessage() {}
+
+func (x *BalanceRollup) ProtoReflect() protoreflect.Message {
+	mi := &file_operation_paystore_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BalanceRollup.ProtoReflect.Descriptor instead.
+func (*BalanceRollup) Descriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *BalanceRollup) GetOrganizationUUID() string {
+	if x != nil {
+		return x.OrganizationUUID
+	}
+	return ""
+}
+
+func (x *BalanceRollup) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *BalanceRollup) GetBalanceCount() int64 {
+	if x != nil {
+		return x.BalanceCount
+	}
+	return 0
+}
+
+func (x *BalanceRollup) GetBalance() int64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+func (x *BalanceRollup) GetIncomeAccumulation() int64 {
+	if x != nil {
+		return x.IncomeAccumulation
+	}
+	return 0
+}
+
+func (x *BalanceRollup) GetWithdrawAccumulation() int64 {
+	if x != nil {
+		return x.WithdrawAccumulation
+	}
+	return 0
+}
+
+func (x *BalanceRollup) GetOrganizations() []*BalanceRollup {
+	if x != nil {
+		return x.Organizations
+	}
+	return nil
+}
+
+type BalanceRollupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rollups       []*BalanceRollup       `protobuf:"bytes,1,rep,name=Rollups,proto3" json:"Rollups,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BalanceRollupResponse) Reset() {
+	*x = BalanceRollupResponse{}
+	mi := &file_operation_paystore_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BalanceRollupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BalanceRollupResponse) ProtoMessage() {}
+
+func (x *BalanceRollupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_operation_paystore_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BalanceRollupResponse.ProtoReflect.Descriptor instead.
+func (*BalanceRollupResponse) Descriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *BalanceRollupResponse) GetRollups() []*BalanceRollup {
+	if x != nil {
+		return x.Rollups
+	}
+	return nil
+}
+
+// Settlement days are UTC dates written as YYYY-MM-DD.
+type RecomputeSettlementRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Day           string                 `protobuf:"bytes,1,opt,name=Day,proto3" json:"Day,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecomputeSettlementRequest) Reset() {
+	*x = RecomputeSettlementRequest{}
+	mi := &file_operation_paystore_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecomputeSettlementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecomputeSettlementRequest) ProtoMessage() {}
+
+func (x *RecomputeSettlementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_operation_paystore_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecomputeSettlementRequest.ProtoReflect.Descriptor instead.
+func (*RecomputeSettlementRequest) Descriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *RecomputeSettlementRequest) GetDay() string {
+	if x != nil {
+		return x.Day
+	}
+	return ""
+}
+
+// GetSettlementReportsRequest lists an organization's reports from From to To, both inclusive;
+// an empty From or To leaves that end open.
+type GetSettlementReportsRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationUUID string                 `protobuf:"bytes,1,opt,name=OrganizationUUID,proto3" json:"OrganizationUUID,omitempty"`
+	Currency         string                 `protobuf:"bytes,2,opt,name=Currency,proto3" json:"Currency,omitempty"`
+	From             string                 `protobuf:"bytes,3,opt,name=From,proto3" json:"From,omitempty"`
+	To               string                 `protobuf:"bytes,4,opt,name=To,proto3" json:"To,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetSettlementReportsRequest) Reset() {
+	*x = GetSettlementReportsRequest{}
+	mi := &file_operation_paystore_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSettlementReportsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSettlementReportsRequest) ProtoMessage() {}
+
+func (x *GetSettlementReportsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_operation_paystore_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSettlementReportsRequest.ProtoReflect.Descriptor instead.
+func (*GetSettlementReportsRequest) Descriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *GetSettlementReportsRequest) GetOrganizationUUID() string {
+	if x != nil {
+		return x.OrganizationUUID
+	}
+	return ""
+}
+
+func (x *GetSettlementReportsRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *GetSettlementReportsRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *GetSettlementReportsRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+type SettlementReport struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	UUID                string                 `protobuf:"bytes,1,opt,name=UUID,proto3" json:"UUID,omitempty"`
+	OrganizationUUID    string                 `protobuf:"bytes,2,opt,name=OrganizationUUID,proto3" json:"OrganizationUUID,omitempty"`
+	Currency            string                 `protobuf:"bytes,3,opt,name=Currency,proto3" json:"Currency,omitempty"`
+	Day                 string                 `protobuf:"bytes,4,opt,name=Day,proto3" json:"Day,omitempty"`
+	GrossCollected      int64                  `protobuf:"varint,5,opt,name=GrossCollected,proto3" json:"GrossCollected,omitempty"`
+	Fees                int64                  `protobuf:"varint,6,opt,name=Fees,proto3" json:"Fees,omitempty"`
+	NetCredited         int64                  `protobuf:"varint,7,opt,name=NetCredited,proto3" json:"NetCredited,omitempty"`
+	PaidCount           int64                  `protobuf:"varint,8,opt,name=PaidCount,proto3" json:"PaidCount,omitempty"`
+	FailedPaymentCount  int64                  `protobuf:"varint,9,opt,name=FailedPaymentCount,proto3" json:"FailedPaymentCount,omitempty"`
+	Withdrawn           int64                  `protobuf:"varint,10,opt,name=Withdrawn,proto3" json:"Withdrawn,omitempty"`
+	WithdrawFees        int64                  `protobuf:"varint,11,opt,name=WithdrawFees,proto3" json:"WithdrawFees,omitempty"`
+	WithdrawCount       int64                  `protobuf:"varint,12,opt,name=WithdrawCount,proto3" json:"WithdrawCount,omitempty"`
+	FailedWithdrawCount int64                  `protobuf:"varint,13,opt,name=FailedWithdrawCount,proto3" json:"FailedWithdrawCount,omitempty"`
+	ClosingLiability    int64                  `protobuf:"varint,14,opt,name=ClosingLiability,proto3" json:"ClosingLiability,omitempty"`
+	UpdatedAt           *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=UpdatedAt,proto3" json:"UpdatedAt,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *SettlementReport) Reset() {
+	*x = SettlementReport{}
+	mi := &file_operation_paystore_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SettlementReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SettlementReport) ProtoMessage() {}
+
+func (x *SettlementReport) ProtoReflect() protoreflect.Message {
+	mi := &file_operation_paystore_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SettlementReport.ProtoReflect.Descriptor instead.
+func (*SettlementReport) Descriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *SettlementReport) GetUUID() string {
+	if x != nil {
+		return x.UUID
+	}
+	return ""
+}
+
+func (x *SettlementReport) GetOrganizationUUID() string {
+	if x != nil {
+		return x.OrganizationUUID
+	}
+	return ""
+}
+
+func (x *SettlementReport) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *SettlementReport) GetDay() string {
+	if x != nil {
+		return x.Day
+	}
+	return ""
+}
+
+func (x *SettlementReport) GetGrossCollected() int64 {
+	if x != nil {
+		return x.GrossCollected
+	}
+	return 0
+}
+
+func (x *SettlementReport) GetFees() int64 {
+	if x != nil {
+		return x.Fees
+	}
+	return 0
+}
+
+func (x *SettlementReport) GetNetCredited() int64 {
+	if x != nil {
+		return x.NetCredited
+	}
+	return 0
+}
+
+func (x *SettlementReport) GetPaidCount() int64 {
+	if x != nil {
+		return x.PaidCount
+	}
+	return 0
+}
+
+func (x *SettlementReport) GetFailedPaymentCount() int64 {
+	if x != nil {
+		return x.FailedPaymentCount
+	}
+	return 0
+}
+
+func (x *SettlementReport) GetWithdrawn() int64 {
+	if x != nil {
+		return x.Withdrawn
+	}
+	return 0
+}
+
+func (x *SettlementReport) GetWithdrawFees() int64 {
+	if x != nil {
+		return x.WithdrawFees
+	}
+	return 0
+}
+
+func (x *SettlementReport) GetWithdrawCount() int64 {
+	if x != nil {
+		return x.WithdrawCount
+	}
+	return 0
+}
+
+func (x *SettlementReport) GetFailedWithdrawCount() int64 {
+	if x != nil {
+		return x.FailedWithdrawCount
+	}
+	return 0
+}
+
+func (x *SettlementReport) GetClosingLiability() int64 {
+	if x != nil {
+		return x.ClosingLiability
+	}
+	return 0
+}
+
+func (x *SettlementReport) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type SettlementReports struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reports       []*SettlementReport    `protobuf:"bytes,1,rep,name=Reports,proto3" json:"Reports,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SettlementReports) Reset() {
+	*x = SettlementReports{}
+	mi := &file_operation_paystore_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SettlementReports) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SettlementReports) ProtoMessage() {}
+
+func (x *SettlementReports) ProtoReflect() protoreflect.Message {
+	mi := &file_operation_paystore_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SettlementReports.ProtoReflect.Descriptor instead.
+func (*SettlementReports) Descriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *SettlementReports) GetReports() []*SettlementReport {
+	if x != nil {
+		return x.Reports
+	}
+	return nil
+}
+
+// SetOrganizationStatusRequest needs a Reason to suspend or close. Actor names who made the
+// change in the audit trail.
+type SetOrganizationStatusRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationUUID string                 `protobuf:"bytes,1,opt,name=OrganizationUUID,proto3" json:"OrganizationUUID,omitempty"`
+	Status           OrganizationStatus     `protobuf:"varint,2,opt,name=Status,proto3,enum=paystore.OrganizationStatus" json:"Status,omitempty"`
+	Reason           string                 `protobuf:"bytes,3,opt,name=Reason,proto3" json:"Reason,omitempty"`
+	Actor            string                 `protobuf:"bytes,4,opt,name=Actor,proto3" json:"Actor,omitempty"`
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
 }
 
-func (x *CreateBalanceRequest) Reset() {
-	*x = CreateBalanceRequest{}
-	mi := &file_operation_paystore_proto_msgTypes[0]
+func (x *SetOrganizationStatusRequest) Reset() {
+	*x = SetOrganizationStatusRequest{}
+	mi := &file_operation_paystore_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateBalanceRequest) String() string {
+func (x *SetOrganizationStatusRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateBalanceRequest) ProtoMessage() {}
+func (*SetOrganizationStatusRequest) ProtoMessage() {}
 
-func (x *CreateBalanceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_operation_paystore_proto_msgTypes[0]
+func (x *SetOrganizationStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_operation_paystore_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -107,55 +4043,61 @@ func (x *CreateBalanceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateBalanceRequest.ProtoReflect.Descriptor instead.
-func (*CreateBalanceRequest) Descriptor() ([]byte, []int) {
-	return file_operation_paystore_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use SetOrganizationStatusRequest.ProtoReflect.Descriptor instead.
+func (*SetOrganizationStatusRequest) Descriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{46}
 }
 
-func (x *CreateBalanceRequest) GetExternalID() string {
+func (x *SetOrganizationStatusRequest) GetOrganizationUUID() string {
 	if x != nil {
-		return x.ExternalID
+		return x.OrganizationUUID
 	}
 	return ""
 }
 
-func (x *CreateBalanceRequest) GetOrganizationSlug() string {
+func (x *SetOrganizationStatusRequest) GetStatus() OrganizationStatus {
 	if x != nil {
-		return x.OrganizationSlug
+		return x.Status
+	}
+	return OrganizationStatus_ORGANIZATION_STATUS_UNSPECIFIED
+}
+
+func (x *SetOrganizationStatusRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
 	}
 	return ""
 }
 
-func (x *CreateBalanceRequest) GetCurrency() string {
+func (x *SetOrganizationStatusRequest) GetActor() string {
 	if x != nil {
-		return x.Currency
+		return x.Actor
 	}
 	return ""
 }
 
-type CreatePaymentRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AccountUUID   string                 `protobuf:"bytes,1,opt,name=AccountUUID,proto3" json:"AccountUUID,omitempty"`
-	Amount        int64                  `protobuf:"varint,2,opt,name=Amount,proto3" json:"Amount,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type GetOrganizationStatusHistoryRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationUUID string                 `protobuf:"bytes,1,opt,name=OrganizationUUID,proto3" json:"OrganizationUUID,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
-func (x *CreatePaymentRequest) Reset() {
-	*x = CreatePaymentRequest{}
-	mi := &file_operation_paystore_proto_msgTypes[1]
+func (x *GetOrganizationStatusHistoryRequest) Reset() {
+	*x = GetOrganizationStatusHistoryRequest{}
+	mi := &file_operation_paystore_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreatePaymentRequest) String() string {
+func (x *GetOrganizationStatusHistoryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreatePaymentRequest) ProtoMessage() {}
+func (*GetOrganizationStatusHistoryRequest) ProtoMessage() {}
 
-func (x *CreatePaymentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_operation_paystore_proto_msgTypes[1]
+func (x *GetOrganizationStatusHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_operation_paystore_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -166,50 +4108,46 @@ func (x *CreatePaymentRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreatePaymentRequest.ProtoReflect.Descriptor instead.
-func (*CreatePaymentRequest) Descriptor() ([]byte, []int) {
-	return file_operation_paystore_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use GetOrganizationStatusHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetOrganizationStatusHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{47}
 }
 
-func (x *CreatePaymentRequest) GetAccountUUID() string {
+func (x *GetOrganizationStatusHistoryRequest) GetOrganizationUUID() string {
 	if x != nil {
-		return x.AccountUUID
+		return x.OrganizationUUID
 	}
 	return ""
 }
 
-func (x *CreatePaymentRequest) GetAmount() int64 {
-	if x != nil {
-		return x.Amount
-	}
-	return 0
-}
-
-type FinalizedPaymentRequest struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	AccountUUID    string                 `protobuf:"bytes,1,opt,name=AccountUUID,proto3" json:"AccountUUID,omitempty"`
-	PaymentUUID    string                 `protobuf:"bytes,2,opt,name=PaymentUUID,proto3" json:"PaymentUUID,omitempty"`
-	VendorRecordId string                 `protobuf:"bytes,3,opt,name=VendorRecordId,proto3" json:"VendorRecordId,omitempty"`
-	PaymentStatus  PaymentStatus          `protobuf:"varint,4,opt,name=PaymentStatus,proto3,enum=paystore.PaymentStatus" json:"PaymentStatus,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+type OrganizationStatusChange struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	UUID             string                 `protobuf:"bytes,1,opt,name=UUID,proto3" json:"UUID,omitempty"`
+	OrganizationUUID string                 `protobuf:"bytes,2,opt,name=OrganizationUUID,proto3" json:"OrganizationUUID,omitempty"`
+	FromStatus       OrganizationStatus     `protobuf:"varint,3,opt,name=FromStatus,proto3,enum=paystore.OrganizationStatus" json:"FromStatus,omitempty"`
+	ToStatus         OrganizationStatus     `protobuf:"varint,4,opt,name=ToStatus,proto3,enum=paystore.OrganizationStatus" json:"ToStatus,omitempty"`
+	Reason           string                 `protobuf:"bytes,5,opt,name=Reason,proto3" json:"Reason,omitempty"`
+	Actor            string                 `protobuf:"bytes,6,opt,name=Actor,proto3" json:"Actor,omitempty"`
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
-func (x *FinalizedPaymentRequest) Reset() {
-	*x = FinalizedPaymentRequest{}
-	mi := &file_operation_paystore_proto_msgTypes[2]
+func (x *OrganizationStatusChange) Reset() {
+	*x = OrganizationStatusChange{}
+	mi := &file_operation_paystore_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FinalizedPaymentRequest) String() string {
+func (x *OrganizationStatusChange) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FinalizedPaymentRequest) ProtoMessage() {}
+func (*OrganizationStatusChange) ProtoMessage() {}
 
-func (x *FinalizedPaymentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_operation_paystore_proto_msgTypes[2]
+func (x *OrganizationStatusChange) ProtoReflect() protoreflect.Message {
+	mi := &file_operation_paystore_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -220,62 +4158,82 @@ func (x *FinalizedPaymentRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FinalizedPaymentRequest.ProtoReflect.Descriptor instead.
-func (*FinalizedPaymentRequest) Descriptor() ([]byte, []int) {
-	return file_operation_paystore_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use OrganizationStatusChange.ProtoReflect.Descriptor instead.
+func (*OrganizationStatusChange) Descriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{48}
 }
 
-func (x *FinalizedPaymentRequest) GetAccountUUID() string {
+func (x *OrganizationStatusChange) GetUUID() string {
 	if x != nil {
-		return x.AccountUUID
+		return x.UUID
 	}
 	return ""
 }
 
-func (x *FinalizedPaymentRequest) GetPaymentUUID() string {
+func (x *OrganizationStatusChange) GetOrganizationUUID() string {
 	if x != nil {
-		return x.PaymentUUID
+		return x.OrganizationUUID
 	}
 	return ""
 }
 
-func (x *FinalizedPaymentRequest) GetVendorRecordId() string {
+func (x *OrganizationStatusChange) GetFromStatus() OrganizationStatus {
 	if x != nil {
-		return x.VendorRecordId
+		return x.FromStatus
+	}
+	return OrganizationStatus_ORGANIZATION_STATUS_UNSPECIFIED
+}
+
+func (x *OrganizationStatusChange) GetToStatus() OrganizationStatus {
+	if x != nil {
+		return x.ToStatus
+	}
+	return OrganizationStatus_ORGANIZATION_STATUS_UNSPECIFIED
+}
+
+func (x *OrganizationStatusChange) GetReason() string {
+	if x != nil {
+		return x.Reason
 	}
 	return ""
 }
 
-func (x *FinalizedPaymentRequest) GetPaymentStatus() PaymentStatus {
+func (x *OrganizationStatusChange) GetActor() string {
 	if x != nil {
-		return x.PaymentStatus
+		return x.Actor
 	}
-	return PaymentStatus_PAYMENT_STATUS_UNSPECIFIED
+	return ""
 }
 
-type CreateWithdrawRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AccountUUID   string                 `protobuf:"bytes,1,opt,name=AccountUUID,proto3" json:"AccountUUID,omitempty"`
-	Amount        int64                  `protobuf:"varint,2,opt,name=Amount,proto3" json:"Amount,omitempty"`
+func (x *OrganizationStatusChange) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type OrganizationStatusHistory struct {
+	state         protoimpl.MessageState      `protogen:"open.v1"`
+	Changes       []*OrganizationStatusChange `protobuf:"bytes,1,rep,name=Changes,proto3" json:"Changes,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateWithdrawRequest) Reset() {
-	*x = CreateWithdrawRequest{}
-	mi := &file_operation_paystore_proto_msgTypes[3]
+func (x *OrganizationStatusHistory) Reset() {
+	*x = OrganizationStatusHistory{}
+	mi := &file_operation_paystore_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateWithdrawRequest) String() string {
+func (x *OrganizationStatusHistory) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateWithdrawRequest) ProtoMessage() {}
+func (*OrganizationStatusHistory) ProtoMessage() {}
 
-func (x *CreateWithdrawRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_operation_paystore_proto_msgTypes[3]
+func (x *OrganizationStatusHistory) ProtoReflect() protoreflect.Message {
+	mi := &file_operation_paystore_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -286,50 +4244,44 @@ func (x *CreateWithdrawRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateWithdrawRequest.ProtoReflect.Descriptor instead.
-func (*CreateWithdrawRequest) Descriptor() ([]byte, []int) {
-	return file_operation_paystore_proto_rawDescGZIP(), []int{3}
-}
-
-func (x *CreateWithdrawRequest) GetAccountUUID() string {
-	if x != nil {
-		return x.AccountUUID
-	}
-	return ""
+// Deprecated: Use OrganizationStatusHistory.ProtoReflect.Descriptor instead.
+func (*OrganizationStatusHistory) Descriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{49}
 }
 
-func (x *CreateWithdrawRequest) GetAmount() int64 {
+func (x *OrganizationStatusHistory) GetChanges() []*OrganizationStatusChange {
 	if x != nil {
-		return x.Amount
+		return x.Changes
 	}
-	return 0
+	return nil
 }
 
-type FinalizedWithdrawRequest struct {
+// SplitPaymentResponse carries the invoice of the parent payment, which is the first of Payments.
+type SplitPaymentResponse struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
-	AccountUUId    string                 `protobuf:"bytes,1,opt,name=AccountUUId,proto3" json:"AccountUUId,omitempty"`
-	WithdrawUUID   string                 `protobuf:"bytes,2,opt,name=WithdrawUUID,proto3" json:"WithdrawUUID,omitempty"`
-	VendorRecordId string                 `protobuf:"bytes,3,opt,name=VendorRecordId,proto3" json:"VendorRecordId,omitempty"`
-	WithdrawStatus PaymentStatus          `protobuf:"varint,4,opt,name=WithdrawStatus,proto3,enum=paystore.PaymentStatus" json:"WithdrawStatus,omitempty"`
+	ID             string                 `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	VendorRecordID string                 `protobuf:"bytes,2,opt,name=VendorRecordID,proto3" json:"VendorRecordID,omitempty"`
+	InvoiceURL     string                 `protobuf:"bytes,3,opt,name=InvoiceURL,proto3" json:"InvoiceURL,omitempty"`
+	Payments       []*Payment             `protobuf:"bytes,4,rep,name=Payments,proto3" json:"Payments,omitempty"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
 
-func (x *FinalizedWithdrawRequest) Reset() {
-	*x = FinalizedWithdrawRequest{}
-	mi := &file_operation_paystore_proto_msgTypes[4]
+func (x *SplitPaymentResponse) Reset() {
+	*x = SplitPaymentResponse{}
+	mi := &file_operation_paystore_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FinalizedWithdrawRequest) String() string {
+func (x *SplitPaymentResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FinalizedWithdrawRequest) ProtoMessage() {}
+func (*SplitPaymentResponse) ProtoMessage() {}
 
-func (x *FinalizedWithdrawRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_operation_paystore_proto_msgTypes[4]
+func (x *SplitPaymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_operation_paystore_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -340,49 +4292,51 @@ func (x *FinalizedWithdrawRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FinalizedWithdrawRequest.ProtoReflect.Descriptor instead.
-func (*FinalizedWithdrawRequest) Descriptor() ([]byte, []int) {
-	return file_operation_paystore_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use SplitPaymentResponse.ProtoReflect.Descriptor instead.
+func (*SplitPaymentResponse) Descriptor() ([]byte, []int) {
+	return file_operation_paystore_proto_rawDescGZIP(), []int{50}
 }
 
-func (x *FinalizedWithdrawRequest) GetAccountUUId() string {
+func (x *SplitPaymentResponse) GetID() string {
 	if x != nil {
-		return x.AccountUUId
+		return x.ID
 	}
 	return ""
 }
 
-func (x *FinalizedWithdrawRequest) GetWithdrawUUID() string {
+func (x *SplitPaymentResponse) GetVendorRecordID() string {
 	if x != nil {
-		return x.WithdrawUUID
+		return x.VendorRecordID
 	}
 	return ""
 }
 
-func (x *FinalizedWithdrawRequest) GetVendorRecordId() string {
+func (x *SplitPaymentResponse) GetInvoiceURL() string {
 	if x != nil {
-		return x.VendorRecordId
+		return x.InvoiceURL
 	}
 	return ""
 }
 
-func (x *FinalizedWithdrawRequest) GetWithdrawStatus() PaymentStatus {
+func (x *SplitPaymentResponse) GetPayments() []*Payment {
 	if x != nil {
-		return x.WithdrawStatus
+		return x.Payments
 	}
-	return PaymentStatus_PAYMENT_STATUS_UNSPECIFIED
+	return nil
 }
 
 type CreatedResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ID            string                 `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ID             string                 `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	VendorRecordID string                 `protobuf:"bytes,2,opt,name=VendorRecordID,proto3" json:"VendorRecordID,omitempty"`
+	InvoiceURL     string                 `protobuf:"bytes,3,opt,name=InvoiceURL,proto3" json:"InvoiceURL,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *CreatedResponse) Reset() {
 	*x = CreatedResponse{}
-	mi := &file_operation_paystore_proto_msgTypes[5]
+	mi := &file_operation_paystore_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -394,7 +4348,7 @@ func (x *CreatedResponse) String() string {
 func (*CreatedResponse) ProtoMessage() {}
 
 func (x *CreatedResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_operation_paystore_proto_msgTypes[5]
+	mi := &file_operation_paystore_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -407,7 +4361,7 @@ func (x *CreatedResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreatedResponse.ProtoReflect.Descriptor instead.
 func (*CreatedResponse) Descriptor() ([]byte, []int) {
-	return file_operation_paystore_proto_rawDescGZIP(), []int{5}
+	return file_operation_paystore_proto_rawDescGZIP(), []int{51}
 }
 
 func (x *CreatedResponse) GetID() string {
@@ -417,6 +4371,20 @@ func (x *CreatedResponse) GetID() string {
 	return ""
 }
 
+func (x *CreatedResponse) GetVendorRecordID() string {
+	if x != nil {
+		return x.VendorRecordID
+	}
+	return ""
+}
+
+func (x *CreatedResponse) GetInvoiceURL() string {
+	if x != nil {
+		return x.InvoiceURL
+	}
+	return ""
+}
+
 type EmptyResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -425,7 +4393,7 @@ type EmptyResponse struct {
 
 func (x *EmptyResponse) Reset() {
 	*x = EmptyResponse{}
-	mi := &file_operation_paystore_proto_msgTypes[6]
+	mi := &file_operation_paystore_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -437,7 +4405,7 @@ func (x *EmptyResponse) String() string {
 func (*EmptyResponse) ProtoMessage() {}
 
 func (x *EmptyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_operation_paystore_proto_msgTypes[6]
+	mi := &file_operation_paystore_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -450,50 +4418,459 @@ func (x *EmptyResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EmptyResponse.ProtoReflect.Descriptor instead.
 func (*EmptyResponse) Descriptor() ([]byte, []int) {
-	return file_operation_paystore_proto_rawDescGZIP(), []int{6}
+	return file_operation_paystore_proto_rawDescGZIP(), []int{52}
 }
 
 var File_operation_paystore_proto protoreflect.FileDescriptor
 
 const file_operation_paystore_proto_rawDesc = "" +
 	"\n" +
-	"\x18operation/paystore.proto\x12\bpaystore\"~\n" +
+	"\x18operation/paystore.proto\x12\bpaystore\x1a\x1fgoogle/protobuf/timestamp.proto\"~\n" +
 	"\x14CreateBalanceRequest\x12\x1e\n" +
 	"\n" +
 	"ExternalID\x18\x01 \x01(\tR\n" +
 	"ExternalID\x12*\n" +
 	"\x10OrganizationSlug\x18\x02 \x01(\tR\x10OrganizationSlug\x12\x1a\n" +
-	"\bCurrency\x18\x03 \x01(\tR\bCurrency\"P\n" +
+	"\bCurrency\x18\x03 \x01(\tR\bCurrency\"\x8a\x01\n" +
 	"\x14CreatePaymentRequest\x12 \n" +
 	"\vAccountUUID\x18\x01 \x01(\tR\vAccountUUID\x12\x16\n" +
-	"\x06Amount\x18\x02 \x01(\x03R\x06Amount\"\xc4\x01\n" +
+	"\x06Amount\x18\x02 \x01(\x03R\x06Amount\x12\x1e\n" +
+	"\n" +
+	"VendorCode\x18\x03 \x01(\tR\n" +
+	"VendorCode\x12\x18\n" +
+	"\aChannel\x18\x04 \x01(\tR\aChannel\"f\n" +
+	"\fPaymentShare\x12 \n" +
+	"\vBalanceUUID\x18\x01 \x01(\tR\vBalanceUUID\x12\x14\n" +
+	"\x05Fixed\x18\x02 \x01(\x03R\x05Fixed\x12\x1e\n" +
+	"\n" +
+	"PercentBps\x18\x03 \x01(\x03R\n" +
+	"PercentBps\"\x9d\x01\n" +
+	"\x19CreateSplitPaymentRequest\x12\x16\n" +
+	"\x06Amount\x18\x01 \x01(\x03R\x06Amount\x12.\n" +
+	"\x06Shares\x18\x02 \x03(\v2\x16.paystore.PaymentShareR\x06Shares\x12\x1e\n" +
+	"\n" +
+	"VendorCode\x18\x03 \x01(\tR\n" +
+	"VendorCode\x12\x18\n" +
+	"\aChannel\x18\x04 \x01(\tR\aChannel\"\x8a\x02\n" +
 	"\x17FinalizedPaymentRequest\x12 \n" +
 	"\vAccountUUID\x18\x01 \x01(\tR\vAccountUUID\x12 \n" +
 	"\vPaymentUUID\x18\x02 \x01(\tR\vPaymentUUID\x12&\n" +
 	"\x0eVendorRecordId\x18\x03 \x01(\tR\x0eVendorRecordId\x12=\n" +
-	"\rPaymentStatus\x18\x04 \x01(\x0e2\x17.paystore.PaymentStatusR\rPaymentStatus\"Q\n" +
+	"\rPaymentStatus\x18\x04 \x01(\x0e2\x17.paystore.PaymentStatusR\rPaymentStatus\x12$\n" +
+	"\rVendorPayload\x18\x05 \x01(\fR\rVendorPayload\x12\x1e\n" +
+	"\n" +
+	"VendorCode\x18\x06 \x01(\tR\n" +
+	"VendorCode\"\xe7\x01\n" +
 	"\x15CreateWithdrawRequest\x12 \n" +
 	"\vAccountUUID\x18\x01 \x01(\tR\vAccountUUID\x12\x16\n" +
-	"\x06Amount\x18\x02 \x01(\x03R\x06Amount\"\xc9\x01\n" +
+	"\x06Amount\x18\x02 \x01(\x03R\x06Amount\x12 \n" +
+	"\vChannelCode\x18\x03 \x01(\tR\vChannelCode\x12$\n" +
+	"\rAccountNumber\x18\x04 \x01(\tR\rAccountNumber\x12,\n" +
+	"\x11AccountHolderName\x18\x05 \x01(\tR\x11AccountHolderName\x12\x1e\n" +
+	"\n" +
+	"VendorCode\x18\x06 \x01(\tR\n" +
+	"VendorCode\"\x8f\x02\n" +
 	"\x18FinalizedWithdrawRequest\x12 \n" +
 	"\vAccountUUId\x18\x01 \x01(\tR\vAccountUUId\x12\"\n" +
 	"\fWithdrawUUID\x18\x02 \x01(\tR\fWithdrawUUID\x12&\n" +
 	"\x0eVendorRecordId\x18\x03 \x01(\tR\x0eVendorRecordId\x12?\n" +
-	"\x0eWithdrawStatus\x18\x04 \x01(\x0e2\x17.paystore.PaymentStatusR\x0eWithdrawStatus\"!\n" +
+	"\x0eWithdrawStatus\x18\x04 \x01(\x0e2\x17.paystore.PaymentStatusR\x0eWithdrawStatus\x12$\n" +
+	"\rVendorPayload\x18\x05 \x01(\fR\rVendorPayload\x12\x1e\n" +
+	"\n" +
+	"VendorCode\x18\x06 \x01(\tR\n" +
+	"VendorCode\"\xc4\x03\n" +
+	"\x15SearchPaymentsRequest\x12*\n" +
+	"\x10OrganizationUUID\x18\x01 \x01(\tR\x10OrganizationUUID\x12 \n" +
+	"\vBalanceUUID\x18\x02 \x01(\tR\vBalanceUUID\x123\n" +
+	"\bStatuses\x18\x03 \x03(\x0e2\x17.paystore.PaymentStatusR\bStatuses\x12\x1c\n" +
+	"\tMinAmount\x18\x04 \x01(\x03R\tMinAmount\x12\x1c\n" +
+	"\tMaxAmount\x18\x05 \x01(\x03R\tMaxAmount\x12<\n" +
+	"\vCreatedFrom\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vCreatedFrom\x128\n" +
+	"\tCreatedTo\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tCreatedTo\x12&\n" +
+	"\x0eVendorRecordID\x18\b \x01(\tR\x0eVendorRecordID\x12\x16\n" +
+	"\x06Cursor\x18\t \x01(\tR\x06Cursor\x12\x14\n" +
+	"\x05Limit\x18\n" +
+	" \x01(\x03R\x05Limit\x12\x1e\n" +
+	"\n" +
+	"VendorCode\x18\v \x01(\tR\n" +
+	"VendorCode\"g\n" +
+	"\x16SearchPaymentsResponse\x12-\n" +
+	"\bPayments\x18\x01 \x03(\v2\x11.paystore.PaymentR\bPayments\x12\x1e\n" +
+	"\n" +
+	"NextCursor\x18\x02 \x01(\tR\n" +
+	"NextCursor\"\xc5\x03\n" +
+	"\x16SearchWithdrawsRequest\x12*\n" +
+	"\x10OrganizationUUID\x18\x01 \x01(\tR\x10OrganizationUUID\x12 \n" +
+	"\vBalanceUUID\x18\x02 \x01(\tR\vBalanceUUID\x123\n" +
+	"\bStatuses\x18\x03 \x03(\x0e2\x17.paystore.PaymentStatusR\bStatuses\x12\x1c\n" +
+	"\tMinAmount\x18\x04 \x01(\x03R\tMinAmount\x12\x1c\n" +
+	"\tMaxAmount\x18\x05 \x01(\x03R\tMaxAmount\x12<\n" +
+	"\vCreatedFrom\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vCreatedFrom\x128\n" +
+	"\tCreatedTo\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tCreatedTo\x12&\n" +
+	"\x0eVendorRecordID\x18\b \x01(\tR\x0eVendorRecordID\x12\x16\n" +
+	"\x06Cursor\x18\t \x01(\tR\x06Cursor\x12\x14\n" +
+	"\x05Limit\x18\n" +
+	" \x01(\x03R\x05Limit\x12\x1e\n" +
+	"\n" +
+	"VendorCode\x18\v \x01(\tR\n" +
+	"VendorCode\"k\n" +
+	"\x17SearchWithdrawsResponse\x120\n" +
+	"\tWithdraws\x18\x01 \x03(\v2\x12.paystore.WithdrawR\tWithdraws\x12\x1e\n" +
+	"\n" +
+	"NextCursor\x18\x02 \x01(\tR\n" +
+	"NextCursor\"\xc4\x05\n" +
+	"\aPayment\x12\x12\n" +
+	"\x04UUID\x18\x01 \x01(\tR\x04UUID\x12\x16\n" +
+	"\x06RandId\x18\x02 \x01(\tR\x06RandId\x128\n" +
+	"\tCreatedAt\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tCreatedAt\x128\n" +
+	"\tUpdatedAt\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tUpdatedAt\x12\x16\n" +
+	"\x06Amount\x18\x05 \x01(\x03R\x06Amount\x12\x12\n" +
+	"\x04Fees\x18\x06 \x01(\x03R\x04Fees\x122\n" +
+	"\x14BalanceBeforePayment\x18\a \x01(\x03R\x14BalanceBeforePayment\x120\n" +
+	"\x13BalanceAfterPayment\x18\b \x01(\x03R\x13BalanceAfterPayment\x12 \n" +
+	"\vBalanceUUID\x18\t \x01(\tR\vBalanceUUID\x12*\n" +
+	"\x10OrganizationUUID\x18\n" +
+	" \x01(\tR\x10OrganizationUUID\x12&\n" +
+	"\x0eVendorRecordID\x18\v \x01(\tR\x0eVendorRecordID\x12/\n" +
+	"\x06Status\x18\f \x01(\x0e2\x17.paystore.PaymentStatusR\x06Status\x12\x12\n" +
+	"\x04Hash\x18\r \x01(\tR\x04Hash\x12\x1e\n" +
+	"\n" +
+	"VendorCode\x18\x0e \x01(\tR\n" +
+	"VendorCode\x12\x18\n" +
+	"\aChannel\x18\x0f \x01(\tR\aChannel\x12:\n" +
+	"\fFeeBreakdown\x18\x10 \x01(\v2\x16.paystore.FeeBreakdownR\fFeeBreakdown\x12(\n" +
+	"\x0fFeeScheduleUUID\x18\x11 \x01(\tR\x0fFeeScheduleUUID\x12,\n" +
+	"\x11ParentPaymentUUID\x18\x12 \x01(\tR\x11ParentPaymentUUID\"\xfb\x02\n" +
+	"\fFeeBreakdown\x12\"\n" +
+	"\fScheduleUUID\x18\x01 \x01(\tR\fScheduleUUID\x12\x18\n" +
+	"\aChannel\x18\x02 \x01(\tR\aChannel\x12\x1e\n" +
+	"\n" +
+	"PercentBps\x18\x03 \x01(\x03R\n" +
+	"PercentBps\x12\x1e\n" +
+	"\n" +
+	"PercentFee\x18\x04 \x01(\x03R\n" +
+	"PercentFee\x12\x1a\n" +
+	"\bFixedFee\x18\x05 \x01(\x03R\bFixedFee\x12\x1e\n" +
+	"\n" +
+	"Adjustment\x18\x06 \x01(\x03R\n" +
+	"Adjustment\x12\x14\n" +
+	"\x05Total\x18\a \x01(\x03R\x05Total\x12$\n" +
+	"\rRateNumerator\x18\b \x01(\x03R\rRateNumerator\x12(\n" +
+	"\x0fRateDenominator\x18\t \x01(\x03R\x0fRateDenominator\x121\n" +
+	"\bRounding\x18\n" +
+	" \x01(\x0e2\x15.paystore.FeeRoundingR\bRounding\x12\x18\n" +
+	"\aResidue\x18\v \x01(\x03R\aResidue\"\xf1\x04\n" +
+	"\bWithdraw\x12\x12\n" +
+	"\x04UUID\x18\x01 \x01(\tR\x04UUID\x12\x16\n" +
+	"\x06RandId\x18\x02 \x01(\tR\x06RandId\x128\n" +
+	"\tCreatedAt\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tCreatedAt\x128\n" +
+	"\tUpdatedAt\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tUpdatedAt\x12\x16\n" +
+	"\x06Amount\x18\x05 \x01(\x03R\x06Amount\x124\n" +
+	"\x15BalanceBeforeWithdraw\x18\x06 \x01(\x03R\x15BalanceBeforeWithdraw\x122\n" +
+	"\x14BalanceAfterWithdraw\x18\a \x01(\x03R\x14BalanceAfterWithdraw\x12 \n" +
+	"\vBalanceUUID\x18\b \x01(\tR\vBalanceUUID\x12*\n" +
+	"\x10OrganizationUUID\x18\t \x01(\tR\x10OrganizationUUID\x12&\n" +
+	"\x0eVendorRecordID\x18\n" +
+	" \x01(\tR\x0eVendorRecordID\x12/\n" +
+	"\x06Status\x18\v \x01(\x0e2\x17.paystore.PaymentStatusR\x06Status\x12\x12\n" +
+	"\x04Hash\x18\f \x01(\tR\x04Hash\x12\x1e\n" +
+	"\n" +
+	"VendorCode\x18\r \x01(\tR\n" +
+	"VendorCode\x12\x12\n" +
+	"\x04Fees\x18\x0e \x01(\x03R\x04Fees\x12\x18\n" +
+	"\aChannel\x18\x0f \x01(\tR\aChannel\x12:\n" +
+	"\fFeeBreakdown\x18\x10 \x01(\v2\x16.paystore.FeeBreakdownR\fFeeBreakdown\"\xe7\x01\n" +
+	"\x18GenerateStatementRequest\x12 \n" +
+	"\vBalanceUUID\x18\x01 \x01(\tR\vBalanceUUID\x12<\n" +
+	"\vPeriodStart\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\vPeriodStart\x128\n" +
+	"\tPeriodEnd\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tPeriodEnd\x121\n" +
+	"\x06Format\x18\x04 \x01(\x0e2\x19.paystore.StatementFormatR\x06Format\"o\n" +
+	"\x11StatementResponse\x12 \n" +
+	"\vContentType\x18\x01 \x01(\tR\vContentType\x12\x18\n" +
+	"\aContent\x18\x02 \x01(\fR\aContent\x12\x1e\n" +
+	"\n" +
+	"Consistent\x18\x03 \x01(\bR\n" +
+	"Consistent\"\xe0\x01\n" +
+	"\x10ReconcileRequest\x120\n" +
+	"\x04Kind\x18\x01 \x01(\x0e2\x1c.paystore.ReconciliationKindR\x04Kind\x12<\n" +
+	"\vPeriodStart\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\vPeriodStart\x128\n" +
+	"\tPeriodEnd\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tPeriodEnd\x12\"\n" +
+	"\fAutoFinalize\x18\x04 \x01(\bR\fAutoFinalize\"x\n" +
+	"\x1eGetReconciliationReportRequest\x12\x1e\n" +
+	"\n" +
+	"ReportUUID\x18\x01 \x01(\tR\n" +
+	"ReportUUID\x126\n" +
+	"\x06Result\x18\x02 \x01(\x0e2\x1e.paystore.ReconciliationResultR\x06Result\"\xac\x04\n" +
+	"\x14ReconciliationReport\x12\x12\n" +
+	"\x04UUID\x18\x01 \x01(\tR\x04UUID\x120\n" +
+	"\x04Kind\x18\x02 \x01(\x0e2\x1c.paystore.ReconciliationKindR\x04Kind\x12<\n" +
+	"\vPeriodStart\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\vPeriodStart\x128\n" +
+	"\tPeriodEnd\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tPeriodEnd\x12\"\n" +
+	"\fMatchedCount\x18\x05 \x01(\x03R\fMatchedCount\x120\n" +
+	"\x13AmountMismatchCount\x18\x06 \x01(\x03R\x13AmountMismatchCount\x120\n" +
+	"\x13StatusMismatchCount\x18\a \x01(\x03R\x13StatusMismatchCount\x126\n" +
+	"\x16MissingInPaystoreCount\x18\b \x01(\x03R\x16MissingInPaystoreCount\x122\n" +
+	"\x14MissingAtVendorCount\x18\t \x01(\x03R\x14MissingAtVendorCount\x12.\n" +
+	"\x12AutoFinalizedCount\x18\n" +
+	" \x01(\x03R\x12AutoFinalizedCount\x122\n" +
+	"\x05Items\x18\v \x03(\v2\x1c.paystore.ReconciliationItemR\x05Items\"\xa0\x04\n" +
+	"\x12ReconciliationItem\x126\n" +
+	"\x06Result\x18\x01 \x01(\x0e2\x1e.paystore.ReconciliationResultR\x06Result\x12\x1e\n" +
+	"\n" +
+	"RecordUUID\x18\x02 \x01(\tR\n" +
+	"RecordUUID\x12 \n" +
+	"\vBalanceUUID\x18\x03 \x01(\tR\vBalanceUUID\x12&\n" +
+	"\x0eVendorRecordID\x18\x04 \x01(\tR\x0eVendorRecordID\x12&\n" +
+	"\x0ePaystoreAmount\x18\x05 \x01(\x03R\x0ePaystoreAmount\x12\"\n" +
+	"\fVendorAmount\x18\x06 \x01(\x03R\fVendorAmount\x12&\n" +
+	"\x0ePaystoreStatus\x18\a \x01(\tR\x0ePaystoreStatus\x12\"\n" +
+	"\fVendorStatus\x18\b \x01(\tR\fVendorStatus\x12(\n" +
+	"\x0fVendorRawStatus\x18\t \x01(\tR\x0fVendorRawStatus\x12*\n" +
+	"\x10PaystoreCurrency\x18\n" +
+	" \x01(\tR\x10PaystoreCurrency\x12&\n" +
+	"\x0eVendorCurrency\x18\v \x01(\tR\x0eVendorCurrency\x12,\n" +
+	"\x11VendorFailureCode\x18\f \x01(\tR\x11VendorFailureCode\x12$\n" +
+	"\rAutoFinalized\x18\r \x01(\bR\rAutoFinalized\"~\n" +
+	"\x1eRegisterWebhookEndpointRequest\x12*\n" +
+	"\x10OrganizationUUID\x18\x01 \x01(\tR\x10OrganizationUUID\x12\x10\n" +
+	"\x03URL\x18\x02 \x01(\tR\x03URL\x12\x1e\n" +
+	"\n" +
+	"EventTypes\x18\x03 \x03(\tR\n" +
+	"EventTypes\"U\n" +
+	"\x17WebhookEndpointResponse\x12\"\n" +
+	"\fEndpointUUID\x18\x01 \x01(\tR\fEndpointUUID\x12\x16\n" +
+	"\x06Secret\x18\x02 \x01(\tR\x06Secret\"n\n" +
+	"\x1cRemoveWebhookEndpointRequest\x12*\n" +
+	"\x10OrganizationUUID\x18\x01 \x01(\tR\x10OrganizationUUID\x12\"\n" +
+	"\fEndpointUUID\x18\x02 \x01(\tR\fEndpointUUID\"H\n" +
+	"\x1aRotateWebhookSecretRequest\x12*\n" +
+	"\x10OrganizationUUID\x18\x01 \x01(\tR\x10OrganizationUUID\"/\n" +
+	"\x15WebhookSecretResponse\x12\x16\n" +
+	"\x06Secret\x18\x01 \x01(\tR\x06Secret\"n\n" +
+	"\x1cReplayWebhookDeliveryRequest\x12*\n" +
+	"\x10OrganizationUUID\x18\x01 \x01(\tR\x10OrganizationUUID\x12\"\n" +
+	"\fDeliveryUUID\x18\x02 \x01(\tR\fDeliveryUUID\"\xcd\x01\n" +
+	"\aFeeRule\x12\x18\n" +
+	"\aChannel\x18\x01 \x01(\tR\aChannel\x12\x1e\n" +
+	"\n" +
+	"PercentBps\x18\x02 \x01(\x03R\n" +
+	"PercentBps\x12\x14\n" +
+	"\x05Fixed\x18\x03 \x01(\x03R\x05Fixed\x12\x10\n" +
+	"\x03Min\x18\x04 \x01(\x03R\x03Min\x12\x10\n" +
+	"\x03Max\x18\x05 \x01(\x03R\x03Max\x12$\n" +
+	"\rRateNumerator\x18\x06 \x01(\x03R\rRateNumerator\x12(\n" +
+	"\x0fRateDenominator\x18\a \x01(\x03R\x0fRateDenominator\"\xdd\x01\n" +
+	"\x15SetFeeScheduleRequest\x12*\n" +
+	"\x10OrganizationUUID\x18\x01 \x01(\tR\x10OrganizationUUID\x12'\n" +
+	"\x05Rules\x18\x02 \x03(\v2\x11.paystore.FeeRuleR\x05Rules\x12-\n" +
+	"\x04Kind\x18\x03 \x01(\x0e2\x19.paystore.FeeScheduleKindR\x04Kind\x12@\n" +
+	"\rEffectiveFrom\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\rEffectiveFrom\"\x9e\x01\n" +
+	"\x15GetFeeScheduleRequest\x12*\n" +
+	"\x10OrganizationUUID\x18\x01 \x01(\tR\x10OrganizationUUID\x12-\n" +
+	"\x04Kind\x18\x02 \x01(\x0e2\x19.paystore.FeeScheduleKindR\x04Kind\x12*\n" +
+	"\x02At\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x02At\"t\n" +
+	"\x17ListFeeSchedulesRequest\x12*\n" +
+	"\x10OrganizationUUID\x18\x01 \x01(\tR\x10OrganizationUUID\x12-\n" +
+	"\x04Kind\x18\x02 \x01(\x0e2\x19.paystore.FeeScheduleKindR\x04Kind\"\xe8\x02\n" +
+	"\vFeeSchedule\x12\"\n" +
+	"\fScheduleUUID\x18\x01 \x01(\tR\fScheduleUUID\x12*\n" +
+	"\x10OrganizationUUID\x18\x02 \x01(\tR\x10OrganizationUUID\x12'\n" +
+	"\x05Rules\x18\x03 \x03(\v2\x11.paystore.FeeRuleR\x05Rules\x12-\n" +
+	"\x04Kind\x18\x04 \x01(\x0e2\x19.paystore.FeeScheduleKindR\x04Kind\x121\n" +
+	"\bRounding\x18\x05 \x01(\x0e2\x15.paystore.FeeRoundingR\bRounding\x12@\n" +
+	"\rEffectiveFrom\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\rEffectiveFrom\x12<\n" +
+	"\vEffectiveTo\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\vEffectiveTo\"C\n" +
+	"\fFeeSchedules\x123\n" +
+	"\tSchedules\x18\x01 \x03(\v2\x15.paystore.FeeScheduleR\tSchedules\"v\n" +
+	"\x15SetFeeRoundingRequest\x12*\n" +
+	"\x10OrganizationUUID\x18\x01 \x01(\tR\x10OrganizationUUID\x121\n" +
+	"\bRounding\x18\x02 \x01(\x0e2\x15.paystore.FeeRoundingR\bRounding\"b\n" +
+	"\x18GetRevenueBalanceRequest\x12*\n" +
+	"\x10OrganizationUUID\x18\x01 \x01(\tR\x10OrganizationUUID\x12\x1a\n" +
+	"\bCurrency\x18\x02 \x01(\tR\bCurrency\"\xf8\x01\n" +
+	"\x0eRevenueBalance\x12 \n" +
+	"\vBalanceUUID\x18\x01 \x01(\tR\vBalanceUUID\x12*\n" +
+	"\x10OrganizationUUID\x18\x02 \x01(\tR\x10OrganizationUUID\x12\x1a\n" +
+	"\bCurrency\x18\x03 \x01(\tR\bCurrency\x12\x18\n" +
+	"\aBalance\x18\x04 \x01(\x03R\aBalance\x12.\n" +
+	"\x12IncomeAccumulation\x18\x05 \x01(\x03R\x12IncomeAccumulation\x122\n" +
+	"\x14WithdrawAccumulation\x18\x06 \x01(\x03R\x14WithdrawAccumulation\"\xf3\x02\n" +
+	"\x05Limit\x12\x12\n" +
+	"\x04UUID\x18\x01 \x01(\tR\x04UUID\x12*\n" +
+	"\x05Scope\x18\x02 \x01(\x0e2\x14.paystore.LimitScopeR\x05Scope\x12\x1c\n" +
+	"\tScopeUUID\x18\x03 \x01(\tR\tScopeUUID\x12\x1e\n" +
+	"\n" +
+	"MaxPayment\x18\x04 \x01(\x03R\n" +
+	"MaxPayment\x12 \n" +
+	"\vMaxWithdraw\x18\x05 \x01(\x03R\vMaxWithdraw\x12\"\n" +
+	"\fDailyPayment\x18\x06 \x01(\x03R\fDailyPayment\x12&\n" +
+	"\x0eMonthlyPayment\x18\a \x01(\x03R\x0eMonthlyPayment\x12$\n" +
+	"\rDailyWithdraw\x18\b \x01(\x03R\rDailyWithdraw\x12(\n" +
+	"\x0fMonthlyWithdraw\x18\t \x01(\x03R\x0fMonthlyWithdraw\x12.\n" +
+	"\x12DailyWithdrawCount\x18\n" +
+	" \x01(\x03R\x12DailyWithdrawCount\"\xe9\x02\n" +
+	"\x0fSetLimitRequest\x12*\n" +
+	"\x05Scope\x18\x01 \x01(\x0e2\x14.paystore.LimitScopeR\x05Scope\x12\x1c\n" +
+	"\tScopeUUID\x18\x02 \x01(\tR\tScopeUUID\x12\x1e\n" +
+	"\n" +
+	"MaxPayment\x18\x03 \x01(\x03R\n" +
+	"MaxPayment\x12 \n" +
+	"\vMaxWithdraw\x18\x04 \x01(\x03R\vMaxWithdraw\x12\"\n" +
+	"\fDailyPayment\x18\x05 \x01(\x03R\fDailyPayment\x12&\n" +
+	"\x0eMonthlyPayment\x18\x06 \x01(\x03R\x0eMonthlyPayment\x12$\n" +
+	"\rDailyWithdraw\x18\a \x01(\x03R\rDailyWithdraw\x12(\n" +
+	"\x0fMonthlyWithdraw\x18\b \x01(\x03R\x0fMonthlyWithdraw\x12.\n" +
+	"\x12DailyWithdrawCount\x18\t \x01(\x03R\x12DailyWithdrawCount\"[\n" +
+	"\x0fGetLimitRequest\x12*\n" +
+	"\x05Scope\x18\x01 \x01(\x0e2\x14.paystore.LimitScopeR\x05Scope\x12\x1c\n" +
+	"\tScopeUUID\x18\x02 \x01(\tR\tScopeUUID\"j\n" +
+	"\x1cSetOrganizationParentRequest\x12*\n" +
+	"\x10OrganizationUUID\x18\x01 \x01(\tR\x10OrganizationUUID\x12\x1e\n" +
+	"\n" +
+	"ParentUUID\x18\x02 \x01(\tR\n" +
+	"ParentUUID\"E\n" +
+	"\x17GetBalanceRollupRequest\x12*\n" +
+	"\x10OrganizationUUID\x18\x01 \x01(\tR\x10OrganizationUUID\"\xb8\x02\n" +
+	"\rBalanceRollup\x12*\n" +
+	"\x10OrganizationUUID\x18\x01 \x01(\tR\x10OrganizationUUID\x12\x1a\n" +
+	"\bCurrency\x18\x02 \x01(\tR\bCurrency\x12\"\n" +
+	"\fBalanceCount\x18\x03 \x01(\x03R\fBalanceCount\x12\x18\n" +
+	"\aBalance\x18\x04 \x01(\x03R\aBalance\x12.\n" +
+	"\x12IncomeAccumulation\x18\x05 \x01(\x03R\x12IncomeAccumulation\x122\n" +
+	"\x14WithdrawAccumulation\x18\x06 \x01(\x03R\x14WithdrawAccumulation\x12=\n" +
+	"\rOrganizations\x18\a \x03(\v2\x17.paystore.BalanceRollupR\rOrganizations\"J\n" +
+	"\x15BalanceRollupResponse\x121\n" +
+	"\aRollups\x18\x01 \x03(\v2\x17.paystore.BalanceRollupR\aRollups\".\n" +
+	"\x1aRecomputeSettlementRequest\x12\x10\n" +
+	"\x03Day\x18\x01 \x01(\tR\x03Day\"\x89\x01\n" +
+	"\x1bGetSettlementReportsRequest\x12*\n" +
+	"\x10OrganizationUUID\x18\x01 \x01(\tR\x10OrganizationUUID\x12\x1a\n" +
+	"\bCurrency\x18\x02 \x01(\tR\bCurrency\x12\x12\n" +
+	"\x04From\x18\x03 \x01(\tR\x04From\x12\x0e\n" +
+	"\x02To\x18\x04 \x01(\tR\x02To\"\xac\x04\n" +
+	"\x10SettlementReport\x12\x12\n" +
+	"\x04UUID\x18\x01 \x01(\tR\x04UUID\x12*\n" +
+	"\x10OrganizationUUID\x18\x02 \x01(\tR\x10OrganizationUUID\x12\x1a\n" +
+	"\bCurrency\x18\x03 \x01(\tR\bCurrency\x12\x10\n" +
+	"\x03Day\x18\x04 \x01(\tR\x03Day\x12&\n" +
+	"\x0eGrossCollected\x18\x05 \x01(\x03R\x0eGrossCollected\x12\x12\n" +
+	"\x04Fees\x18\x06 \x01(\x03R\x04Fees\x12 \n" +
+	"\vNetCredited\x18\a \x01(\x03R\vNetCredited\x12\x1c\n" +
+	"\tPaidCount\x18\b \x01(\x03R\tPaidCount\x12.\n" +
+	"\x12FailedPaymentCount\x18\t \x01(\x03R\x12FailedPaymentCount\x12\x1c\n" +
+	"\tWithdrawn\x18\n" +
+	" \x01(\x03R\tWithdrawn\x12\"\n" +
+	"\fWithdrawFees\x18\v \x01(\x03R\fWithdrawFees\x12$\n" +
+	"\rWithdrawCount\x18\f \x01(\x03R\rWithdrawCount\x120\n" +
+	"\x13FailedWithdrawCount\x18\r \x01(\x03R\x13FailedWithdrawCount\x12*\n" +
+	"\x10ClosingLiability\x18\x0e \x01(\x03R\x10ClosingLiability\x128\n" +
+	"\tUpdatedAt\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tUpdatedAt\"I\n" +
+	"\x11SettlementReports\x124\n" +
+	"\aReports\x18\x01 \x03(\v2\x1a.paystore.SettlementReportR\aReports\"\xae\x01\n" +
+	"\x1cSetOrganizationStatusRequest\x12*\n" +
+	"\x10OrganizationUUID\x18\x01 \x01(\tR\x10OrganizationUUID\x124\n" +
+	"\x06Status\x18\x02 \x01(\x0e2\x1c.paystore.OrganizationStatusR\x06Status\x12\x16\n" +
+	"\x06Reason\x18\x03 \x01(\tR\x06Reason\x12\x14\n" +
+	"\x05Actor\x18\x04 \x01(\tR\x05Actor\"Q\n" +
+	"#GetOrganizationStatusHistoryRequest\x12*\n" +
+	"\x10OrganizationUUID\x18\x01 \x01(\tR\x10OrganizationUUID\"\xba\x02\n" +
+	"\x18OrganizationStatusChange\x12\x12\n" +
+	"\x04UUID\x18\x01 \x01(\tR\x04UUID\x12*\n" +
+	"\x10OrganizationUUID\x18\x02 \x01(\tR\x10OrganizationUUID\x12<\n" +
+	"\n" +
+	"FromStatus\x18\x03 \x01(\x0e2\x1c.paystore.OrganizationStatusR\n" +
+	"FromStatus\x128\n" +
+	"\bToStatus\x18\x04 \x01(\x0e2\x1c.paystore.OrganizationStatusR\bToStatus\x12\x16\n" +
+	"\x06Reason\x18\x05 \x01(\tR\x06Reason\x12\x14\n" +
+	"\x05Actor\x18\x06 \x01(\tR\x05Actor\x128\n" +
+	"\tCreatedAt\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tCreatedAt\"Y\n" +
+	"\x19OrganizationStatusHistory\x12<\n" +
+	"\aChanges\x18\x01 \x03(\v2\".paystore.OrganizationStatusChangeR\aChanges\"\x9d\x01\n" +
+	"\x14SplitPaymentResponse\x12\x0e\n" +
+	"\x02ID\x18\x01 \x01(\tR\x02ID\x12&\n" +
+	"\x0eVendorRecordID\x18\x02 \x01(\tR\x0eVendorRecordID\x12\x1e\n" +
+	"\n" +
+	"InvoiceURL\x18\x03 \x01(\tR\n" +
+	"InvoiceURL\x12-\n" +
+	"\bPayments\x18\x04 \x03(\v2\x11.paystore.PaymentR\bPayments\"i\n" +
 	"\x0fCreatedResponse\x12\x0e\n" +
-	"\x02ID\x18\x01 \x01(\tR\x02ID\"\x0f\n" +
-	"\rEmptyResponse*\x7f\n" +
+	"\x02ID\x18\x01 \x01(\tR\x02ID\x12&\n" +
+	"\x0eVendorRecordID\x18\x02 \x01(\tR\x0eVendorRecordID\x12\x1e\n" +
+	"\n" +
+	"InvoiceURL\x18\x03 \x01(\tR\n" +
+	"InvoiceURL\"\x0f\n" +
+	"\rEmptyResponse*\xb6\x01\n" +
 	"\rPaymentStatus\x12\x1e\n" +
 	"\x1aPAYMENT_STATUS_UNSPECIFIED\x10\x00\x12\x1a\n" +
 	"\x16PAYMENT_STATUS_PENDING\x10\x01\x12\x17\n" +
 	"\x13PAYMENT_STATUS_PAID\x10\x02\x12\x19\n" +
-	"\x15PAYMENT_STATUS_FAILED\x10\x032\x92\x03\n" +
+	"\x15PAYMENT_STATUS_FAILED\x10\x03\x12\x1a\n" +
+	"\x16PAYMENT_STATUS_EXPIRED\x10\x04\x12\x19\n" +
+	"\x15PAYMENT_STATUS_REVIEW\x10\x05*h\n" +
+	"\x0fStatementFormat\x12 \n" +
+	"\x1cSTATEMENT_FORMAT_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15STATEMENT_FORMAT_JSON\x10\x01\x12\x18\n" +
+	"\x14STATEMENT_FORMAT_CSV\x10\x02*s\n" +
+	"\x0fFeeScheduleKind\x12!\n" +
+	"\x1dFEE_SCHEDULE_KIND_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19FEE_SCHEDULE_KIND_PAYMENT\x10\x01\x12\x1e\n" +
+	"\x1aFEE_SCHEDULE_KIND_WITHDRAW\x10\x02*\x8d\x01\n" +
+	"\vFeeRounding\x12\x1c\n" +
+	"\x18FEE_ROUNDING_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11FEE_ROUNDING_DOWN\x10\x01\x12\x13\n" +
+	"\x0fFEE_ROUNDING_UP\x10\x02\x12\x18\n" +
+	"\x14FEE_ROUNDING_HALF_UP\x10\x03\x12\x1a\n" +
+	"\x16FEE_ROUNDING_HALF_EVEN\x10\x04*`\n" +
+	"\n" +
+	"LimitScope\x12\x1b\n" +
+	"\x17LIMIT_SCOPE_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18LIMIT_SCOPE_ORGANIZATION\x10\x01\x12\x17\n" +
+	"\x13LIMIT_SCOPE_BALANCE\x10\x02*\x9c\x01\n" +
+	"\x12OrganizationStatus\x12#\n" +
+	"\x1fORGANIZATION_STATUS_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aORGANIZATION_STATUS_ACTIVE\x10\x01\x12!\n" +
+	"\x1dORGANIZATION_STATUS_SUSPENDED\x10\x02\x12\x1e\n" +
+	"\x1aORGANIZATION_STATUS_CLOSED\x10\x03*|\n" +
+	"\x12ReconciliationKind\x12#\n" +
+	"\x1fRECONCILIATION_KIND_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bRECONCILIATION_KIND_PAYMENT\x10\x01\x12 \n" +
+	"\x1cRECONCILIATION_KIND_WITHDRAW\x10\x02*\x92\x02\n" +
+	"\x14ReconciliationResult\x12%\n" +
+	"!RECONCILIATION_RESULT_UNSPECIFIED\x10\x00\x12!\n" +
+	"\x1dRECONCILIATION_RESULT_MATCHED\x10\x01\x12)\n" +
+	"%RECONCILIATION_RESULT_AMOUNT_MISMATCH\x10\x02\x12)\n" +
+	"%RECONCILIATION_RESULT_STATUS_MISMATCH\x10\x03\x12-\n" +
+	")RECONCILIATION_RESULT_MISSING_IN_PAYSTORE\x10\x04\x12+\n" +
+	"'RECONCILIATION_RESULT_MISSING_AT_VENDOR\x10\x052\xcb\x12\n" +
 	"\bPaystore\x12J\n" +
 	"\rCreateBalance\x12\x1e.paystore.CreateBalanceRequest\x1a\x19.paystore.CreatedResponse\x12J\n" +
-	"\rCreatePayment\x12\x1e.paystore.CreatePaymentRequest\x1a\x19.paystore.CreatedResponse\x12N\n" +
+	"\rCreatePayment\x12\x1e.paystore.CreatePaymentRequest\x1a\x19.paystore.CreatedResponse\x12Y\n" +
+	"\x12CreateSplitPayment\x12#.paystore.CreateSplitPaymentRequest\x1a\x1e.paystore.SplitPaymentResponse\x12N\n" +
 	"\x10FinalizedPayment\x12!.paystore.FinalizedPaymentRequest\x1a\x17.paystore.EmptyResponse\x12L\n" +
 	"\x0eCreateWithdraw\x12\x1f.paystore.CreateWithdrawRequest\x1a\x19.paystore.CreatedResponse\x12P\n" +
-	"\x11FinalizedWithdraw\x12\".paystore.FinalizedWithdrawRequest\x1a\x17.paystore.EmptyResponseB\n" +
+	"\x11FinalizedWithdraw\x12\".paystore.FinalizedWithdrawRequest\x1a\x17.paystore.EmptyResponse\x12S\n" +
+	"\x0eSearchPayments\x12\x1f.paystore.SearchPaymentsRequest\x1a .paystore.SearchPaymentsResponse\x12V\n" +
+	"\x0fSearchWithdraws\x12 .paystore.SearchWithdrawsRequest\x1a!.paystore.SearchWithdrawsResponse\x12T\n" +
+	"\x11GenerateStatement\x12\".paystore.GenerateStatementRequest\x1a\x1b.paystore.StatementResponse\x12G\n" +
+	"\tReconcile\x12\x1a.paystore.ReconcileRequest\x1a\x1e.paystore.ReconciliationReport\x12c\n" +
+	"\x17GetReconciliationReport\x12(.paystore.GetReconciliationReportRequest\x1a\x1e.paystore.ReconciliationReport\x12f\n" +
+	"\x17RegisterWebhookEndpoint\x12(.paystore.RegisterWebhookEndpointRequest\x1a!.paystore.WebhookEndpointResponse\x12X\n" +
+	"\x15RemoveWebhookEndpoint\x12&.paystore.RemoveWebhookEndpointRequest\x1a\x17.paystore.EmptyResponse\x12\\\n" +
+	"\x13RotateWebhookSecret\x12$.paystore.RotateWebhookSecretRequest\x1a\x1f.paystore.WebhookSecretResponse\x12X\n" +
+	"\x15ReplayWebhookDelivery\x12&.paystore.ReplayWebhookDeliveryRequest\x1a\x17.paystore.EmptyResponse\x12H\n" +
+	"\x0eSetFeeSchedule\x12\x1f.paystore.SetFeeScheduleRequest\x1a\x15.paystore.FeeSchedule\x12H\n" +
+	"\x0eGetFeeSchedule\x12\x1f.paystore.GetFeeScheduleRequest\x1a\x15.paystore.FeeSchedule\x12M\n" +
+	"\x10ListFeeSchedules\x12!.paystore.ListFeeSchedulesRequest\x1a\x16.paystore.FeeSchedules\x12J\n" +
+	"\x0eSetFeeRounding\x12\x1f.paystore.SetFeeRoundingRequest\x1a\x17.paystore.EmptyResponse\x12Q\n" +
+	"\x11GetRevenueBalance\x12\".paystore.GetRevenueBalanceRequest\x1a\x18.paystore.RevenueBalance\x126\n" +
+	"\bSetLimit\x12\x19.paystore.SetLimitRequest\x1a\x0f.paystore.Limit\x126\n" +
+	"\bGetLimit\x12\x19.paystore.GetLimitRequest\x1a\x0f.paystore.Limit\x12X\n" +
+	"\x15SetOrganizationParent\x12&.paystore.SetOrganizationParentRequest\x1a\x17.paystore.EmptyResponse\x12V\n" +
+	"\x10GetBalanceRollup\x12!.paystore.GetBalanceRollupRequest\x1a\x1f.paystore.BalanceRollupResponse\x12X\n" +
+	"\x13RecomputeSettlement\x12$.paystore.RecomputeSettlementRequest\x1a\x1b.paystore.SettlementReports\x12Z\n" +
+	"\x14GetSettlementReports\x12%.paystore.GetSettlementReportsRequest\x1a\x1b.paystore.SettlementReports\x12c\n" +
+	"\x15SetOrganizationStatus\x12&.paystore.SetOrganizationStatusRequest\x1a\".paystore.OrganizationStatusChange\x12r\n" +
+	"\x1cGetOrganizationStatusHistory\x12-.paystore.GetOrganizationStatusHistoryRequest\x1a#.paystore.OrganizationStatusHistoryB\n" +
 	"Z\b./protosb\x06proto3"
 
 var (
@@ -508,36 +4885,192 @@ func file_operation_paystore_proto_rawDescGZIP() []byte {
 	return file_operation_paystore_proto_rawDescData
 }
 
-var file_operation_paystore_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_operation_paystore_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_operation_paystore_proto_enumTypes = make([]protoimpl.EnumInfo, 8)
+var file_operation_paystore_proto_msgTypes = make([]protoimpl.MessageInfo, 53)
 var file_operation_paystore_proto_goTypes = []any{
-	(PaymentStatus)(0),               // 0: paystore.PaymentStatus
-	(*CreateBalanceRequest)(nil),     // 1: paystore.CreateBalanceRequest
-	(*CreatePaymentRequest)(nil),     // 2: paystore.CreatePaymentRequest
-	(*FinalizedPaymentRequest)(nil),  // 3: paystore.FinalizedPaymentRequest
-	(*CreateWithdrawRequest)(nil),    // 4: paystore.CreateWithdrawRequest
-	(*FinalizedWithdrawRequest)(nil), // 5: paystore.FinalizedWithdrawRequest
-	(*CreatedResponse)(nil),          // 6: paystore.CreatedResponse
-	(*EmptyResponse)(nil),            // 7: paystore.EmptyResponse
+	(PaymentStatus)(0),                          // 0: paystore.PaymentStatus
+	(StatementFormat)(0),                        // 1: paystore.StatementFormat
+	(FeeScheduleKind)(0),                        // 2: paystore.FeeScheduleKind
+	(FeeRounding)(0),                            // 3: paystore.FeeRounding
+	(LimitScope)(0),                             // 4: paystore.LimitScope
+	(OrganizationStatus)(0),                     // 5: paystore.OrganizationStatus
+	(ReconciliationKind)(0),                     // 6: paystore.ReconciliationKind
+	(ReconciliationResult)(0),                   // 7: paystore.ReconciliationResult
+	(*CreateBalanceRequest)(nil),                // 8: paystore.CreateBalanceRequest
+	(*CreatePaymentRequest)(nil),                // 9: paystore.CreatePaymentRequest
+	(*PaymentShare)(nil),                        // 10: paystore.PaymentShare
+	(*CreateSplitPaymentRequest)(nil),           // 11: paystore.CreateSplitPaymentRequest
+	(*FinalizedPaymentRequest)(nil),             // 12: paystore.FinalizedPaymentRequest
+	(*CreateWithdrawRequest)(nil),               // 13: paystore.CreateWithdrawRequest
+	(*FinalizedWithdrawRequest)(nil),            // 14: paystore.FinalizedWithdrawRequest
+	(*SearchPaymentsRequest)(nil),               // 15: paystore.SearchPaymentsRequest
+	(*SearchPaymentsResponse)(nil),              // 16: paystore.SearchPaymentsResponse
+	(*SearchWithdrawsRequest)(nil),              // 17: paystore.SearchWithdrawsRequest
+	(*SearchWithdrawsResponse)(nil),             // 18: paystore.SearchWithdrawsResponse
+	(*Payment)(nil),                             // 19: paystore.Payment
+	(*FeeBreakdown)(nil),                        // 20: paystore.FeeBreakdown
+	(*Withdraw)(nil),                            // 21: paystore.Withdraw
+	(*GenerateStatementRequest)(nil),            // 22: paystore.GenerateStatementRequest
+	(*StatementResponse)(nil),                   // 23: paystore.StatementResponse
+	(*ReconcileRequest)(nil),                    // 24: paystore.ReconcileRequest
+	(*GetReconciliationReportRequest)(nil),      // 25: paystore.GetReconciliationReportRequest
+	(*ReconciliationReport)(nil),                // 26: paystore.ReconciliationReport
+	(*ReconciliationItem)(nil),                  // 27: paystore.ReconciliationItem
+	(*RegisterWebhookEndpointRequest)(nil),      // 28: paystore.RegisterWebhookEndpointRequest
+	(*WebhookEndpointResponse)(nil),             // 29: paystore.WebhookEndpointResponse
+	(*RemoveWebhookEndpointRequest)(nil),        // 30: paystore.RemoveWebhookEndpointRequest
+	(*RotateWebhookSecretRequest)(nil),          // 31: paystore.RotateWebhookSecretRequest
+	(*WebhookSecretResponse)(nil),               // 32: paystore.WebhookSecretResponse
+	(*ReplayWebhookDeliveryRequest)(nil),        // 33: paystore.ReplayWebhookDeliveryRequest
+	(*FeeRule)(nil),                             // 34: paystore.FeeRule
+	(*SetFeeScheduleRequest)(nil),               // 35: paystore.SetFeeScheduleRequest
+	(*GetFeeScheduleRequest)(nil),               // 36: paystore.GetFeeScheduleRequest
+	(*ListFeeSchedulesRequest)(nil),             // 37: paystore.ListFeeSchedulesRequest
+	(*FeeSchedule)(nil),                         // 38: paystore.FeeSchedule
+	(*FeeSchedules)(nil),                        // 39: paystore.FeeSchedules
+	(*SetFeeRoundingRequest)(nil),               // 40: paystore.SetFeeRoundingRequest
+	(*GetRevenueBalanceRequest)(nil),            // 41: paystore.GetRevenueBalanceRequest
+	(*RevenueBalance)(nil),                      // 42: paystore.RevenueBalance
+	(*Limit)(nil),                               // 43: paystore.Limit
+	(*SetLimitRequest)(nil),                     // 44: paystore.SetLimitRequest
+	(*GetLimitRequest)(nil),                     // 45: paystore.GetLimitRequest
+	(*SetOrganizationParentRequest)(nil),        // 46: paystore.SetOrganizationParentRequest
+	(*GetBalanceRollupRequest)(nil),             // 47: paystore.GetBalanceRollupRequest
+	(*BalanceRollup)(nil),                       // 48: paystore.BalanceRollup
+	(*BalanceRollupResponse)(nil),               // 49: paystore.BalanceRollupResponse
+	(*RecomputeSettlementRequest)(nil),          // 50: paystore.RecomputeSettlementRequest
+	(*GetSettlementReportsRequest)(nil),         // 51: paystore.GetSettlementReportsRequest
+	(*SettlementReport)(nil),                    // 52: paystore.SettlementReport
+	(*SettlementReports)(nil),                   // 53: paystore.SettlementReports
+	(*SetOrganizationStatusRequest)(nil),        // 54: paystore.SetOrganizationStatusRequest
+	(*GetOrganizationStatusHistoryRequest)(nil), // 55: paystore.GetOrganizationStatusHistoryRequest
+	(*OrganizationStatusChange)(nil),            // 56: paystore.OrganizationStatusChange
+	(*OrganizationStatusHistory)(nil),           // 57: paystore.OrganizationStatusHistory
+	(*SplitPaymentResponse)(nil),                // 58: paystore.SplitPaymentResponse
+	(*CreatedResponse)(nil),                     // 59: paystore.CreatedResponse
+	(*EmptyResponse)(nil),                       // 60: paystore.EmptyResponse
+	(*timestamppb.Timestamp)(nil),               // 61: google.protobuf.Timestamp
 }
 var file_operation_paystore_proto_depIdxs = []int32{
-	0, // 0: paystore.FinalizedPaymentRequest.PaymentStatus:type_name -> paystore.PaymentStatus
-	0, // 1: paystore.FinalizedWithdrawRequest.WithdrawStatus:type_name -> paystore.PaymentStatus
-	1, // 2: paystore.Paystore.CreateBalance:input_type -> paystore.CreateBalanceRequest
-	2, // 3: paystore.Paystore.CreatePayment:input_type -> paystore.CreatePaymentRequest
-	3, // 4: paystore.Paystore.FinalizedPayment:input_type -> paystore.FinalizedPaymentRequest
-	4, // 5: paystore.Paystore.CreateWithdraw:input_type -> paystore.CreateWithdrawRequest
-	5, // 6: paystore.Paystore.FinalizedWithdraw:input_type -> paystore.FinalizedWithdrawRequest
-	6, // 7: paystore.Paystore.CreateBalance:output_type -> paystore.CreatedResponse
-	6, // 8: paystore.Paystore.CreatePayment:output_type -> paystore.CreatedResponse
-	7, // 9: paystore.Paystore.FinalizedPayment:output_type -> paystore.EmptyResponse
-	6, // 10: paystore.Paystore.CreateWithdraw:output_type -> paystore.CreatedResponse
-	7, // 11: paystore.Paystore.FinalizedWithdraw:output_type -> paystore.EmptyResponse
-	7, // [7:12] is the sub-list for method output_type
-	2, // [2:7] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	10, // 0: paystore.CreateSplitPaymentRequest.Shares:type_name -> paystore.PaymentShare
+	0,  // 1: paystore.FinalizedPaymentRequest.PaymentStatus:type_name -> paystore.PaymentStatus
+	0,  // 2: paystore.FinalizedWithdrawRequest.WithdrawStatus:type_name -> paystore.PaymentStatus
+	0,  // 3: paystore.SearchPaymentsRequest.Statuses:type_name -> paystore.PaymentStatus
+	61, // 4: paystore.SearchPaymentsRequest.CreatedFrom:type_name -> google.protobuf.Timestamp
+	61, // 5: paystore.SearchPaymentsRequest.CreatedTo:type_name -> google.protobuf.Timestamp
+	19, // 6: paystore.SearchPaymentsResponse.Payments:type_name -> paystore.Payment
+	0,  // 7: paystore.SearchWithdrawsRequest.Statuses:type_name -> paystore.PaymentStatus
+	61, // 8: paystore.SearchWithdrawsRequest.CreatedFrom:type_name -> google.protobuf.Timestamp
+	61, // 9: paystore.SearchWithdrawsRequest.CreatedTo:type_name -> google.protobuf.Timestamp
+	21, // 10: paystore.SearchWithdrawsResponse.Withdraws:type_name -> paystore.Withdraw
+	61, // 11: paystore.Payment.CreatedAt:type_name -> google.protobuf.Timestamp
+	61, // 12: paystore.Payment.UpdatedAt:type_name -> google.protobuf.Timestamp
+	0,  // 13: paystore.Payment.Status:type_name -> paystore.PaymentStatus
+	20, // 14: paystore.Payment.FeeBreakdown:type_name -> paystore.FeeBreakdown
+	3,  // 15: paystore.FeeBreakdown.Rounding:type_name -> paystore.FeeRounding
+	61, // 16: paystore.Withdraw.CreatedAt:type_name -> google.protobuf.Timestamp
+	61, // 17: paystore.Withdraw.UpdatedAt:type_name -> google.protobuf.Timestamp
+	0,  // 18: paystore.Withdraw.Status:type_name -> paystore.PaymentStatus
+	20, // 19: paystore.Withdraw.FeeBreakdown:type_name -> paystore.FeeBreakdown
+	61, // 20: paystore.GenerateStatementRequest.PeriodStart:type_name -> google.protobuf.Timestamp
+	61, // 21: paystore.GenerateStatementRequest.PeriodEnd:type_name -> google.protobuf.Timestamp
+	1,  // 22: paystore.GenerateStatementRequest.Format:type_name -> paystore.StatementFormat
+	6,  // 23: paystore.ReconcileRequest.Kind:type_name -> paystore.ReconciliationKind
+	61, // 24: paystore.ReconcileRequest.PeriodStart:type_name -> google.protobuf.Timestamp
+	61, // 25: paystore.ReconcileRequest.PeriodEnd:type_name -> google.protobuf.Timestamp
+	7,  // 26: paystore.GetReconciliationReportRequest.Result:type_name -> paystore.ReconciliationResult
+	6,  // 27: paystore.ReconciliationReport.Kind:type_name -> paystore.ReconciliationKind
+	61, // 28: paystore.ReconciliationReport.PeriodStart:type_name -> google.protobuf.Timestamp
+	61, // 29: paystore.ReconciliationReport.PeriodEnd:type_name -> google.protobuf.Timestamp
+	27, // 30: paystore.ReconciliationReport.Items:type_name -> paystore.ReconciliationItem
+	7,  // 31: paystore.ReconciliationItem.Result:type_name -> paystore.ReconciliationResult
+	34, // 32: paystore.SetFeeScheduleRequest.Rules:type_name -> paystore.FeeRule
+	2,  // 33: paystore.SetFeeScheduleRequest.Kind:type_name -> paystore.FeeScheduleKind
+	61, // 34: paystore.SetFeeScheduleRequest.EffectiveFrom:type_name -> google.protobuf.Timestamp
+	2,  // 35: paystore.GetFeeScheduleRequest.Kind:type_name -> paystore.FeeScheduleKind
+	61, // 36: paystore.GetFeeScheduleRequest.At:type_name -> google.protobuf.Timestamp
+	2,  // 37: paystore.ListFeeSchedulesRequest.Kind:type_name -> paystore.FeeScheduleKind
+	34, // 38: paystore.FeeSchedule.Rules:type_name -> paystore.FeeRule
+	2,  // 39: paystore.FeeSchedule.Kind:type_name -> paystore.FeeScheduleKind
+	3,  // 40: paystore.FeeSchedule.Rounding:type_name -> paystore.FeeRounding
+	61, // 41: paystore.FeeSchedule.EffectiveFrom:type_name -> google.protobuf.Timestamp
+	61, // 42: paystore.FeeSchedule.EffectiveTo:type_name -> google.protobuf.Timestamp
+	38, // 43: paystore.FeeSchedules.Schedules:type_name -> paystore.FeeSchedule
+	3,  // 44: paystore.SetFeeRoundingRequest.Rounding:type_name -> paystore.FeeRounding
+	4,  // 45: paystore.Limit.Scope:type_name -> paystore.LimitScope
+	4,  // 46: paystore.SetLimitRequest.Scope:type_name -> paystore.LimitScope
+	4,  // 47: paystore.GetLimitRequest.Scope:type_name -> paystore.LimitScope
+	48, // 48: paystore.BalanceRollup.Organizations:type_name -> paystore.BalanceRollup
+	48, // 49: paystore.BalanceRollupResponse.Rollups:type_name -> paystore.BalanceRollup
+	61, // 50: paystore.SettlementReport.UpdatedAt:type_name -> google.protobuf.Timestamp
+	52, // 51: paystore.SettlementReports.Reports:type_name -> paystore.SettlementReport
+	5,  // 52: paystore.SetOrganizationStatusRequest.Status:type_name -> paystore.OrganizationStatus
+	5,  // 53: paystore.OrganizationStatusChange.FromStatus:type_name -> paystore.OrganizationStatus
+	5,  // 54: paystore.OrganizationStatusChange.ToStatus:type_name -> paystore.OrganizationStatus
+	61, // 55: paystore.OrganizationStatusChange.CreatedAt:type_name -> google.protobuf.Timestamp
+	56, // 56: paystore.OrganizationStatusHistory.Changes:type_name -> paystore.OrganizationStatusChange
+	19, // 57: paystore.SplitPaymentResponse.Payments:type_name -> paystore.Payment
+	8,  // 58: paystore.Paystore.CreateBalance:input_type -> paystore.CreateBalanceRequest
+	9,  // 59: paystore.Paystore.CreatePayment:input_type -> paystore.CreatePaymentRequest
+	11, // 60: paystore.Paystore.CreateSplitPayment:input_type -> paystore.CreateSplitPaymentRequest
+	12, // 61: paystore.Paystore.FinalizedPayment:input_type -> paystore.FinalizedPaymentRequest
+	13, // 62: paystore.Paystore.CreateWithdraw:input_type -> paystore.CreateWithdrawRequest
+	14, // 63: paystore.Paystore.FinalizedWithdraw:input_type -> paystore.FinalizedWithdrawRequest
+	15, // 64: paystore.Paystore.SearchPayments:input_type -> paystore.SearchPaymentsRequest
+	17, // 65: paystore.Paystore.SearchWithdraws:input_type -> paystore.SearchWithdrawsRequest
+	22, // 66: paystore.Paystore.GenerateStatement:input_type -> paystore.GenerateStatementRequest
+	24, // 67: paystore.Paystore.Reconcile:input_type -> paystore.ReconcileRequest
+	25, // 68: paystore.Paystore.GetReconciliationReport:input_type -> paystore.GetReconciliationReportRequest
+	28, // 69: paystore.Paystore.RegisterWebhookEndpoint:input_type -> paystore.RegisterWebhookEndpointRequest
+	30, // 70: paystore.Paystore.RemoveWebhookEndpoint:input_type -> paystore.RemoveWebhookEndpointRequest
+	31, // 71: paystore.Paystore.RotateWebhookSecret:input_type -> paystore.RotateWebhookSecretRequest
+	33, // 72: paystore.Paystore.ReplayWebhookDelivery:input_type -> paystore.ReplayWebhookDeliveryRequest
+	35, // 73: paystore.Paystore.SetFeeSchedule:input_type -> paystore.SetFeeScheduleRequest
+	36, // 74: paystore.Paystore.GetFeeSchedule:input_type -> paystore.GetFeeScheduleRequest
+	37, // 75: paystore.Paystore.ListFeeSchedules:input_type -> paystore.ListFeeSchedulesRequest
+	40, // 76: paystore.Paystore.SetFeeRounding:input_type -> paystore.SetFeeRoundingRequest
+	41, // 77: paystore.Paystore.GetRevenueBalance:input_type -> paystore.GetRevenueBalanceRequest
+	44, // 78: paystore.Paystore.SetLimit:input_type -> paystore.SetLimitRequest
+	45, // 79: paystore.Paystore.GetLimit:input_type -> paystore.GetLimitRequest
+	46, // 80: paystore.Paystore.SetOrganizationParent:input_type -> paystore.SetOrganizationParentRequest
+	47, // 81: paystore.Paystore.GetBalanceRollup:input_type -> paystore.GetBalanceRollupRequest
+	50, // 82: paystore.Paystore.RecomputeSettlement:input_type -> paystore.RecomputeSettlementRequest
+	51, // 83: paystore.Paystore.GetSettlementReports:input_type -> paystore.GetSettlementReportsRequest
+	54, // 84: paystore.Paystore.SetOrganizationStatus:input_type -> paystore.SetOrganizationStatusRequest
+	55, // 85: paystore.Paystore.GetOrganizationStatusHistory:input_type -> paystore.GetOrganizationStatusHistoryRequest
+	59, // 86: paystore.Paystore.CreateBalance:output_type -> paystore.CreatedResponse
+	59, // 87: paystore.Paystore.CreatePayment:output_type -> paystore.CreatedResponse
+	58, // 88: paystore.Paystore.CreateSplitPayment:output_type -> paystore.SplitPaymentResponse
+	60, // 89: paystore.Paystore.FinalizedPayment:output_type -> paystore.EmptyResponse
+	59, // 90: paystore.Paystore.CreateWithdraw:output_type -> paystore.CreatedResponse
+	60, // 91: paystore.Paystore.FinalizedWithdraw:output_type -> paystore.EmptyResponse
+	16, // 92: paystore.Paystore.SearchPayments:output_type -> paystore.SearchPaymentsResponse
+	18, // 93: paystore.Paystore.SearchWithdraws:output_type -> paystore.SearchWithdrawsResponse
+	23, // 94: paystore.Paystore.GenerateStatement:output_type -> paystore.StatementResponse
+	26, // 95: paystore.Paystore.Reconcile:output_type -> paystore.ReconciliationReport
+	26, // 96: paystore.Paystore.GetReconciliationReport:output_type -> paystore.ReconciliationReport
+	29, // 97: paystore.Paystore.RegisterWebhookEndpoint:output_type -> paystore.WebhookEndpointResponse
+	60, // 98: paystore.Paystore.RemoveWebhookEndpoint:output_type -> paystore.EmptyResponse
+	32, // 99: paystore.Paystore.RotateWebhookSecret:output_type -> paystore.WebhookSecretResponse
+	60, // 100: paystore.Paystore.ReplayWebhookDelivery:output_type -> paystore.EmptyResponse
+	38, // 101: paystore.Paystore.SetFeeSchedule:output_type -> paystore.FeeSchedule
+	38, // 102: paystore.Paystore.GetFeeSchedule:output_type -> paystore.FeeSchedule
+	39, // 103: paystore.Paystore.ListFeeSchedules:output_type -> paystore.FeeSchedules
+	60, // 104: paystore.Paystore.SetFeeRounding:output_type -> paystore.EmptyResponse
+	42, // 105: paystore.Paystore.GetRevenueBalance:output_type -> paystore.RevenueBalance
+	43, // 106: paystore.Paystore.SetLimit:output_type -> paystore.Limit
+	43, // 107: paystore.Paystore.GetLimit:output_type -> paystore.Limit
+	60, // 108: paystore.Paystore.SetOrganizationParent:output_type -> paystore.EmptyResponse
+	49, // 109: paystore.Paystore.GetBalanceRollup:output_type -> paystore.BalanceRollupResponse
+	53, // 110: paystore.Paystore.RecomputeSettlement:output_type -> paystore.SettlementReports
+	53, // 111: paystore.Paystore.GetSettlementReports:output_type -> paystore.SettlementReports
+	56, // 112: paystore.Paystore.SetOrganizationStatus:output_type -> paystore.OrganizationStatusChange
+	57, // 113: paystore.Paystore.GetOrganizationStatusHistory:output_type -> paystore.OrganizationStatusHistory
+	86, // [86:114] is the sub-list for method output_type
+	58, // [58:86] is the sub-list for method input_type
+	58, // [58:58] is the sub-list for extension type_name
+	58, // [58:58] is the sub-list for extension extendee
+	0,  // [0:58] is the sub-list for field type_name
 }
 
 func init() { file_operation_paystore_proto_init() }
@@ -550,8 +5083,8 @@ func file_operation_paystore_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_operation_paystore_proto_rawDesc), len(file_operation_paystore_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   7,
+			NumEnums:      8,
+			NumMessages:   53,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,11 +19,34 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Paystore_CreateBalance_FullMethodName     = "/paystore.Paystore/CreateBalance"
-	Paystore_CreatePayment_FullMethodName     = "/paystore.Paystore/CreatePayment"
-	Paystore_FinalizedPayment_FullMethodName  = "/paystore.Paystore/FinalizedPayment"
-	Paystore_CreateWithdraw_FullMethodName    = "/paystore.Paystore/CreateWithdraw"
-	Paystore_FinalizedWithdraw_FullMethodName = "/paystore.Paystore/FinalizedWithdraw"
+	Paystore_CreateBalance_FullMethodName                = "/paystore.Paystore/CreateBalance"
+	Paystore_CreatePayment_FullMethodName                = "/paystore.Paystore/CreatePayment"
+	Paystore_CreateSplitPayment_FullMethodName           = "/paystore.Paystore/CreateSplitPayment"
+	Paystore_FinalizedPayment_FullMethodName             = "/paystore.Paystore/FinalizedPayment"
+	Paystore_CreateWithdraw_FullMethodName               = "/paystore.Paystore/CreateWithdraw"
+	Paystore_FinalizedWithdraw_FullMethodName            = "/paystore.Paystore/FinalizedWithdraw"
+	Paystore_SearchPayments_FullMethodName               = "/paystore.Paystore/SearchPayments"
+	Paystore_SearchWithdraws_FullMethodName              = "/paystore.Paystore/SearchWithdraws"
+	Paystore_GenerateStatement_FullMethodName            = "/paystore.Paystore/GenerateStatement"
+	Paystore_Reconcile_FullMethodName                    = "/paystore.Paystore/Reconcile"
+	Paystore_GetReconciliationReport_FullMethodName      = "/paystore.Paystore/GetReconciliationReport"
+	Paystore_RegisterWebhookEndpoint_FullMethodName      = "/paystore.Paystore/RegisterWebhookEndpoint"
+	Paystore_RemoveWebhookEndpoint_FullMethodName        = "/paystore.Paystore/RemoveWebhookEndpoint"
+	Paystore_RotateWebhookSecret_FullMethodName          = "/paystore.Paystore/RotateWebhookSecret"
+	Paystore_ReplayWebhookDelivery_FullMethodName        = "/paystore.Paystore/ReplayWebhookDelivery"
+	Paystore_SetFeeSchedule_FullMethodName               = "/paystore.Paystore/SetFeeSchedule"
+	Paystore_GetFeeSchedule_FullMethodName               = "/paystore.Paystore/GetFeeSchedule"
+	Paystore_ListFeeSchedules_FullMethodName             = "/paystore.Paystore/ListFeeSchedules"
+	Paystore_SetFeeRounding_FullMethodName               = "/paystore.Paystore/SetFeeRounding"
+	Paystore_GetRevenueBalance_FullMethodName            = "/paystore.Paystore/GetRevenueBalance"
+	Paystore_SetLimit_FullMethodName                     = "/paystore.Paystore/SetLimit"
+	Paystore_GetLimit_FullMethodName                     = "/paystore.Paystore/GetLimit"
+	Paystore_SetOrganizationParent_FullMethodName        = "/paystore.Paystore/SetOrganizationParent"
+	Paystore_GetBalanceRollup_FullMethodName             = "/paystore.Paystore/GetBalanceRollup"
+	Paystore_RecomputeSettlement_FullMethodName          = "/paystore.Paystore/RecomputeSettlement"
+	Paystore_GetSettlementReports_FullMethodName         = "/paystore.Paystore/GetSettlementReports"
+	Paystore_SetOrganizationStatus_FullMethodName        = "/paystore.Paystore/SetOrganizationStatus"
+	Paystore_GetOrganizationStatusHistory_FullMethodName = "/paystore.Paystore/GetOrganizationStatusHistory"
 )
 
 // PaystoreClient is the client API for Paystore service.
@@ -32,9 +55,32 @@ const (
 type PaystoreClient interface {
 	CreateBalance(ctx context.Context, in *CreateBalanceRequest, opts ...grpc.CallOption) (*CreatedResponse, error)
 	CreatePayment(ctx context.Context, in *CreatePaymentRequest, opts ...grpc.CallOption) (*CreatedResponse, error)
+	CreateSplitPayment(ctx context.Context, in *CreateSplitPaymentRequest, opts ...grpc.CallOption) (*SplitPaymentResponse, error)
 	FinalizedPayment(ctx context.Context, in *FinalizedPaymentRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
 	CreateWithdraw(ctx context.Context, in *CreateWithdrawRequest, opts ...grpc.CallOption) (*CreatedResponse, error)
 	FinalizedWithdraw(ctx context.Context, in *FinalizedWithdrawRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	SearchPayments(ctx context.Context, in *SearchPaymentsRequest, opts ...grpc.CallOption) (*SearchPaymentsResponse, error)
+	SearchWithdraws(ctx context.Context, in *SearchWithdrawsRequest, opts ...grpc.CallOption) (*SearchWithdrawsResponse, error)
+	GenerateStatement(ctx context.Context, in *GenerateStatementRequest, opts ...grpc.CallOption) (*StatementResponse, error)
+	Reconcile(ctx context.Context, in *ReconcileRequest, opts ...grpc.CallOption) (*ReconciliationReport, error)
+	GetReconciliationReport(ctx context.Context, in *GetReconciliationReportRequest, opts ...grpc.CallOption) (*ReconciliationReport, error)
+	RegisterWebhookEndpoint(ctx context.Context, in *RegisterWebhookEndpointRequest, opts ...grpc.CallOption) (*WebhookEndpointResponse, error)
+	RemoveWebhookEndpoint(ctx context.Context, in *RemoveWebhookEndpointRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	RotateWebhookSecret(ctx context.Context, in *RotateWebhookSecretRequest, opts ...grpc.CallOption) (*WebhookSecretResponse, error)
+	ReplayWebhookDelivery(ctx context.Context, in *ReplayWebhookDeliveryRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	SetFeeSchedule(ctx context.Context, in *SetFeeScheduleRequest, opts ...grpc.CallOption) (*FeeSchedule, error)
+	GetFeeSchedule(ctx context.Context, in *GetFeeScheduleRequest, opts ...grpc.CallOption) (*FeeSchedule, error)
+	ListFeeSchedules(ctx context.Context, in *ListFeeSchedulesRequest, opts ...grpc.CallOption) (*FeeSchedules, error)
+	SetFeeRounding(ctx context.Context, in *SetFeeRoundingRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	GetRevenueBalance(ctx context.Context, in *GetRevenueBalanceRequest, opts ...grpc.CallOption) (*RevenueBalance, error)
+	SetLimit(ctx context.Context, in *SetLimitRequest, opts ...grpc.CallOption) (*Limit, error)
+	GetLimit(ctx context.Context, in *GetLimitRequest, opts ...grpc.CallOption) (*Limit, error)
+	SetOrganizationParent(ctx context.Context, in *SetOrganizationParentRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	GetBalanceRollup(ctx context.Context, in *GetBalanceRollupRequest, opts ...grpc.CallOption) (*BalanceRollupResponse, error)
+	RecomputeSettlement(ctx context.Context, in *RecomputeSettlementRequest, opts ...grpc.CallOption) (*SettlementReports, error)
+	GetSettlementReports(ctx context.Context, in *GetSettlementReportsRequest, opts ...grpc.CallOption) (*SettlementReports, error)
+	SetOrganizationStatus(ctx context.Context, in *SetOrganizationStatusRequest, opts ...grpc.CallOption) (*OrganizationStatusChange, error)
+	GetOrganizationStatusHistory(ctx context.Context, in *GetOrganizationStatusHistoryRequest, opts ...grpc.CallOption) (*OrganizationStatusHistory, error)
 }
 
 type paystoreClient struct {
@@ -65,6 +111,16 @@ func (c *paystoreClient) CreatePayment(ctx context.Context, in *CreatePaymentReq
 	return out, nil
 }
 
+func (c *paystoreClient) CreateSplitPayment(ctx context.Context, in *CreateSplitPaymentRequest, opts ...grpc.CallOption) (*SplitPaymentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SplitPaymentResponse)
+	err := c.cc.Invoke(ctx, Paystore_CreateSplitPayment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *paystoreClient) FinalizedPayment(ctx context.Context, in *FinalizedPaymentRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(EmptyResponse)
@@ -95,15 +151,258 @@ func (c *paystoreClient) FinalizedWithdraw(ctx context.Context, in *FinalizedWit
 	return out, nil
 }
 
+func (c *paystoreClient) SearchPayments(ctx context.Context, in *SearchPaymentsRequest, opts ...grpc.CallOption) (*SearchPaymentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchPaymentsResponse)
+	err := c.cc.Invoke(ctx, Paystore_SearchPayments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paystoreClient) SearchWithdraws(ctx context.Context, in *SearchWithdrawsRequest, opts ...grpc.CallOption) (*SearchWithdrawsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchWithdrawsResponse)
+	err := c.cc.Invoke(ctx, Paystore_SearchWithdraws_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paystoreClient) GenerateStatement(ctx context.Context, in *GenerateStatementRequest, opts ...grpc.CallOption) (*StatementResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StatementResponse)
+	err := c.cc.Invoke(ctx, Paystore_GenerateStatement_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paystoreClient) Reconcile(ctx context.Context, in *ReconcileRequest, opts ...grpc.CallOption) (*ReconciliationReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReconciliationReport)
+	err := c.cc.Invoke(ctx, Paystore_Reconcile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paystoreClient) GetReconciliationReport(ctx context.Context, in *GetReconciliationReportRequest, opts ...grpc.CallOption) (*ReconciliationReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReconciliationReport)
+	err := c.cc.Invoke(ctx, Paystore_GetReconciliationReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paystoreClient) RegisterWebhookEndpoint(ctx context.Context, in *RegisterWebhookEndpointRequest, opts ...grpc.CallOption) (*WebhookEndpointResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WebhookEndpointResponse)
+	err := c.cc.Invoke(ctx, Paystore_RegisterWebhookEndpoint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paystoreClient) RemoveWebhookEndpoint(ctx context.Context, in *RemoveWebhookEndpointRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, Paystore_RemoveWebhookEndpoint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paystoreClient) RotateWebhookSecret(ctx context.Context, in *RotateWebhookSecretRequest, opts ...grpc.CallOption) (*WebhookSecretResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WebhookSecretResponse)
+	err := c.cc.Invoke(ctx, Paystore_RotateWebhookSecret_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paystoreClient) ReplayWebhookDelivery(ctx context.Context, in *ReplayWebhookDeliveryRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, Paystore_ReplayWebhookDelivery_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paystoreClient) SetFeeSchedule(ctx context.Context, in *SetFeeScheduleRequest, opts ...grpc.CallOption) (*FeeSchedule, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FeeSchedule)
+	err := c.cc.Invoke(ctx, Paystore_SetFeeSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paystoreClient) GetFeeSchedule(ctx context.Context, in *GetFeeScheduleRequest, opts ...grpc.CallOption) (*FeeSchedule, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FeeSchedule)
+	err := c.cc.Invoke(ctx, Paystore_GetFeeSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paystoreClient) ListFeeSchedules(ctx context.Context, in *ListFeeSchedulesRequest, opts ...grpc.CallOption) (*FeeSchedules, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FeeSchedules)
+	err := c.cc.Invoke(ctx, Paystore_ListFeeSchedules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paystoreClient) SetFeeRounding(ctx context.Context, in *SetFeeRoundingRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, Paystore_SetFeeRounding_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paystoreClient) GetRevenueBalance(ctx context.Context, in *GetRevenueBalanceRequest, opts ...grpc.CallOption) (*RevenueBalance, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevenueBalance)
+	err := c.cc.Invoke(ctx, Paystore_GetRevenueBalance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paystoreClient) SetLimit(ctx context.Context, in *SetLimitRequest, opts ...grpc.CallOption) (*Limit, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Limit)
+	err := c.cc.Invoke(ctx, Paystore_SetLimit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paystoreClient) GetLimit(ctx context.Context, in *GetLimitRequest, opts ...grpc.CallOption) (*Limit, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Limit)
+	err := c.cc.Invoke(ctx, Paystore_GetLimit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paystoreClient) SetOrganizationParent(ctx context.Context, in *SetOrganizationParentRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, Paystore_SetOrganizationParent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paystoreClient) GetBalanceRollup(ctx context.Context, in *GetBalanceRollupRequest, opts ...grpc.CallOption) (*BalanceRollupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BalanceRollupResponse)
+	err := c.cc.Invoke(ctx, Paystore_GetBalanceRollup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paystoreClient) RecomputeSettlement(ctx context.Context, in *RecomputeSettlementRequest, opts ...grpc.CallOption) (*SettlementReports, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SettlementReports)
+	err := c.cc.Invoke(ctx, Paystore_RecomputeSettlement_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paystoreClient) GetSettlementReports(ctx context.Context, in *GetSettlementReportsRequest, opts ...grpc.CallOption) (*SettlementReports, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SettlementReports)
+	err := c.cc.Invoke(ctx, Paystore_GetSettlementReports_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paystoreClient) SetOrganizationStatus(ctx context.Context, in *SetOrganizationStatusRequest, opts ...grpc.CallOption) (*OrganizationStatusChange, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrganizationStatusChange)
+	err := c.cc.Invoke(ctx, Paystore_SetOrganizationStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paystoreClient) GetOrganizationStatusHistory(ctx context.Context, in *GetOrganizationStatusHistoryRequest, opts ...grpc.CallOption) (*OrganizationStatusHistory, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrganizationStatusHistory)
+	err := c.cc.Invoke(ctx, Paystore_GetOrganizationStatusHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PaystoreServer is the server API for Paystore service.
 // All implementations must embed UnimplementedPaystoreServer
 // for forward compatibility.
 type PaystoreServer interface {
 	CreateBalance(context.Context, *CreateBalanceRequest) (*CreatedResponse, error)
 	CreatePayment(context.Context, *CreatePaymentRequest) (*CreatedResponse, error)
+	CreateSplitPayment(context.Context, *CreateSplitPaymentRequest) (*SplitPaymentResponse, error)
 	FinalizedPayment(context.Context, *FinalizedPaymentRequest) (*EmptyResponse, error)
 	CreateWithdraw(context.Context, *CreateWithdrawRequest) (*CreatedResponse, error)
 	FinalizedWithdraw(context.Context, *FinalizedWithdrawRequest) (*EmptyResponse, error)
+	SearchPayments(context.Context, *SearchPaymentsRequest) (*SearchPaymentsResponse, error)
+	SearchWithdraws(context.Context, *SearchWithdrawsRequest) (*SearchWithdrawsResponse, error)
+	GenerateStatement(context.Context, *GenerateStatementRequest) (*StatementResponse, error)
+	Reconcile(context.Context, *ReconcileRequest) (*ReconciliationReport, error)
+	GetReconciliationReport(context.Context, *GetReconciliationReportRequest) (*ReconciliationReport, error)
+	RegisterWebhookEndpoint(context.Context, *RegisterWebhookEndpointRequest) (*WebhookEndpointResponse, error)
+	RemoveWebhookEndpoint(context.Context, *RemoveWebhookEndpointRequest) (*EmptyResponse, error)
+	RotateWebhookSecret(context.Context, *RotateWebhookSecretRequest) (*WebhookSecretResponse, error)
+	ReplayWebhookDelivery(context.Context, *ReplayWebhookDeliveryRequest) (*EmptyResponse, error)
+	SetFeeSchedule(context.Context, *SetFeeScheduleRequest) (*FeeSchedule, error)
+	GetFeeSchedule(context.Context, *GetFeeScheduleRequest) (*FeeSchedule, error)
+	ListFeeSchedules(context.Context, *ListFeeSchedulesRequest) (*FeeSchedules, error)
+	SetFeeRounding(context.Context, *SetFeeRoundingRequest) (*EmptyResponse, error)
+	GetRevenueBalance(context.Context, *GetRevenueBalanceRequest) (*RevenueBalance, error)
+	SetLimit(context.Context, *SetLimitRequest) (*Limit, error)
+	GetLimit(context.Context, *GetLimitRequest) (*Limit, error)
+	SetOrganizationParent(context.Context, *SetOrganizationParentRequest) (*EmptyResponse, error)
+	GetBalanceRollup(context.Context, *GetBalanceRollupRequest) (*BalanceRollupResponse, error)
+	RecomputeSettlement(context.Context, *RecomputeSettlementRequest) (*SettlementReports, error)
+	GetSettlementReports(context.Context, *GetSettlementReportsRequest) (*SettlementReports, error)
+	SetOrganizationStatus(context.Context, *SetOrganizationStatusRequest) (*OrganizationStatusChange, error)
+	GetOrganizationStatusHistory(context.Context, *GetOrganizationStatusHistoryRequest) (*OrganizationStatusHistory, error)
 	mustEmbedUnimplementedPaystoreServer()
 }
 
@@ -120,6 +419,9 @@ func (UnimplementedPaystoreServer) CreateBalance(context.Context, *CreateBalance
 func (UnimplementedPaystoreServer) CreatePayment(context.Context, *CreatePaymentRequest) (*CreatedResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreatePayment not implemented")
 }
+func (UnimplementedPaystoreServer) CreateSplitPayment(context.Context, *CreateSplitPaymentRequest) (*SplitPaymentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSplitPayment not implemented")
+}
 func (UnimplementedPaystoreServer) FinalizedPayment(context.Context, *FinalizedPaymentRequest) (*EmptyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FinalizedPayment not implemented")
 }
@@ -129,6 +431,72 @@ func (UnimplementedPaystoreServer) CreateWithdraw(context.Context, *CreateWithdr
 func (UnimplementedPaystoreServer) FinalizedWithdraw(context.Context, *FinalizedWithdrawRequest) (*EmptyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FinalizedWithdraw not implemented")
 }
+func (UnimplementedPaystoreServer) SearchPayments(context.Context, *SearchPaymentsRequest) (*SearchPaymentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchPayments not implemented")
+}
+func (UnimplementedPaystoreServer) SearchWithdraws(context.Context, *SearchWithdrawsRequest) (*SearchWithdrawsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchWithdraws not implemented")
+}
+func (UnimplementedPaystoreServer) GenerateStatement(context.Context, *GenerateStatementRequest) (*StatementResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateStatement not implemented")
+}
+func (UnimplementedPaystoreServer) Reconcile(context.Context, *ReconcileRequest) (*ReconciliationReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reconcile not implemented")
+}
+func (UnimplementedPaystoreServer) GetReconciliationReport(context.Context, *GetReconciliationReportRequest) (*ReconciliationReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReconciliationReport not implemented")
+}
+func (UnimplementedPaystoreServer) RegisterWebhookEndpoint(context.Context, *RegisterWebhookEndpointRequest) (*WebhookEndpointResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterWebhookEndpoint not implemented")
+}
+func (UnimplementedPaystoreServer) RemoveWebhookEndpoint(context.Context, *RemoveWebhookEndpointRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveWebhookEndpoint not implemented")
+}
+func (UnimplementedPaystoreServer) RotateWebhookSecret(context.Context, *RotateWebhookSecretRequest) (*WebhookSecretResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateWebhookSecret not implemented")
+}
+func (UnimplementedPaystoreServer) ReplayWebhookDelivery(context.Context, *ReplayWebhookDeliveryRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplayWebhookDelivery not implemented")
+}
+func (UnimplementedPaystoreServer) SetFeeSchedule(context.Context, *SetFeeScheduleRequest) (*FeeSchedule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetFeeSchedule not implemented")
+}
+func (UnimplementedPaystoreServer) GetFeeSchedule(context.Context, *GetFeeScheduleRequest) (*FeeSchedule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFeeSchedule not implemented")
+}
+func (UnimplementedPaystoreServer) ListFeeSchedules(context.Context, *ListFeeSchedulesRequest) (*FeeSchedules, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFeeSchedules not implemented")
+}
+func (UnimplementedPaystoreServer) SetFeeRounding(context.Context, *SetFeeRoundingRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetFeeRounding not implemented")
+}
+func (UnimplementedPaystoreServer) GetRevenueBalance(context.Context, *GetRevenueBalanceRequest) (*RevenueBalance, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRevenueBalance not implemented")
+}
+func (UnimplementedPaystoreServer) SetLimit(context.Context, *SetLimitRequest) (*Limit, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLimit not implemented")
+}
+func (UnimplementedPaystoreServer) GetLimit(context.Context, *GetLimitRequest) (*Limit, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLimit not implemented")
+}
+func (UnimplementedPaystoreServer) SetOrganizationParent(context.Context, *SetOrganizationParentRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetOrganizationParent not implemented")
+}
+func (UnimplementedPaystoreServer) GetBalanceRollup(context.Context, *GetBalanceRollupRequest) (*BalanceRollupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBalanceRollup not implemented")
+}
+func (UnimplementedPaystoreServer) RecomputeSettlement(context.Context, *RecomputeSettlementRequest) (*SettlementReports, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecomputeSettlement not implemented")
+}
+func (UnimplementedPaystoreServer) GetSettlementReports(context.Context, *GetSettlementReportsRequest) (*SettlementReports, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSettlementReports not implemented")
+}
+func (UnimplementedPaystoreServer) SetOrganizationStatus(context.Context, *SetOrganizationStatusRequest) (*OrganizationStatusChange, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetOrganizationStatus not implemented")
+}
+func (UnimplementedPaystoreServer) GetOrganizationStatusHistory(context.Context, *GetOrganizationStatusHistoryRequest) (*OrganizationStatusHistory, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrganizationStatusHistory not implemented")
+}
 func (UnimplementedPaystoreServer) mustEmbedUnimplementedPaystoreServer() {}
 func (UnimplementedPaystoreServer) testEmbeddedByValue()                  {}
 
@@ -186,6 +554,24 @@ func _Paystore_CreatePayment_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Paystore_CreateSplitPayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSplitPaymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaystoreServer).CreateSplitPayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Paystore_CreateSplitPayment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaystoreServer).CreateSplitPayment(ctx, req.(*CreateSplitPaymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Paystore_FinalizedPayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(FinalizedPaymentRequest)
 	if err := dec(in); err != nil {
@@ -240,32 +626,520 @@ func _Paystore_FinalizedWithdraw_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
-// Paystore_ServiceDesc is the grpc.ServiceDesc for Paystore service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var Paystore_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "paystore.Paystore",
-	HandlerType: (*PaystoreServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "CreateBalance",
-			Handler:    _Paystore_CreateBalance_Handler,
-		},
-		{
-			MethodName: "CreatePayment",
-			Handler:    _Paystore_CreatePayment_Handler,
-		},
-		{
-			MethodName: "FinalizedPayment",
-			Handler:    _Paystore_FinalizedPayment_Handler,
-		},
-		{
-			MethodName: "CreateWithdraw",
-			Handler:    _Paystore_CreateWithdraw_Handler,
-		},
-		{
-			MethodName: "FinalizedWithdraw",
-			Handler:    _Paystore_FinalizedWithdraw_Handler,
+func _Paystore_SearchPayments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchPaymentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaystoreServer).SearchPayments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Paystore_SearchPayments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaystoreServer).SearchPayments(ctx, req.(*SearchPaymentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Paystore_SearchWithdraws_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchWithdrawsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaystoreServer).SearchWithdraws(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Paystore_SearchWithdraws_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaystoreServer).SearchWithdraws(ctx, req.(*SearchWithdrawsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Paystore_GenerateStatement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateStatementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaystoreServer).GenerateStatement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Paystore_GenerateStatement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaystoreServer).GenerateStatement(ctx, req.(*GenerateStatementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Paystore_Reconcile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReconcileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaystoreServer).Reconcile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Paystore_Reconcile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaystoreServer).Reconcile(ctx, req.(*ReconcileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Paystore_GetReconciliationReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReconciliationReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaystoreServer).GetReconciliationReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Paystore_GetReconciliationReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaystoreServer).GetReconciliationReport(ctx, req.(*GetReconciliationReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Paystore_RegisterWebhookEndpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterWebhookEndpointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaystoreServer).RegisterWebhookEndpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Paystore_RegisterWebhookEndpoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaystoreServer).RegisterWebhookEndpoint(ctx, req.(*RegisterWebhookEndpointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Paystore_RemoveWebhookEndpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveWebhookEndpointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaystoreServer).RemoveWebhookEndpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Paystore_RemoveWebhookEndpoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaystoreServer).RemoveWebhookEndpoint(ctx, req.(*RemoveWebhookEndpointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Paystore_RotateWebhookSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateWebhookSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaystoreServer).RotateWebhookSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Paystore_RotateWebhookSecret_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaystoreServer).RotateWebhookSecret(ctx, req.(*RotateWebhookSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Paystore_ReplayWebhookDelivery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplayWebhookDeliveryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaystoreServer).ReplayWebhookDelivery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Paystore_ReplayWebhookDelivery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaystoreServer).ReplayWebhookDelivery(ctx, req.(*ReplayWebhookDeliveryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Paystore_SetFeeSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetFeeScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaystoreServer).SetFeeSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Paystore_SetFeeSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaystoreServer).SetFeeSchedule(ctx, req.(*SetFeeScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Paystore_GetFeeSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFeeScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaystoreServer).GetFeeSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Paystore_GetFeeSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaystoreServer).GetFeeSchedule(ctx, req.(*GetFeeScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Paystore_ListFeeSchedules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFeeSchedulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaystoreServer).ListFeeSchedules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Paystore_ListFeeSchedules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaystoreServer).ListFeeSchedules(ctx, req.(*ListFeeSchedulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Paystore_SetFeeRounding_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetFeeRoundingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaystoreServer).SetFeeRounding(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Paystore_SetFeeRounding_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaystoreServer).SetFeeRounding(ctx, req.(*SetFeeRoundingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Paystore_GetRevenueBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRevenueBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaystoreServer).GetRevenueBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Paystore_GetRevenueBalance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaystoreServer).GetRevenueBalance(ctx, req.(*GetRevenueBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Paystore_SetLimit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLimitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaystoreServer).SetLimit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Paystore_SetLimit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaystoreServer).SetLimit(ctx, req.(*SetLimitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Paystore_GetLimit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLimitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaystoreServer).GetLimit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Paystore_GetLimit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaystoreServer).GetLimit(ctx, req.(*GetLimitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Paystore_SetOrganizationParent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetOrganizationParentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaystoreServer).SetOrganizationParent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Paystore_SetOrganizationParent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaystoreServer).SetOrganizationParent(ctx, req.(*SetOrganizationParentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Paystore_GetBalanceRollup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBalanceRollupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaystoreServer).GetBalanceRollup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Paystore_GetBalanceRollup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaystoreServer).GetBalanceRollup(ctx, req.(*GetBalanceRollupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Paystore_RecomputeSettlement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecomputeSettlementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaystoreServer).RecomputeSettlement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Paystore_RecomputeSettlement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaystoreServer).RecomputeSettlement(ctx, req.(*RecomputeSettlementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Paystore_GetSettlementReports_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSettlementReportsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaystoreServer).GetSettlementReports(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Paystore_GetSettlementReports_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaystoreServer).GetSettlementReports(ctx, req.(*GetSettlementReportsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Paystore_SetOrganizationStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetOrganizationStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaystoreServer).SetOrganizationStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Paystore_SetOrganizationStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaystoreServer).SetOrganizationStatus(ctx, req.(*SetOrganizationStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Paystore_GetOrganizationStatusHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrganizationStatusHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaystoreServer).GetOrganizationStatusHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Paystore_GetOrganizationStatusHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaystoreServer).GetOrganizationStatusHistory(ctx, req.(*GetOrganizationStatusHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Paystore_ServiceDesc is the grpc.ServiceDesc for Paystore service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Paystore_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "paystore.Paystore",
+	HandlerType: (*PaystoreServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateBalance",
+			Handler:    _Paystore_CreateBalance_Handler,
+		},
+		{
+			MethodName: "CreatePayment",
+			Handler:    _Paystore_CreatePayment_Handler,
+		},
+		{
+			MethodName: "CreateSplitPayment",
+			Handler:    _Paystore_CreateSplitPayment_Handler,
+		},
+		{
+			MethodName: "FinalizedPayment",
+			Handler:    _Paystore_FinalizedPayment_Handler,
+		},
+		{
+			MethodName: "CreateWithdraw",
+			Handler:    _Paystore_CreateWithdraw_Handler,
+		},
+		{
+			MethodName: "FinalizedWithdraw",
+			Handler:    _Paystore_FinalizedWithdraw_Handler,
+		},
+		{
+			MethodName: "SearchPayments",
+			Handler:    _Paystore_SearchPayments_Handler,
+		},
+		{
+			MethodName: "SearchWithdraws",
+			Handler:    _Paystore_SearchWithdraws_Handler,
+		},
+		{
+			MethodName: "GenerateStatement",
+			Handler:    _Paystore_GenerateStatement_Handler,
+		},
+		{
+			MethodName: "Reconcile",
+			Handler:    _Paystore_Reconcile_Handler,
+		},
+		{
+			MethodName: "GetReconciliationReport",
+			Handler:    _Paystore_GetReconciliationReport_Handler,
+		},
+		{
+			MethodName: "RegisterWebhookEndpoint",
+			Handler:    _Paystore_RegisterWebhookEndpoint_Handler,
+		},
+		{
+			MethodName: "RemoveWebhookEndpoint",
+			Handler:    _Paystore_RemoveWebhookEndpoint_Handler,
+		},
+		{
+			MethodName: "RotateWebhookSecret",
+			Handler:    _Paystore_RotateWebhookSecret_Handler,
+		},
+		{
+			MethodName: "ReplayWebhookDelivery",
+			Handler:    _Paystore_ReplayWebhookDelivery_Handler,
+		},
+		{
+			MethodName: "SetFeeSchedule",
+			Handler:    _Paystore_SetFeeSchedule_Handler,
+		},
+		{
+			MethodName: "GetFeeSchedule",
+			Handler:    _Paystore_GetFeeSchedule_Handler,
+		},
+		{
+			MethodName: "ListFeeSchedules",
+			Handler:    _Paystore_ListFeeSchedules_Handler,
+		},
+		{
+			MethodName: "SetFeeRounding",
+			Handler:    _Paystore_SetFeeRounding_Handler,
+		},
+		{
+			MethodName: "GetRevenueBalance",
+			Handler:    _Paystore_GetRevenueBalance_Handler,
+		},
+		{
+			MethodName: "SetLimit",
+			Handler:    _Paystore_SetLimit_Handler,
+		},
+		{
+			MethodName: "GetLimit",
+			Handler:    _Paystore_GetLimit_Handler,
+		},
+		{
+			MethodName: "SetOrganizationParent",
+			Handler:    _Paystore_SetOrganizationParent_Handler,
+		},
+		{
+			MethodName: "GetBalanceRollup",
+			Handler:    _Paystore_GetBalanceRollup_Handler,
+		},
+		{
+			MethodName: "RecomputeSettlement",
+			Handler:    _Paystore_RecomputeSettlement_Handler,
+		},
+		{
+			MethodName: "GetSettlementReports",
+			Handler:    _Paystore_GetSettlementReports_Handler,
+		},
+		{
+			MethodName: "SetOrganizationStatus",
+			Handler:    _Paystore_SetOrganizationStatus_Handler,
+		},
+		{
+			MethodName: "GetOrganizationStatusHistory",
+			Handler:    _Paystore_GetOrganizationStatusHistory_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
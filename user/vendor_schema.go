@@ -0,0 +1,26 @@
+package user
+
+import (
+	_ "embed"
+	"paystore/lib/schema"
+)
+
+// DefaultVendorSchema maps webhook data from your payment provider to your database schema.
+// TODO: Customize vendor_schema.json to match your vendor's webhook format, or point
+// VENDOR_SCHEMA_FILE at a file with the same layout.
+//
+// Each column has a name in the vendor table, a type (text, integer, decimal, boolean,
+// timestamp or text[]), a dot separated JSON path in the webhook payload, and optionally
+// the role paystore reads it as. See lib/schema for the roles every schema must map.
+//
+//go:embed vendor_schema.json
+var DefaultVendorSchema []byte
+
+// defaultVendorSchemas parses DefaultVendorSchema for the deprecated vendor models.
+func defaultVendorSchemas() *schema.Schemas {
+	schemas, errParse := schema.Parse(DefaultVendorSchema)
+	if errParse != nil {
+		panic(errParse)
+	}
+	return schemas
+}